@@ -8,11 +8,20 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+	// Часовые пояса пользователей не зависят от tzdata в образе
+	_ "time/tzdata"
 
 	"test_avito/internal/api"
 	"test_avito/internal/api/handlers"
 	"test_avito/internal/database"
+	"test_avito/internal/integrations"
+	"test_avito/internal/integrations/github"
+	"test_avito/internal/integrations/gitlab"
+	"test_avito/internal/notify"
+	"test_avito/internal/outbox"
 	"test_avito/internal/repository"
+	"test_avito/internal/scheduler"
 	"test_avito/internal/service"
 	"test_avito/pkg/config"
 	"test_avito/pkg/logger"
@@ -28,6 +37,7 @@ func main() {
 	appLogger.Info("starting PR reviewer service",
 		"version", "1.0.0",
 		"port", cfg.Server.Port,
+		"reviewer_strategy", cfg.Reviewer.Strategy,
 	)
 
 	ctx := context.Background()
@@ -43,15 +53,159 @@ func main() {
 	userRepo := repository.NewUserRepository(db.Pool, appLogger)
 	prRepo := repository.NewPullRequestRepository(db.Pool, appLogger)
 	statsRepo := repository.NewStatsRepository(db.Pool, appLogger)
+	webhookRepo := repository.NewWebhookRepository(db.Pool, appLogger)
+	outboxRepo := repository.NewOutboxRepository(db.Pool, appLogger)
+	streamRepo := repository.NewStreamRepository(db.Pool, appLogger)
+
+	// Стратегия выбора ревьюеров
+	selector, err := service.NewReviewerSelector(cfg.Reviewer.Strategy, teamRepo)
+	if err != nil {
+		appLogger.Error("failed to create reviewer selector", "error", err)
+		os.Exit(1)
+	}
+
+	// Соответствие логинов GitHub и пользователей сервиса
+	githubLogins, err := integrations.ParseLoginMap(cfg.GitHub.Logins)
+	if err != nil {
+		appLogger.Error("failed to parse github logins", "error", err)
+		os.Exit(1)
+	}
+
+	// Назначения ревьюеров дублируются в GitHub в фоне, если задан токен
+	var codeHost service.CodeHostClient = service.NopCodeHost{}
+	var githubSync *service.AsyncCodeHost
+	if cfg.GitHub.Token != "" {
+		githubClient := github.NewClient(cfg.GitHub.APIURL, cfg.GitHub.Token, githubLogins, cfg.GitHub.APITimeout, appLogger)
+		githubSync = service.NewAsyncCodeHost(githubClient, cfg.GitHub.SyncQueueSize, appLogger)
+		codeHost = githubSync
+	}
 
 	// Инициализация сервисов
-	teamService := service.NewTeamService(teamRepo, userRepo, appLogger)
-	userService := service.NewUserService(userRepo, appLogger)
-	prService := service.NewPullRequestService(prRepo, userRepo, appLogger)
+	webhookService := service.NewWebhookService(webhookRepo, service.WebhookOptions{
+		MaxAttempts: cfg.Webhook.MaxAttempts,
+		BackoffBase: cfg.Webhook.BackoffBase,
+		BatchSize:   cfg.Webhook.BatchSize,
+		Timeout:     cfg.Webhook.Timeout,
+		Retention:   cfg.Webhook.Retention,
+	}, appLogger)
+
+	// Поток событий для SSE-клиентов
+	streamService := service.NewEventStreamService(streamRepo, userRepo, teamRepo, service.EventStreamOptions{
+		PollInterval:      cfg.Stream.PollInterval,
+		HeartbeatInterval: cfg.Stream.HeartbeatInterval,
+		BatchSize:         cfg.Stream.BatchSize,
+		Retention:         cfg.Stream.Retention,
+	}, appLogger)
+
+	// События получают подписчики webhooks, поток SSE и, если настроены чат или почта, назначенные ревьюеры
+	var events service.EventEmitter = service.MultiEmitter{webhookService, streamService}
+	var chatNotifier *notify.ChatNotifier
+	if cfg.Chat.WebhookURL != "" {
+		chatNotifier, err = notify.NewChatNotifier(userRepo, teamRepo, prRepo, notify.ChatOptions{
+			WebhookURL:     cfg.Chat.WebhookURL,
+			DefaultChannel: cfg.Chat.DefaultChannel,
+			Username:       cfg.Chat.Username,
+			PRLinkTemplate: cfg.Chat.PRLinkTemplate,
+			Timeout:        cfg.Chat.Timeout,
+		}, appLogger)
+		if err != nil {
+			appLogger.Error("failed to create chat notifier", "error", err)
+			os.Exit(1)
+		}
+		events = service.MultiEmitter{events, chatNotifier}
+	}
+
+	// Письма о назначениях и ежедневный дайджест, если настроен SMTP
+	var emailNotifier *notify.EmailNotifier
+	if cfg.Email.SMTPHost != "" {
+		emailNotifier, err = notify.NewEmailNotifier(userRepo, prRepo, notify.EmailOptions{
+			Host:           cfg.Email.SMTPHost,
+			Port:           cfg.Email.SMTPPort,
+			Username:       cfg.Email.SMTPUsername,
+			Password:       cfg.Email.SMTPPassword,
+			From:           cfg.Email.From,
+			PRLinkTemplate: cfg.Email.PRLinkTemplate,
+			DigestHour:     cfg.Email.DigestHour,
+			Timeout:        cfg.Email.Timeout,
+		}, appLogger)
+		if err != nil {
+			appLogger.Error("failed to create email notifier", "error", err)
+			os.Exit(1)
+		}
+		events = service.MultiEmitter{events, emailNotifier}
+	}
+
+	teamService := service.NewTeamService(teamRepo, userRepo, events, codeHost, appLogger)
+	userService := service.NewUserService(userRepo, events, codeHost, appLogger)
+	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo, selector, events, codeHost, appLogger)
 	statsService := service.NewStatsService(statsRepo, appLogger)
+	slaService := service.NewReviewSLAService(prRepo, prService, cfg.ReviewSLA.BatchSize, appLogger)
+
+	// Публикация событий из outbox
+	publisher, err := outbox.NewPublisher(cfg.Outbox.Publisher, cfg.Outbox.HTTPURL, cfg.Outbox.HTTPTimeout, appLogger)
+	if err != nil {
+		appLogger.Error("failed to create outbox publisher", "error", err)
+		os.Exit(1)
+	}
+	relay := outbox.NewRelay(outboxRepo, publisher, outbox.Options{
+		BatchSize: cfg.Outbox.BatchSize,
+		Timeout:   cfg.Outbox.HTTPTimeout,
+		Retention: cfg.Outbox.Retention,
+	}, appLogger)
+
+	// Фоновые задачи
+	sched := scheduler.New(appLogger)
+	if cfg.ReviewSLA.CheckInterval > 0 {
+		sched.Every("review_sla", cfg.ReviewSLA.CheckInterval, func(ctx context.Context) error {
+			_, err := slaService.CheckOverdueReviews(ctx, time.Now())
+			return err
+		})
+	}
+	if cfg.Webhook.DeliveryInterval > 0 {
+		sched.Every("webhook_delivery", cfg.Webhook.DeliveryInterval, func(ctx context.Context) error {
+			_, err := webhookService.DeliverDue(ctx, time.Now())
+			return err
+		})
+	}
+	if cfg.Outbox.RelayInterval > 0 {
+		sched.Every("outbox_relay", cfg.Outbox.RelayInterval, func(ctx context.Context) error {
+			_, err := relay.RunOnce(ctx, time.Now())
+			return err
+		})
+	}
+	if cfg.Stream.CleanupInterval > 0 {
+		sched.Every("event_stream_cleanup", cfg.Stream.CleanupInterval, func(ctx context.Context) error {
+			_, err := streamService.DeleteExpired(ctx, time.Now())
+			return err
+		})
+	}
+	if emailNotifier != nil && cfg.Email.DigestInterval > 0 {
+		sched.Every("email_digest", cfg.Email.DigestInterval, func(ctx context.Context) error {
+			_, err := emailNotifier.SendDigests(ctx, time.Now())
+			return err
+		})
+	}
+	sched.Start(context.Background())
+
+	// Прием webhook-событий GitHub
+	var githubReceiver *github.Receiver
+	if cfg.GitHub.WebhookSecret != "" {
+		githubReceiver = github.NewReceiver(cfg.GitHub.WebhookSecret, githubLogins, prService, appLogger)
+	}
+
+	// Прием webhook-событий GitLab
+	var gitlabReceiver *gitlab.Receiver
+	if cfg.GitLab.WebhookToken != "" {
+		logins, err := integrations.ParseLoginMap(cfg.GitLab.Logins)
+		if err != nil {
+			appLogger.Error("failed to parse gitlab logins", "error", err)
+			os.Exit(1)
+		}
+		gitlabReceiver = gitlab.NewReceiver(cfg.GitLab.WebhookToken, logins, prService, appLogger)
+	}
 
 	// Инициализация хендлеров
-	handler := handlers.NewHandler(teamService, userService, prService, statsService, appLogger)
+	handler := handlers.NewHandler(teamService, userService, prService, statsService, webhookService, streamService, githubReceiver, gitlabReceiver, cfg.Merge.ForceToken, appLogger)
 
 	// Инициализация роутера и мидлваре
 	router := api.NewRouter(handler, appLogger)
@@ -62,6 +216,8 @@ func main() {
 		ReadTimeout:  cfg.Server.ReadTimeout,
 		WriteTimeout: cfg.Server.WriteTimeout,
 	}
+	// Shutdown ждет, пока соединения освободятся, а поток SSE сам не завершается
+	srv.RegisterOnShutdown(streamService.Close)
 
 	go func() {
 		appLogger.Info("server listening", "address", srv.Addr)
@@ -86,5 +242,27 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := sched.Stop(ctx); err != nil {
+		appLogger.Error("scheduler forced to stop", "error", err)
+	}
+
+	if githubSync != nil {
+		if err := githubSync.Close(ctx); err != nil {
+			appLogger.Error("github reviewer sync dropped on shutdown", "error", err)
+		}
+	}
+
+	if chatNotifier != nil {
+		if err := chatNotifier.Wait(ctx); err != nil {
+			appLogger.Error("chat notifications dropped on shutdown", "error", err)
+		}
+	}
+
+	if emailNotifier != nil {
+		if err := emailNotifier.Wait(ctx); err != nil {
+			appLogger.Error("email notifications dropped on shutdown", "error", err)
+		}
+	}
+
 	appLogger.Info("server stopped gracefully")
 }
@@ -10,28 +10,171 @@ import (
 // Defines values for ErrorResponseErrorCode.
 const (
 	BADREQUEST           ErrorResponseErrorCode = "BAD_REQUEST"
+	FORBIDDEN            ErrorResponseErrorCode = "FORBIDDEN"
+	HASOPENREVIEWS       ErrorResponseErrorCode = "HAS_OPEN_REVIEWS"
 	INTERNALERROR        ErrorResponseErrorCode = "INTERNAL_ERROR"
+	INVALIDTRANSITION    ErrorResponseErrorCode = "INVALID_TRANSITION"
 	NOCANDIDATE          ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTAPPROVED          ErrorResponseErrorCode = "NOT_APPROVED"
 	NOTASSIGNED          ErrorResponseErrorCode = "NOT_ASSIGNED"
 	NOTFOUND             ErrorResponseErrorCode = "NOT_FOUND"
+	PRCLOSED             ErrorResponseErrorCode = "PR_CLOSED"
+	PRDRAFT              ErrorResponseErrorCode = "PR_DRAFT"
 	PREXISTS             ErrorResponseErrorCode = "PR_EXISTS"
 	PRMERGED             ErrorResponseErrorCode = "PR_MERGED"
+	REVIEWERATCAPACITY   ErrorResponseErrorCode = "REVIEWER_AT_CAPACITY"
 	REVIEWERSASSIGNED    ErrorResponseErrorCode = "REVIEWERS_ASSIGNED"
+	UNAUTHORIZED         ErrorResponseErrorCode = "UNAUTHORIZED"
 	UNSUPPORTEDMEDIATYPE ErrorResponseErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	USERINOTHERTEAM      ErrorResponseErrorCode = "USER_IN_OTHER_TEAM"
+	USERISTEAMLEAD       ErrorResponseErrorCode = "USER_IS_TEAM_LEAD"
+)
+
+// Defines values for IntegrationResultStatus.
+const (
+	Applied IntegrationResultStatus = "applied"
+	Ignored IntegrationResultStatus = "ignored"
+)
+
+// Defines values for PRHistoryEntryEventType.
+const (
+	Created            PRHistoryEntryEventType = "created"
+	Merged             PRHistoryEntryEventType = "merged"
+	ReviewSubmitted    PRHistoryEntryEventType = "review_submitted"
+	ReviewerAssigned   PRHistoryEntryEventType = "reviewer_assigned"
+	ReviewerReassigned PRHistoryEntryEventType = "reviewer_reassigned"
+	ReviewerRemoved    PRHistoryEntryEventType = "reviewer_removed"
+	StatusChanged      PRHistoryEntryEventType = "status_changed"
 )
 
 // Defines values for PullRequestStatus.
 const (
+	PullRequestStatusCLOSED PullRequestStatus = "CLOSED"
+	PullRequestStatusDRAFT  PullRequestStatus = "DRAFT"
 	PullRequestStatusMERGED PullRequestStatus = "MERGED"
 	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
 )
 
 // Defines values for PullRequestShortStatus.
 const (
+	PullRequestShortStatusCLOSED PullRequestShortStatus = "CLOSED"
+	PullRequestShortStatusDRAFT  PullRequestShortStatus = "DRAFT"
 	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
 	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
 )
 
+// Defines values for PullRequestSummaryStatus.
+const (
+	PullRequestSummaryStatusCLOSED PullRequestSummaryStatus = "CLOSED"
+	PullRequestSummaryStatusDRAFT  PullRequestSummaryStatus = "DRAFT"
+	PullRequestSummaryStatusMERGED PullRequestSummaryStatus = "MERGED"
+	PullRequestSummaryStatusOPEN   PullRequestSummaryStatus = "OPEN"
+)
+
+// Defines values for ReviewState.
+const (
+	ReviewStateAPPROVED         ReviewState = "APPROVED"
+	ReviewStateCHANGESREQUESTED ReviewState = "CHANGES_REQUESTED"
+	ReviewStateCOMMENTED        ReviewState = "COMMENTED"
+	ReviewStatePENDING          ReviewState = "PENDING"
+)
+
+// Defines values for TeamAddRequestMode.
+const (
+	Replace TeamAddRequestMode = "replace"
+	Upsert  TeamAddRequestMode = "upsert"
+)
+
+// Defines values for TeamAddRequestReviewPolicy.
+const (
+	TeamAddRequestReviewPolicyFail     TeamAddRequestReviewPolicy = "fail"
+	TeamAddRequestReviewPolicyKeep     TeamAddRequestReviewPolicy = "keep"
+	TeamAddRequestReviewPolicyReassign TeamAddRequestReviewPolicy = "reassign"
+)
+
+// Defines values for TeamMembershipChangeReviewPolicy.
+const (
+	TeamMembershipChangeReviewPolicyFail     TeamMembershipChangeReviewPolicy = "fail"
+	TeamMembershipChangeReviewPolicyKeep     TeamMembershipChangeReviewPolicy = "keep"
+	TeamMembershipChangeReviewPolicyReassign TeamMembershipChangeReviewPolicy = "reassign"
+)
+
+// Defines values for TeamMembershipChangeSource.
+const (
+	TeamAdd  TeamMembershipChangeSource = "team_add"
+	Transfer TeamMembershipChangeSource = "transfer"
+)
+
+// Defines values for TeamPolicySlaAction.
+const (
+	TeamPolicySlaActionEscalate TeamPolicySlaAction = "escalate"
+	TeamPolicySlaActionReassign TeamPolicySlaAction = "reassign"
+)
+
+// Defines values for WebhookEventType.
+const (
+	PrCreated            WebhookEventType = "pr.created"
+	PrMerged             WebhookEventType = "pr.merged"
+	PrReviewerReassigned WebhookEventType = "pr.reviewer_reassigned"
+	PrReviewersAssigned  WebhookEventType = "pr.reviewers_assigned"
+	TeamDeactivated      WebhookEventType = "team.deactivated"
+	UserDeactivated      WebhookEventType = "user.deactivated"
+	UserTransferred      WebhookEventType = "user.transferred"
+)
+
+// Defines values for GetPullRequestListParamsSort.
+const (
+	GetPullRequestListParamsSortCreatedAtAsc  GetPullRequestListParamsSort = "created_at_asc"
+	GetPullRequestListParamsSortCreatedAtDesc GetPullRequestListParamsSort = "created_at_desc"
+)
+
+// Defines values for GetPullRequestListParamsStatus.
+const (
+	GetPullRequestListParamsStatusCLOSED GetPullRequestListParamsStatus = "CLOSED"
+	GetPullRequestListParamsStatusDRAFT  GetPullRequestListParamsStatus = "DRAFT"
+	GetPullRequestListParamsStatusMERGED GetPullRequestListParamsStatus = "MERGED"
+	GetPullRequestListParamsStatusOPEN   GetPullRequestListParamsStatus = "OPEN"
+)
+
+// Defines values for GetUsersGetReviewParamsSort.
+const (
+	GetUsersGetReviewParamsSortCreatedAtAsc  GetUsersGetReviewParamsSort = "created_at_asc"
+	GetUsersGetReviewParamsSortCreatedAtDesc GetUsersGetReviewParamsSort = "created_at_desc"
+)
+
+// Defines values for GetUsersGetReviewParamsStatus.
+const (
+	GetUsersGetReviewParamsStatusCLOSED GetUsersGetReviewParamsStatus = "CLOSED"
+	GetUsersGetReviewParamsStatusDRAFT  GetUsersGetReviewParamsStatus = "DRAFT"
+	GetUsersGetReviewParamsStatusMERGED GetUsersGetReviewParamsStatus = "MERGED"
+	GetUsersGetReviewParamsStatusOPEN   GetUsersGetReviewParamsStatus = "OPEN"
+)
+
+// Defines values for PostPullRequestReviewJSONBodyState.
+const (
+	PostPullRequestReviewJSONBodyStateAPPROVED         PostPullRequestReviewJSONBodyState = "APPROVED"
+	PostPullRequestReviewJSONBodyStateCHANGESREQUESTED PostPullRequestReviewJSONBodyState = "CHANGES_REQUESTED"
+	PostPullRequestReviewJSONBodyStateCOMMENTED        PostPullRequestReviewJSONBodyState = "COMMENTED"
+)
+
+// Defines values for PostUsersTransferJSONBodyReviewPolicy.
+const (
+	PostUsersTransferJSONBodyReviewPolicyFail     PostUsersTransferJSONBodyReviewPolicy = "fail"
+	PostUsersTransferJSONBodyReviewPolicyKeep     PostUsersTransferJSONBodyReviewPolicy = "keep"
+	PostUsersTransferJSONBodyReviewPolicyReassign PostUsersTransferJSONBodyReviewPolicy = "reassign"
+)
+
+// DirectoryUser Пользователь в справочнике (/users/get, /users/search) — без email и настроек уведомлений
+type DirectoryUser struct {
+	IsActive bool `json:"is_active"`
+
+	// MaxOpenReviews Максимальное число открытых ревью (null — без ограничения)
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+	TeamName       string `json:"team_name"`
+	UserId         string `json:"user_id"`
+	Username       string `json:"username"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
@@ -43,21 +186,94 @@ type ErrorResponse struct {
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// IntegrationResult defines model for IntegrationResult.
+type IntegrationResult struct {
+	// Action Действие события (например, `opened`)
+	Action *string `json:"action,omitempty"`
+
+	// Event Тип события code host (например, `pull_request`)
+	Event string `json:"event"`
+
+	// PullRequestId ID PR в сервисе
+	PullRequestId *string `json:"pull_request_id,omitempty"`
+
+	// Reason Причина, по которой событие пропущено
+	Reason *string `json:"reason,omitempty"`
+
+	// Status applied — событие применено, ignored — подтверждено без изменений
+	Status IntegrationResultStatus `json:"status"`
+}
+
+// IntegrationResultStatus applied — событие применено, ignored — подтверждено без изменений
+type IntegrationResultStatus string
+
+// PRHistoryEntry Запись истории PR; заполнены только поля, относящиеся к event_type
+type PRHistoryEntry struct {
+	// Actor Кто сделал изменение (заголовок `X-Actor` до 255 символов; `github`, `gitlab` и `review_sla` зарезервированы за сервисом)
+	Actor     *string                 `json:"actor,omitempty"`
+	CreatedAt time.Time               `json:"created_at"`
+	EventType PRHistoryEntryEventType `json:"event_type"`
+
+	// FromStatus Статус PR до изменения
+	FromStatus *string `json:"from_status,omitempty"`
+	Id         int64   `json:"id"`
+
+	// OldReviewerId Замененный ревьювер (reviewer_reassigned)
+	OldReviewerId *string `json:"old_reviewer_id,omitempty"`
+	PullRequestId string  `json:"pull_request_id"`
+
+	// Reason Почему выбран ревьювер: стратегия выбора (`random`, `round_robin`, `least_loaded`),
+	// `explicit` — указан в запросе, `deactivation` — замена при деактивации,
+	// `transfer` — замена при переводе в другую команду; `force` — merge в обход правила команды
+	Reason *string `json:"reason,omitempty"`
+
+	// RequestId X-Request-ID запроса, которым сделано изменение
+	RequestId *string `json:"request_id,omitempty"`
+
+	// ReviewState Отправленное состояние ревью (review_submitted)
+	ReviewState *string `json:"review_state,omitempty"`
+
+	// ReviewerId Назначенный, снятый или новый ревьювер; автор ревью для review_submitted
+	ReviewerId *string `json:"reviewer_id,omitempty"`
+
+	// ToStatus Статус PR после изменения
+	ToStatus *string `json:"to_status,omitempty"`
+}
+
+// PRHistoryEntryEventType defines model for PRHistoryEntry.EventType.
+type PRHistoryEntryEventType string
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
-	// AssignedReviewers user_id назначенных ревьюверов (0..2)
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	AuthorId          string            `json:"author_id"`
-	CreatedAt         *time.Time        `json:"createdAt"`
-	MergedAt          *time.Time        `json:"mergedAt"`
-	PullRequestId     string            `json:"pull_request_id"`
-	PullRequestName   string            `json:"pull_request_name"`
-	Status            PullRequestStatus `json:"status"`
+	// AssignedReviewers user_id назначенных ревьюверов (0..max_reviewers политики команды)
+	AssignedReviewers []string `json:"assigned_reviewers"`
+	AuthorId          string   `json:"author_id"`
+
+	// ClosedAt Время закрытия PR без merge (только для CLOSED)
+	ClosedAt  *time.Time `json:"closedAt"`
+	CreatedAt *time.Time `json:"createdAt"`
+
+	// ForceMerged PR смержен принудительно в обход правила merge команды
+	ForceMerged     *bool      `json:"force_merged,omitempty"`
+	MergedAt        *time.Time `json:"mergedAt"`
+	PullRequestId   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+
+	// Reviews Состояние ревью каждого назначенного ревьювера
+	Reviews *[]Review         `json:"reviews,omitempty"`
+	Status  PullRequestStatus `json:"status"`
 }
 
 // PullRequestStatus defines model for PullRequest.Status.
 type PullRequestStatus string
 
+// PullRequestPage defines model for PullRequestPage.
+type PullRequestPage struct {
+	// NextCursor Курсор следующей страницы; отсутствует на последней
+	NextCursor   *string              `json:"next_cursor,omitempty"`
+	PullRequests []PullRequestSummary `json:"pull_requests"`
+}
+
 // PullRequestShort defines model for PullRequestShort.
 type PullRequestShort struct {
 	AuthorId        string                 `json:"author_id"`
@@ -69,6 +285,63 @@ type PullRequestShort struct {
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
+// PullRequestSummary PR в списке — с ревьюверами и командой автора, без состояний ревью
+type PullRequestSummary struct {
+	// AssignedReviewers user_id назначенных ревьюверов в порядке назначения
+	AssignedReviewers []string                 `json:"assigned_reviewers"`
+	AuthorId          string                   `json:"author_id"`
+	ClosedAt          *time.Time               `json:"closedAt"`
+	CreatedAt         time.Time                `json:"createdAt"`
+	MergedAt          *time.Time               `json:"mergedAt"`
+	PullRequestId     string                   `json:"pull_request_id"`
+	PullRequestName   string                   `json:"pull_request_name"`
+	Status            PullRequestSummaryStatus `json:"status"`
+
+	// TeamName Команда автора PR
+	TeamName string `json:"team_name"`
+}
+
+// PullRequestSummaryStatus defines model for PullRequestSummary.Status.
+type PullRequestSummaryStatus string
+
+// ReassignmentReport defines model for ReassignmentReport.
+type ReassignmentReport struct {
+	// LeftShort Открытые PR, где ревьювер снят без замены (нет подходящих кандидатов)
+	LeftShort []ReviewerReassignment `json:"left_short"`
+
+	// Reassigned Открытые PR, где ревьювер заменён активным участником команды
+	Reassigned []ReviewerReassignment `json:"reassigned"`
+}
+
+// Review defines model for Review.
+type Review struct {
+	// EscalatedAt Время эскалации просроченного ревью, которое некому было передать
+	EscalatedAt *time.Time  `json:"escalated_at,omitempty"`
+	State       ReviewState `json:"state"`
+
+	// StateUpdatedAt Время последнего изменения состояния (отсутствует, пока ревью не отправлено)
+	StateUpdatedAt *time.Time `json:"state_updated_at,omitempty"`
+	UserId         string     `json:"user_id"`
+}
+
+// ReviewState defines model for Review.State.
+type ReviewState string
+
+// ReviewerReassignment defines model for ReviewerReassignment.
+type ReviewerReassignment struct {
+	// NewReviewerId Новый ревьювер (отсутствует, если замена не найдена)
+	NewReviewerId *string `json:"new_reviewer_id,omitempty"`
+	OldReviewerId string  `json:"old_reviewer_id"`
+	PullRequestId string  `json:"pull_request_id"`
+}
+
+// QuietHours Тихие часы в часовом поясе пользователя: с `start` до `end` (не включая) письма не отправляются.
+// Если `start` больше `end`, интервал переходит через полночь (22–7).
+type QuietHours struct {
+	End   int `json:"end"`
+	Start int `json:"start"`
+}
+
 // ShortUser defines model for ShortUser.
 type ShortUser struct {
 	UserId string `json:"user_id"`
@@ -79,6 +352,12 @@ type Stats struct {
 	// ActiveUsers Количество активных пользователей
 	ActiveUsers *int `json:"active_users,omitempty"`
 
+	// ClosedPrs Количество закрытых без merge PR
+	ClosedPrs *int `json:"closed_prs,omitempty"`
+
+	// DraftPrs Количество PR в черновике
+	DraftPrs *int `json:"draft_prs,omitempty"`
+
 	// MergedPrs Количество смерженных PR
 	MergedPrs *int `json:"merged_prs,omitempty"`
 
@@ -98,22 +377,179 @@ type Stats struct {
 // Team defines model for Team.
 type Team struct {
 	Members  []TeamMember `json:"members"`
+	Policy   *TeamPolicy  `json:"policy,omitempty"`
 	TeamName string       `json:"team_name"`
 }
 
+// TeamAddRequest defines model for TeamAddRequest.
+type TeamAddRequest struct {
+	// AllowMoves Разрешить забрать участников из других команд (без него — `409 USER_IN_OTHER_TEAM`)
+	AllowMoves *bool        `json:"allow_moves,omitempty"`
+	Members    []TeamMember `json:"members"`
+
+	// Mode `upsert` — создать/обновить перечисленных участников, остальных не трогать;
+	// `replace` — дополнительно деактивировать активных участников, которых нет в запросе
+	Mode   *TeamAddRequestMode `json:"mode,omitempty"`
+	Policy *TeamPolicy         `json:"policy,omitempty"`
+
+	// ReviewPolicy Что делать с ревью забранных участников на открытых PR — как в `/users/transfer`
+	ReviewPolicy *TeamAddRequestReviewPolicy `json:"review_policy,omitempty"`
+	TeamName     string                      `json:"team_name"`
+}
+
+// TeamAddRequestMode `upsert` — создать/обновить перечисленных участников, остальных не трогать;
+// `replace` — дополнительно деактивировать активных участников, которых нет в запросе
+type TeamAddRequestMode string
+
+// TeamAddRequestReviewPolicy Что делать с ревью забранных участников на открытых PR — как в `/users/transfer`
+type TeamAddRequestReviewPolicy string
+
 // TeamMember defines model for TeamMember.
 type TeamMember struct {
-	IsActive bool   `json:"is_active"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	IsActive bool `json:"is_active"`
+
+	// MaxOpenReviews Максимальное число открытых ревью. Для нового участника null — без ограничения;
+	// у существующего null или отсутствие поля сохраняет текущий лимит (снять его можно через `/users/update`)
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+	UserId         string `json:"user_id"`
+	Username       string `json:"username"`
+}
+
+// TeamMembershipChange Запись истории членства пользователя в командах
+type TeamMembershipChange struct {
+	// Actor Кто перевёл пользователя (заголовок `X-Actor`)
+	Actor     *string   `json:"actor,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	FromTeam  string    `json:"from_team"`
+	Id        int64     `json:"id"`
+
+	// RequestId X-Request-ID запроса, которым сделан перевод
+	RequestId *string `json:"request_id,omitempty"`
+
+	// ReviewPolicy Что сделано с открытыми ревью
+	ReviewPolicy *TeamMembershipChangeReviewPolicy `json:"review_policy,omitempty"`
+
+	// Source `transfer` — через /users/transfer, `team_add` — через /team/add с `allow_moves`
+	Source TeamMembershipChangeSource `json:"source"`
+	ToTeam string                     `json:"to_team"`
+	UserId string                     `json:"user_id"`
+}
+
+// TeamMembershipChangeReviewPolicy Что сделано с открытыми ревью
+type TeamMembershipChangeReviewPolicy string
+
+// TeamMembershipChangeSource `transfer` — через /users/transfer, `team_add` — через /team/add с `allow_moves`
+type TeamMembershipChangeSource string
+
+// TeamMembershipChanges Изменения состава команды в режиме `replace`; участники без изменений не перечисляются
+type TeamMembershipChanges struct {
+	// Added Новые пользователи
+	Added []string `json:"added"`
+
+	// Moved Пользователи, перешедшие из другой команды
+	Moved        []string           `json:"moved"`
+	Reassignment ReassignmentReport `json:"reassignment"`
+
+	// Removed Деактивированные участники, которых нет в запросе (остаются в команде неактивными)
+	Removed []string `json:"removed"`
+
+	// Updated Участники команды с изменёнными атрибутами
+	Updated []string `json:"updated"`
+}
+
+// TeamPolicy defines model for TeamPolicy.
+type TeamPolicy struct {
+	// BlockOnChangesRequested Запрещать merge, пока у PR есть ревью CHANGES_REQUESTED
+	BlockOnChangesRequested *bool `json:"block_on_changes_requested,omitempty"`
+
+	// ChatChannel Канал чата для уведомлений ревьюверов команды (null — CHAT_DEFAULT_CHANNEL)
+	ChatChannel *string `json:"chat_channel"`
+
+	// ChatTemplate Шаблон уведомления (Go text/template; null — шаблон по умолчанию)
+	ChatTemplate *string `json:"chat_template"`
+
+	// LeadUserId Лид команды, которому эскалируются просроченные ревью (обязателен для escalate)
+	LeadUserId *string `json:"lead_user_id"`
+
+	// MaxReviewers Максимальное число ревьюверов на PR (автоназначение выбирает до этого числа)
+	MaxReviewers int `json:"max_reviewers"`
+
+	// MinReviewers Минимальное число ревьюверов на PR
+	MinReviewers *int `json:"min_reviewers,omitempty"`
+
+	// RequiredApprovals Сколько одобрений (APPROVED) нужно для merge (не больше max_reviewers, 0 — без требования)
+	RequiredApprovals *int `json:"required_approvals,omitempty"`
+
+	// ReviewSlaMinutes Через сколько минут ожидающее (PENDING) ревью считается просроченным (0 — SLA отключён)
+	ReviewSlaMinutes *int `json:"review_sla_minutes,omitempty"`
+
+	// SlaAction Что делать с просроченным ревью — переназначить или передать лиду команды
+	SlaAction *TeamPolicySlaAction `json:"sla_action,omitempty"`
+}
+
+// TeamPolicySlaAction Что делать с просроченным ревью — переназначить или передать лиду команды
+type TeamPolicySlaAction string
+
+// TeamSummary defines model for TeamSummary.
+type TeamSummary struct {
+	// ActiveCount Число активных участников; команда активна, пока оно больше нуля
+	ActiveCount int `json:"active_count"`
+
+	// MemberCount Число участников команды
+	MemberCount int    `json:"member_count"`
+	TeamName    string `json:"team_name"`
 }
 
 // User defines model for User.
 type User struct {
-	IsActive bool   `json:"is_active"`
-	TeamName string `json:"team_name"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	// Email Адрес для писем о назначениях и ежедневного дайджеста (null — писем нет)
+	Email *string `json:"email"`
+
+	// EmailOptOut Пользователь отказался от писем
+	EmailOptOut *bool `json:"email_opt_out,omitempty"`
+	IsActive    bool  `json:"is_active"`
+
+	// MaxOpenReviews Максимальное число открытых ревью (null — без ограничения)
+	MaxOpenReviews *int `json:"max_open_reviews"`
+
+	// QuietHours Тихие часы в часовом поясе пользователя: с `start` до `end` (не включая) письма не отправляются.
+	// Если `start` больше `end`, интервал переходит через полночь (22–7).
+	QuietHours *QuietHours `json:"quiet_hours"`
+	TeamName   string      `json:"team_name"`
+
+	// Timezone Часовой пояс IANA для тихих часов и времени дайджеста
+	Timezone *string `json:"timezone,omitempty"`
+	UserId   string  `json:"user_id"`
+	Username string  `json:"username"`
+}
+
+// WebhookDeadLetter defines model for WebhookDeadLetter.
+type WebhookDeadLetter struct {
+	// Attempts Число выполненных попыток доставки
+	Attempts  int              `json:"attempts"`
+	EventType WebhookEventType `json:"event_type"`
+	FailedAt  time.Time        `json:"failed_at"`
+	Id        int64            `json:"id"`
+
+	// LastError Ошибка последней попытки
+	LastError string `json:"last_error"`
+
+	// Payload Тело события в том виде, в котором оно отправлялось
+	Payload        map[string]interface{} `json:"payload"`
+	SubscriptionId int64                  `json:"subscription_id"`
+}
+
+// WebhookEventType defines model for WebhookEventType.
+type WebhookEventType string
+
+// WebhookSubscription defines model for WebhookSubscription.
+type WebhookSubscription struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Events Типы событий подписки (пустой список — все события)
+	Events []WebhookEventType `json:"events"`
+	Id     int64              `json:"id"`
+	Url    string             `json:"url"`
 }
 
 // TeamNameQuery defines model for TeamNameQuery.
@@ -131,33 +567,185 @@ type InternalError = ErrorResponse
 // UnsupportedMediaType defines model for UnsupportedMediaType.
 type UnsupportedMediaType = ErrorResponse
 
+// GetEventsStreamParams defines parameters for GetEventsStream.
+type GetEventsStreamParams struct {
+	// TeamName Только события PR авторов команды и деактивации ее участников
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// UserId Только события, где пользователь — автор PR или ревьювер
+	UserId *string `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// LastEventId Продолжить после события с этим id (если нет заголовка `Last-Event-ID`)
+	LastEventId *int64 `form:"last_event_id,omitempty" json:"last_event_id,omitempty"`
+
+	// LastEventID id последнего полученного события, браузер передает его при переподключении
+	LastEventID *int64 `json:"Last-Event-ID,omitempty"`
+}
+
+// PostIntegrationsGithubWebhookJSONBody defines parameters for PostIntegrationsGithubWebhook.
+type PostIntegrationsGithubWebhookJSONBody map[string]interface{}
+
+// PostIntegrationsGithubWebhookParams defines parameters for PostIntegrationsGithubWebhook.
+type PostIntegrationsGithubWebhookParams struct {
+	// XGitHubEvent Тип события
+	XGitHubEvent string `json:"X-GitHub-Event"`
+
+	// XHubSignature256 sha256=<hex>
+	XHubSignature256 string `json:"X-Hub-Signature-256"`
+
+	// XGitHubDelivery ID доставки
+	XGitHubDelivery *string `json:"X-GitHub-Delivery,omitempty"`
+}
+
+// PostIntegrationsGitlabWebhookJSONBody defines parameters for PostIntegrationsGitlabWebhook.
+type PostIntegrationsGitlabWebhookJSONBody map[string]interface{}
+
+// PostIntegrationsGitlabWebhookParams defines parameters for PostIntegrationsGitlabWebhook.
+type PostIntegrationsGitlabWebhookParams struct {
+	// XGitlabEvent Тип события (`Merge Request Hook`)
+	XGitlabEvent string `json:"X-Gitlab-Event"`
+
+	// XGitlabToken Секретный токен webhook
+	XGitlabToken string `json:"X-Gitlab-Token"`
+}
+
+// PostPullRequestAddReviewerJSONBody defines parameters for PostPullRequestAddReviewer.
+type PostPullRequestAddReviewerJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+
+	// UserId Ревьювер для добавления (если не указан — выбирается автоматически)
+	UserId *string `json:"user_id,omitempty"`
+}
+
 // PostPullRequestAssignJSONBody defines parameters for PostPullRequestAssign.
 type PostPullRequestAssignJSONBody struct {
 	// PullRequestId ID существующего PR
 	PullRequestId string `json:"pull_request_id"`
 
-	// ReviewerIds Опциональный список ревьюверов для назначения (до 2)
+	// ReviewerIds Опциональный список ревьюверов для назначения (в пределах политики команды)
 	ReviewerIds *[]ShortUser `json:"reviewer_ids,omitempty"`
 }
 
+// PostPullRequestCloseJSONBody defines parameters for PostPullRequestClose.
+type PostPullRequestCloseJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
-	AuthorId        string `json:"author_id"`
+	AuthorId string `json:"author_id"`
+
+	// Draft Создать PR в статусе DRAFT без назначения ревьюверов
+	Draft           *bool  `json:"draft,omitempty"`
 	PullRequestId   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 }
 
+// GetPullRequestGetParams defines parameters for GetPullRequestGet.
+type GetPullRequestGetParams struct {
+	// PullRequestId Идентификатор PR
+	PullRequestId string `form:"pull_request_id" json:"pull_request_id"`
+}
+
+// GetPullRequestHistoryParams defines parameters for GetPullRequestHistory.
+type GetPullRequestHistoryParams struct {
+	// PullRequestId Идентификатор PR
+	PullRequestId string `form:"pull_request_id" json:"pull_request_id"`
+}
+
+// GetPullRequestListParams defines parameters for GetPullRequestList.
+type GetPullRequestListParams struct {
+	// AuthorId Только PR этого автора
+	AuthorId *string `form:"author_id,omitempty" json:"author_id,omitempty"`
+
+	// TeamName Только PR авторов из этой команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// Status Только PR в этом статусе
+	Status *GetPullRequestListParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// ReviewerId Только PR, где пользователь назначен ревьювером
+	ReviewerId *string `form:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+
+	// NoReviewers Только PR без назначенных ревьюверов (нельзя вместе с `reviewer_id`)
+	NoReviewers *bool `form:"no_reviewers,omitempty" json:"no_reviewers,omitempty"`
+
+	// CreatedAfter PR, созданные не раньше этого момента (RFC 3339)
+	CreatedAfter *time.Time `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore PR, созданные раньше этого момента (RFC 3339)
+	CreatedBefore *time.Time `form:"created_before,omitempty" json:"created_before,omitempty"`
+
+	// Name Подстрока названия PR без учета регистра
+	Name *string `form:"name,omitempty" json:"name,omitempty"`
+
+	// Search Полнотекстовый поиск по словам названия PR: слова через пробел — все должны встретиться,
+	// `"фраза"` — подряд, `or` — любое из слов, `-слово` — исключить
+	Search *string `form:"search,omitempty" json:"search,omitempty"`
+
+	// Sort Порядок по дате создания, при равенстве — по ID PR
+	Sort *GetPullRequestListParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Limit Размер страницы
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor `next_cursor` предыдущей страницы
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetPullRequestListParamsSort defines parameters for GetPullRequestList.
+type GetPullRequestListParamsSort string
+
+// GetPullRequestListParamsStatus defines parameters for GetPullRequestList.
+type GetPullRequestListParamsStatus string
+
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
 type PostPullRequestMergeJSONBody struct {
+	// Force Смержить в обход правила merge команды (административное действие, требует `X-Admin-Token`)
+	Force         *bool  `json:"force,omitempty"`
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestMergeParams defines parameters for PostPullRequestMerge.
+type PostPullRequestMergeParams struct {
+	// XAdminToken Административный токен (`MERGE_FORCE_TOKEN`), нужен только для `force`
+	XAdminToken *string `json:"X-Admin-Token,omitempty"`
+}
+
+// PostPullRequestReadyJSONBody defines parameters for PostPullRequestReady.
+type PostPullRequestReadyJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
 // PostPullRequestReassignJSONBody defines parameters for PostPullRequestReassign.
 type PostPullRequestReassignJSONBody struct {
-	OldUserId     string `json:"old_user_id"`
+	// NewUserId Явно выбранный новый ревьювер (если не указан — выбирается автоматически)
+	NewUserId     *string `json:"new_user_id,omitempty"`
+	OldUserId     string  `json:"old_user_id"`
+	PullRequestId string  `json:"pull_request_id"`
+}
+
+// PostPullRequestRemoveReviewerJSONBody defines parameters for PostPullRequestRemoveReviewer.
+type PostPullRequestRemoveReviewerJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+	UserId        string `json:"user_id"`
+}
+
+// PostPullRequestReopenJSONBody defines parameters for PostPullRequestReopen.
+type PostPullRequestReopenJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// PostPullRequestReviewJSONBody defines parameters for PostPullRequestReview.
+type PostPullRequestReviewJSONBody struct {
+	PullRequestId string                             `json:"pull_request_id"`
+	State         PostPullRequestReviewJSONBodyState `json:"state"`
+	UserId        string                             `json:"user_id"`
+}
+
+// PostPullRequestReviewJSONBodyState defines parameters for PostPullRequestReview.
+type PostPullRequestReviewJSONBodyState string
+
 // PostTeamDeactivateJSONBody defines parameters for PostTeamDeactivate.
 type PostTeamDeactivateJSONBody struct {
 	TeamName string `json:"team_name"`
@@ -169,10 +757,65 @@ type GetTeamGetParams struct {
 	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
 }
 
+// GetTeamListParams defines parameters for GetTeamList.
+type GetTeamListParams struct {
+	// IsActive true — команды с активными участниками, false — без них (по умолчанию — все)
+	IsActive *bool `form:"is_active,omitempty" json:"is_active,omitempty"`
+}
+
+// GetUsersGetParams defines parameters for GetUsersGet.
+type GetUsersGetParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
 // GetUsersGetReviewParams defines parameters for GetUsersGetReview.
 type GetUsersGetReviewParams struct {
 	// UserId Идентификатор пользователя
 	UserId UserIdQuery `form:"user_id" json:"user_id"`
+
+	// Pending Только открытые PR, где ревью пользователя ещё в состоянии PENDING
+	Pending *bool `form:"pending,omitempty" json:"pending,omitempty"`
+
+	// Status Только PR в этом статусе
+	Status *GetUsersGetReviewParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// CreatedAfter PR, созданные не раньше этого момента (RFC 3339)
+	CreatedAfter *time.Time `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore PR, созданные раньше этого момента (RFC 3339)
+	CreatedBefore *time.Time `form:"created_before,omitempty" json:"created_before,omitempty"`
+
+	// TeamName Только PR авторов из этой команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// Sort Порядок по дате создания, при равенстве — по ID PR
+	Sort *GetUsersGetReviewParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Limit Размер страницы
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor `next_cursor` предыдущей страницы. Курсор непрозрачен; продолжать по нему
+	// нужно с теми же фильтрами и тем же `sort`
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetUsersGetReviewParamsSort defines parameters for GetUsersGetReview.
+type GetUsersGetReviewParamsSort string
+
+// GetUsersGetReviewParamsStatus defines parameters for GetUsersGetReview.
+type GetUsersGetReviewParamsStatus string
+
+// GetUsersSearchParams defines parameters for GetUsersSearch.
+type GetUsersSearchParams struct {
+	// Q Подстрока ID или имени
+	Q string `form:"q" json:"q"`
+
+	// IsActive true — только активные, false — только неактивные (по умолчанию — все)
+	IsActive *bool `form:"is_active,omitempty" json:"is_active,omitempty"`
+
+	// Limit Максимальное число пользователей в ответе
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
 // PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
@@ -181,23 +824,124 @@ type PostUsersSetIsActiveJSONBody struct {
 	UserId   string `json:"user_id"`
 }
 
+// GetUsersTeamHistoryParams defines parameters for GetUsersTeamHistory.
+type GetUsersTeamHistoryParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// PostUsersTransferJSONBody defines parameters for PostUsersTransfer.
+type PostUsersTransferJSONBody struct {
+	ReviewPolicy *PostUsersTransferJSONBodyReviewPolicy `json:"review_policy,omitempty"`
+
+	// TeamName Новая команда
+	TeamName string `json:"team_name"`
+	UserId   string `json:"user_id"`
+}
+
+// PostUsersTransferJSONBodyReviewPolicy defines parameters for PostUsersTransfer.
+type PostUsersTransferJSONBodyReviewPolicy string
+
+// PostUsersUpdateJSONBody defines parameters for PostUsersUpdate.
+type PostUsersUpdateJSONBody struct {
+	Email       *string `json:"email"`
+	EmailOptOut *bool   `json:"email_opt_out,omitempty"`
+
+	// MaxOpenReviews Максимальное число открытых ревью (null — без ограничения)
+	MaxOpenReviews *int `json:"max_open_reviews"`
+
+	// QuietHours Тихие часы в часовом поясе пользователя: с `start` до `end` (не включая) письма не отправляются.
+	// Если `start` больше `end`, интервал переходит через полночь (22–7).
+	QuietHours *QuietHours `json:"quiet_hours"`
+	Timezone   *string     `json:"timezone"`
+	UserId     string      `json:"user_id"`
+}
+
+// GetWebhooksFailedParams defines parameters for GetWebhooksFailed.
+type GetWebhooksFailedParams struct {
+	// SubscriptionId Только доставки указанной подписки
+	SubscriptionId *int64 `form:"subscription_id,omitempty" json:"subscription_id,omitempty"`
+}
+
+// PostWebhooksRedeliverJSONBody defines parameters for PostWebhooksRedeliver.
+type PostWebhooksRedeliverJSONBody struct {
+	// Id id из `/webhooks/failed`
+	Id int64 `json:"id"`
+}
+
+// PostWebhooksSubscribeJSONBody defines parameters for PostWebhooksSubscribe.
+type PostWebhooksSubscribeJSONBody struct {
+	// Events Типы событий (не указаны — все события)
+	Events *[]WebhookEventType `json:"events,omitempty"`
+
+	// Secret Секрет для подписи тела запроса
+	Secret string `json:"secret"`
+
+	// Url http(s) URL получателя
+	Url string `json:"url"`
+}
+
+// PostWebhooksUnsubscribeJSONBody defines parameters for PostWebhooksUnsubscribe.
+type PostWebhooksUnsubscribeJSONBody struct {
+	Id int64 `json:"id"`
+}
+
+// PostIntegrationsGithubWebhookJSONRequestBody defines body for PostIntegrationsGithubWebhook for application/json ContentType.
+type PostIntegrationsGithubWebhookJSONRequestBody PostIntegrationsGithubWebhookJSONBody
+
+// PostIntegrationsGitlabWebhookJSONRequestBody defines body for PostIntegrationsGitlabWebhook for application/json ContentType.
+type PostIntegrationsGitlabWebhookJSONRequestBody PostIntegrationsGitlabWebhookJSONBody
+
+// PostPullRequestAddReviewerJSONRequestBody defines body for PostPullRequestAddReviewer for application/json ContentType.
+type PostPullRequestAddReviewerJSONRequestBody PostPullRequestAddReviewerJSONBody
+
 // PostPullRequestAssignJSONRequestBody defines body for PostPullRequestAssign for application/json ContentType.
 type PostPullRequestAssignJSONRequestBody PostPullRequestAssignJSONBody
 
+// PostPullRequestCloseJSONRequestBody defines body for PostPullRequestClose for application/json ContentType.
+type PostPullRequestCloseJSONRequestBody PostPullRequestCloseJSONBody
+
 // PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
 type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
 
 // PostPullRequestMergeJSONRequestBody defines body for PostPullRequestMerge for application/json ContentType.
 type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
 
+// PostPullRequestReadyJSONRequestBody defines body for PostPullRequestReady for application/json ContentType.
+type PostPullRequestReadyJSONRequestBody PostPullRequestReadyJSONBody
+
 // PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
 type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
 
+// PostPullRequestRemoveReviewerJSONRequestBody defines body for PostPullRequestRemoveReviewer for application/json ContentType.
+type PostPullRequestRemoveReviewerJSONRequestBody PostPullRequestRemoveReviewerJSONBody
+
+// PostPullRequestReopenJSONRequestBody defines body for PostPullRequestReopen for application/json ContentType.
+type PostPullRequestReopenJSONRequestBody PostPullRequestReopenJSONBody
+
+// PostPullRequestReviewJSONRequestBody defines body for PostPullRequestReview for application/json ContentType.
+type PostPullRequestReviewJSONRequestBody PostPullRequestReviewJSONBody
+
 // PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
-type PostTeamAddJSONRequestBody = Team
+type PostTeamAddJSONRequestBody = TeamAddRequest
 
 // PostTeamDeactivateJSONRequestBody defines body for PostTeamDeactivate for application/json ContentType.
 type PostTeamDeactivateJSONRequestBody PostTeamDeactivateJSONBody
 
 // PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
 type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
+
+// PostUsersTransferJSONRequestBody defines body for PostUsersTransfer for application/json ContentType.
+type PostUsersTransferJSONRequestBody PostUsersTransferJSONBody
+
+// PostUsersUpdateJSONRequestBody defines body for PostUsersUpdate for application/json ContentType.
+type PostUsersUpdateJSONRequestBody PostUsersUpdateJSONBody
+
+// PostWebhooksRedeliverJSONRequestBody defines body for PostWebhooksRedeliver for application/json ContentType.
+type PostWebhooksRedeliverJSONRequestBody PostWebhooksRedeliverJSONBody
+
+// PostWebhooksSubscribeJSONRequestBody defines body for PostWebhooksSubscribe for application/json ContentType.
+type PostWebhooksSubscribeJSONRequestBody PostWebhooksSubscribeJSONBody
+
+// PostWebhooksUnsubscribeJSONRequestBody defines body for PostWebhooksUnsubscribe for application/json ContentType.
+type PostWebhooksUnsubscribeJSONRequestBody PostWebhooksUnsubscribeJSONBody
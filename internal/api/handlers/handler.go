@@ -1,21 +1,35 @@
 package handlers
 
 import (
+	"crypto/subtle"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
 	"test_avito/internal/domain"
+	"test_avito/internal/integrations"
+	"test_avito/internal/integrations/github"
+	"test_avito/internal/integrations/gitlab"
 	"test_avito/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Handler struct {
-	teamService  *service.TeamService
-	userService  *service.UserService
-	prService    *service.PullRequestService
-	statsService *service.StatsService
-	logger       *slog.Logger
+	teamService    *service.TeamService
+	userService    *service.UserService
+	prService      *service.PullRequestService
+	statsService   *service.StatsService
+	webhookService *service.WebhookService
+	streamService  *service.EventStreamService
+	githubReceiver *github.Receiver
+	gitlabReceiver *gitlab.Receiver
+	// forceMergeToken административный токен для merge с force (пустой — force запрещён)
+	forceMergeToken []byte
+	logger          *slog.Logger
 }
 
 func NewHandler(
@@ -23,14 +37,24 @@ func NewHandler(
 	userService *service.UserService,
 	prService *service.PullRequestService,
 	statsService *service.StatsService,
+	webhookService *service.WebhookService,
+	streamService *service.EventStreamService,
+	githubReceiver *github.Receiver,
+	gitlabReceiver *gitlab.Receiver,
+	forceMergeToken string,
 	logger *slog.Logger,
 ) *Handler {
 	return &Handler{
-		teamService:  teamService,
-		userService:  userService,
-		prService:    prService,
-		statsService: statsService,
-		logger:       logger,
+		teamService:     teamService,
+		userService:     userService,
+		prService:       prService,
+		statsService:    statsService,
+		webhookService:  webhookService,
+		streamService:   streamService,
+		githubReceiver:  githubReceiver,
+		gitlabReceiver:  gitlabReceiver,
+		forceMergeToken: []byte(forceMergeToken),
+		logger:          logger,
 	}
 }
 
@@ -57,48 +81,107 @@ func (h *Handler) TeamAdd(c *gin.Context) {
 	var req struct {
 		TeamName string `json:"team_name" binding:"required"`
 		Members  []struct {
-			UserID   string `json:"user_id" binding:"required"`
-			Username string `json:"username" binding:"required"`
-			IsActive bool   `json:"is_active"`
+			UserID         string `json:"user_id" binding:"required"`
+			Username       string `json:"username" binding:"required"`
+			IsActive       bool   `json:"is_active"`
+			MaxOpenReviews *int   `json:"max_open_reviews"`
 		} `json:"members" binding:"required"`
+		Policy *struct {
+			MinReviewers            int     `json:"min_reviewers"`
+			MaxReviewers            int     `json:"max_reviewers" binding:"required"`
+			RequiredApprovals       int     `json:"required_approvals"`
+			BlockOnChangesRequested bool    `json:"block_on_changes_requested"`
+			ReviewSLAMinutes        int     `json:"review_sla_minutes"`
+			SLAAction               string  `json:"sla_action"`
+			LeadUserID              *string `json:"lead_user_id"`
+			ChatChannel             *string `json:"chat_channel"`
+			ChatTemplate            *string `json:"chat_template"`
+		} `json:"policy"`
+		Mode         domain.TeamSyncMode         `json:"mode"`
+		AllowMoves   bool                        `json:"allow_moves"`
+		ReviewPolicy domain.TransferReviewPolicy `json:"review_policy"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.handleError(c, domain.ErrInvalidInput)
 		return
 	}
+	if req.Mode == "" {
+		req.Mode = domain.TeamSyncUpsert
+	}
+	if !req.Mode.IsValid() {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+	// Как и в /users/transfer, без явной политики переход участника с открытыми ревью отклоняется
+	if req.AllowMoves && req.ReviewPolicy == "" {
+		req.ReviewPolicy = domain.TransferFailOnReviews
+	}
 
 	// Конвертация в доменную модель
 	members := make([]domain.User, len(req.Members))
 	for i, m := range req.Members {
 		members[i] = domain.User{
-			ID:       m.UserID,
-			Username: m.Username,
-			TeamName: req.TeamName,
-			IsActive: m.IsActive,
+			ID:             m.UserID,
+			Username:       m.Username,
+			TeamName:       req.TeamName,
+			IsActive:       m.IsActive,
+			MaxOpenReviews: m.MaxOpenReviews,
 		}
 	}
 
 	team := &domain.Team{
-		Name:    req.TeamName,
-		Members: members,
+		Name:             req.TeamName,
+		Members:          members,
+		AllowMoves:       req.AllowMoves,
+		MoveReviewPolicy: req.ReviewPolicy,
+	}
+	if req.Policy != nil {
+		team.Policy = &domain.TeamPolicy{
+			MinReviewers:            req.Policy.MinReviewers,
+			MaxReviewers:            req.Policy.MaxReviewers,
+			RequiredApprovals:       req.Policy.RequiredApprovals,
+			BlockOnChangesRequested: req.Policy.BlockOnChangesRequested,
+			ReviewSLAMinutes:        req.Policy.ReviewSLAMinutes,
+			SLAAction:               domain.SLAAction(req.Policy.SLAAction).OrDefault(),
+			LeadUserID:              req.Policy.LeadUserID,
+			ChatChannel:             req.Policy.ChatChannel,
+			ChatTemplate:            req.Policy.ChatTemplate,
+		}
 	}
 
 	// Check if team exists to determine status code
 	existingTeam, _ := h.teamService.GetTeam(c.Request.Context(), req.TeamName)
 
-	err := h.teamService.AddTeam(c.Request.Context(), team)
-	if err != nil {
-		h.handleError(c, err)
-		return
-	}
-
 	// Возвращаем соответствующий статусный код
 	status := http.StatusCreated
 	if existingTeam != nil {
 		status = http.StatusOK
 	}
 
+	// В режиме replace отсутствующие в запросе участники деактивируются
+	if req.Mode == domain.TeamSyncReplace {
+		changes, err := h.teamService.ReplaceTeam(c.Request.Context(), team)
+		if err != nil {
+			h.handleError(c, err)
+			return
+		}
+
+		c.JSON(status, gin.H{
+			"team": gin.H{
+				"team_name": team.Name,
+			},
+			"changes": changes,
+		})
+		return
+	}
+
+	err := h.teamService.AddTeam(c.Request.Context(), team)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
 	c.JSON(status, gin.H{
 		"team": gin.H{
 			"team_name": team.Name,
@@ -124,15 +207,46 @@ func (h *Handler) TeamGet(c *gin.Context) {
 	members := make([]gin.H, len(team.Members))
 	for i, m := range team.Members {
 		members[i] = gin.H{
-			"user_id":   m.ID,
-			"username":  m.Username,
-			"is_active": m.IsActive,
+			"user_id":          m.ID,
+			"username":         m.Username,
+			"is_active":        m.IsActive,
+			"max_open_reviews": m.MaxOpenReviews,
 		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"team_name": team.Name,
 		"members":   members,
+		"policy": gin.H{
+			"min_reviewers":              team.Policy.MinReviewers,
+			"max_reviewers":              team.Policy.MaxReviewers,
+			"required_approvals":         team.Policy.RequiredApprovals,
+			"block_on_changes_requested": team.Policy.BlockOnChangesRequested,
+			"review_sla_minutes":         team.Policy.ReviewSLAMinutes,
+			"sla_action":                 team.Policy.SLAAction,
+			"lead_user_id":               team.Policy.LeadUserID,
+			"chat_channel":               team.Policy.ChatChannel,
+			"chat_template":              team.Policy.ChatTemplate,
+		},
+	})
+}
+
+// /team/list
+func (h *Handler) TeamList(c *gin.Context) {
+	isActive, err := optionalBoolQuery(c, "is_active")
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	teams, err := h.teamService.ListTeams(c.Request.Context(), isActive)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"teams": teams,
 	})
 }
 
@@ -147,7 +261,7 @@ func (h *Handler) TeamDeactivate(c *gin.Context) {
 		return
 	}
 
-	team, deactivatedCount, err := h.teamService.DeactivateTeam(c.Request.Context(), req.TeamName)
+	team, deactivatedCount, report, err := h.teamService.DeactivateTeam(c.Request.Context(), req.TeamName)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -157,9 +271,10 @@ func (h *Handler) TeamDeactivate(c *gin.Context) {
 	members := make([]gin.H, len(team.Members))
 	for i, m := range team.Members {
 		members[i] = gin.H{
-			"user_id":   m.ID,
-			"username":  m.Username,
-			"is_active": m.IsActive,
+			"user_id":          m.ID,
+			"username":         m.Username,
+			"is_active":        m.IsActive,
+			"max_open_reviews": m.MaxOpenReviews,
 		}
 	}
 
@@ -169,6 +284,7 @@ func (h *Handler) TeamDeactivate(c *gin.Context) {
 			"members":   members,
 		},
 		"deactivated_count": deactivatedCount,
+		"reassignment":      report,
 	})
 }
 
@@ -184,6 +300,21 @@ func (h *Handler) UsersSetIsActive(c *gin.Context) {
 		return
 	}
 
+	if !req.IsActive {
+		// Деактивация сопровождается переназначением открытых ревью
+		user, report, err := h.userService.Deactivate(c.Request.Context(), req.UserID)
+		if err != nil {
+			h.handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"user":         h.userToResponse(user),
+			"reassignment": report,
+		})
+		return
+	}
+
 	user, err := h.userService.SetIsActive(c.Request.Context(), req.UserID, req.IsActive)
 	if err != nil {
 		h.handleError(c, err)
@@ -191,12 +322,142 @@ func (h *Handler) UsersSetIsActive(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"user": gin.H{
-			"user_id":   user.ID,
-			"username":  user.Username,
-			"team_name": user.TeamName,
-			"is_active": user.IsActive,
-		},
+		"user": h.userToResponse(user),
+	})
+}
+
+// /users/transfer
+func (h *Handler) UsersTransfer(c *gin.Context) {
+	var req struct {
+		UserID       string                      `json:"user_id" binding:"required"`
+		TeamName     string                      `json:"team_name" binding:"required"`
+		ReviewPolicy domain.TransferReviewPolicy `json:"review_policy"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+	// Без явной политики перевод с открытыми ревью отклоняется
+	if req.ReviewPolicy == "" {
+		req.ReviewPolicy = domain.TransferFailOnReviews
+	}
+
+	result, err := h.teamService.TransferUser(c.Request.Context(), req.UserID, req.TeamName, req.ReviewPolicy)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user":         h.userToResponse(result.User),
+		"from_team":    result.FromTeam,
+		"reassignment": result.Reassignment,
+	})
+}
+
+// /users/teamHistory
+func (h *Handler) UsersTeamHistory(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	history, err := h.userService.GetMembershipHistory(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id": userID,
+		"history": history,
+	})
+}
+
+// /users/get
+func (h *Handler) UsersGet(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	user, err := h.userService.GetUser(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": h.directoryUserToResponse(user),
+	})
+}
+
+// /users/search
+func (h *Handler) UsersSearch(c *gin.Context) {
+	search := domain.UserSearch{Query: c.Query("q")}
+
+	var err error
+	if search.IsActive, err = optionalBoolQuery(c, "is_active"); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	if raw := c.Query("limit"); raw != "" {
+		search.Limit, err = strconv.Atoi(raw)
+		if err != nil || search.Limit <= 0 {
+			h.handleError(c, domain.ErrInvalidInput)
+			return
+		}
+	}
+
+	users, err := h.userService.SearchUsers(c.Request.Context(), search)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	userList := make([]gin.H, len(users))
+	for i := range users {
+		userList[i] = h.directoryUserToResponse(&users[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"users": userList,
+	})
+}
+
+// /users/update
+func (h *Handler) UsersUpdate(c *gin.Context) {
+	var req struct {
+		UserID         string                             `json:"user_id" binding:"required"`
+		MaxOpenReviews domain.Optional[int]               `json:"max_open_reviews"`
+		Email          domain.Optional[string]            `json:"email"`
+		EmailOptOut    domain.Optional[bool]              `json:"email_opt_out"`
+		QuietHours     domain.Optional[domain.QuietHours] `json:"quiet_hours"`
+		Timezone       domain.Optional[string]            `json:"timezone"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	user, err := h.userService.UpdateUser(c.Request.Context(), req.UserID, domain.UserUpdate{
+		MaxOpenReviews: req.MaxOpenReviews,
+		Email:          req.Email,
+		EmailOptOut:    req.EmailOptOut,
+		QuietHours:     req.QuietHours,
+		Timezone:       req.Timezone,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": h.userToResponse(user),
 	})
 }
 
@@ -206,6 +467,7 @@ func (h *Handler) PullRequestCreate(c *gin.Context) {
 		PullRequestID   string `json:"pull_request_id" binding:"required"`
 		PullRequestName string `json:"pull_request_name" binding:"required"`
 		AuthorID        string `json:"author_id" binding:"required"`
+		Draft           bool   `json:"draft"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -213,7 +475,13 @@ func (h *Handler) PullRequestCreate(c *gin.Context) {
 		return
 	}
 
-	pr, err := h.prService.CreatePR(c.Request.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	// Черновик создаётся без ревьюверов
+	create := h.prService.CreatePR
+	if req.Draft {
+		create = h.prService.CreateDraftPR
+	}
+
+	pr, err := create(c.Request.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -224,8 +492,97 @@ func (h *Handler) PullRequestCreate(c *gin.Context) {
 	})
 }
 
+// ForceMergeTokenHeader carries the admin token that allows /pullRequest/merge with force
+const ForceMergeTokenHeader = "X-Admin-Token"
+
+// verifyForceMergeToken checks the token against MERGE_FORCE_TOKEN; with no token configured force is never allowed
+func (h *Handler) verifyForceMergeToken(token string) bool {
+	if len(h.forceMergeToken) == 0 {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), h.forceMergeToken) == 1
+}
+
 // /pullRequest/merge
 func (h *Handler) PullRequestMerge(c *gin.Context) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id" binding:"required"`
+		Force         bool   `json:"force"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	// Merge в обход правила команды — административное действие, доступное только с токеном из MERGE_FORCE_TOKEN;
+	// X-Actor клиент задает сам, поэтому он не подтверждает права
+	if req.Force && !h.verifyForceMergeToken(c.GetHeader(ForceMergeTokenHeader)) {
+		h.logger.Warn("force merge rejected",
+			slog.String("pr_id", req.PullRequestID),
+			slog.String("actor", domain.ActorFromContext(c.Request.Context())),
+		)
+		h.handleError(c, domain.ErrForceMergeForbidden)
+		return
+	}
+
+	pr, err := h.prService.MergePR(c.Request.Context(), req.PullRequestID, req.Force)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr": h.prToResponse(pr),
+	})
+}
+
+// /pullRequest/ready
+func (h *Handler) PullRequestReady(c *gin.Context) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	pr, err := h.prService.MarkReady(c.Request.Context(), req.PullRequestID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr": h.prToResponse(pr),
+	})
+}
+
+// /pullRequest/close
+func (h *Handler) PullRequestClose(c *gin.Context) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	pr, err := h.prService.ClosePR(c.Request.Context(), req.PullRequestID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr": h.prToResponse(pr),
+	})
+}
+
+// /pullRequest/reopen
+func (h *Handler) PullRequestReopen(c *gin.Context) {
 	var req struct {
 		PullRequestID string `json:"pull_request_id" binding:"required"`
 	}
@@ -235,7 +592,7 @@ func (h *Handler) PullRequestMerge(c *gin.Context) {
 		return
 	}
 
-	pr, err := h.prService.MergePR(c.Request.Context(), req.PullRequestID)
+	pr, err := h.prService.ReopenPR(c.Request.Context(), req.PullRequestID)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -251,6 +608,7 @@ func (h *Handler) PullRequestReassign(c *gin.Context) {
 	var req struct {
 		PullRequestID string `json:"pull_request_id" binding:"required"`
 		OldUserID     string `json:"old_user_id" binding:"required"`
+		NewUserID     string `json:"new_user_id"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -258,7 +616,7 @@ func (h *Handler) PullRequestReassign(c *gin.Context) {
 		return
 	}
 
-	newReviewerID, pr, err := h.prService.ReassignReviewer(c.Request.Context(), req.PullRequestID, req.OldUserID)
+	newReviewerID, pr, err := h.prService.ReassignReviewer(c.Request.Context(), req.PullRequestID, req.OldUserID, req.NewUserID)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -304,6 +662,146 @@ func (h *Handler) PullRequestAssign(c *gin.Context) {
 	})
 }
 
+// /pullRequest/addReviewer
+func (h *Handler) PullRequestAddReviewer(c *gin.Context) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id" binding:"required"`
+		UserID        string `json:"user_id"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	reviewerID, pr, err := h.prService.AddReviewer(c.Request.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr":       h.prToResponse(pr),
+		"added_by": reviewerID,
+	})
+}
+
+// /pullRequest/removeReviewer
+func (h *Handler) PullRequestRemoveReviewer(c *gin.Context) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id" binding:"required"`
+		UserID        string `json:"user_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	pr, err := h.prService.RemoveReviewer(c.Request.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr": h.prToResponse(pr),
+	})
+}
+
+// /pullRequest/review
+func (h *Handler) PullRequestReview(c *gin.Context) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id" binding:"required"`
+		UserID        string `json:"user_id" binding:"required"`
+		State         string `json:"state" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	pr, err := h.prService.SubmitReview(c.Request.Context(), req.PullRequestID, req.UserID, domain.ReviewState(req.State))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr": h.prToResponse(pr),
+	})
+}
+
+// /pullRequest/history
+func (h *Handler) PullRequestHistory(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	history, err := h.prService.GetHistory(c.Request.Context(), prID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Пустые поля записи не относятся к ее типу и в ответ не попадают
+	c.JSON(http.StatusOK, gin.H{
+		"pull_request_id": prID,
+		"history":         history,
+	})
+}
+
+// /pullRequest/get
+func (h *Handler) PullRequestGet(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	pr, err := h.prService.GetPR(c.Request.Context(), prID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr": h.prToResponse(pr),
+	})
+}
+
+// /pullRequest/list
+func (h *Handler) PullRequestList(c *gin.Context) {
+	filter, err := prFilterFromQuery(c)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	page, err := h.prService.ListPRs(c.Request.Context(), filter)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	prList := make([]gin.H, len(page.PullRequests))
+	for i := range page.PullRequests {
+		prList[i] = h.prSummaryToResponse(&page.PullRequests[i])
+	}
+
+	response := gin.H{
+		"pull_requests": prList,
+	}
+	// На последней странице next_cursor не возвращается
+	if page.NextCursor != "" {
+		response["next_cursor"] = page.NextCursor
+	}
+	c.JSON(http.StatusOK, response)
+}
+
 // /users/getReview
 func (h *Handler) UsersGetReview(c *gin.Context) {
 	userID := c.Query("user_id")
@@ -312,15 +810,22 @@ func (h *Handler) UsersGetReview(c *gin.Context) {
 		return
 	}
 
-	prs, err := h.prService.GetPRsByReviewer(c.Request.Context(), userID)
+	filter, err := reviewerPRFilterFromQuery(c)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	filter.ReviewerID = userID
+
+	page, err := h.prService.ListReviewerPRs(c.Request.Context(), filter)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
 	// Convert to API model
-	prList := make([]gin.H, len(prs))
-	for i, pr := range prs {
+	prList := make([]gin.H, len(page.PullRequests))
+	for i, pr := range page.PullRequests {
 		prList[i] = gin.H{
 			"pull_request_id":   pr.ID,
 			"pull_request_name": pr.Name,
@@ -329,14 +834,412 @@ func (h *Handler) UsersGetReview(c *gin.Context) {
 		}
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"user_id":       userID,
 		"pull_requests": prList,
+	}
+	// На последней странице next_cursor не возвращается
+	if page.NextCursor != "" {
+		response["next_cursor"] = page.NextCursor
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// reviewerPRFilterFromQuery reads the filter, sort and page parameters of /users/getReview
+func reviewerPRFilterFromQuery(c *gin.Context) (domain.ReviewerPRFilter, error) {
+	filter := domain.ReviewerPRFilter{
+		Status:   domain.PRStatus(c.Query("status")),
+		TeamName: c.Query("team_name"),
+	}
+
+	// pending=true оставляет только открытые PR, где ревью ещё не отправлено
+	var err error
+	if filter.PendingOnly, err = boolQuery(c, "pending"); err != nil {
+		return filter, err
+	}
+
+	page, err := prPageFromQuery(c)
+	if err != nil {
+		return filter, err
+	}
+	filter.Sort = page.sort
+	filter.Limit = page.limit
+	filter.After = page.after
+	filter.CreatedAfter = page.createdAfter
+	filter.CreatedBefore = page.createdBefore
+
+	return filter, nil
+}
+
+// prFilterFromQuery reads the filter, sort and page parameters of /pullRequest/list
+func prFilterFromQuery(c *gin.Context) (domain.PRFilter, error) {
+	filter := domain.PRFilter{
+		AuthorID:     c.Query("author_id"),
+		TeamName:     c.Query("team_name"),
+		Status:       domain.PRStatus(c.Query("status")),
+		ReviewerID:   c.Query("reviewer_id"),
+		NameContains: c.Query("name"),
+		Search:       c.Query("search"),
+	}
+
+	var err error
+	if filter.NoReviewers, err = boolQuery(c, "no_reviewers"); err != nil {
+		return filter, err
+	}
+
+	page, err := prPageFromQuery(c)
+	if err != nil {
+		return filter, err
+	}
+	filter.Sort = page.sort
+	filter.Limit = page.limit
+	filter.After = page.after
+	filter.CreatedAfter = page.createdAfter
+	filter.CreatedBefore = page.createdBefore
+
+	return filter, nil
+}
+
+// prPageQuery holds the parameters shared by PR lists: creation period, sort and page
+type prPageQuery struct {
+	createdAfter  *time.Time
+	createdBefore *time.Time
+	sort          domain.PRSort
+	limit         int
+	after         *domain.PRCursor
+}
+
+func prPageFromQuery(c *gin.Context) (prPageQuery, error) {
+	page := prPageQuery{sort: domain.PRSort(c.Query("sort"))}
+
+	// Границы периода в RFC 3339: created_after включительно, created_before исключительно
+	var err error
+	if page.createdAfter, err = timeQuery(c, "created_after"); err != nil {
+		return page, err
+	}
+	if page.createdBefore, err = timeQuery(c, "created_before"); err != nil {
+		return page, err
+	}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return page, domain.ErrInvalidInput
+		}
+		page.limit = limit
+	}
+
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := domain.DecodePRCursor(raw)
+		if err != nil {
+			return page, err
+		}
+		page.after = cursor
+	}
+
+	return page, nil
+}
+
+// boolQuery parses an optional boolean query parameter, false when it is absent
+func boolQuery(c *gin.Context, name string) (bool, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return false, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, domain.ErrInvalidInput
+	}
+	return v, nil
+}
+
+// optionalBoolQuery parses an optional boolean query parameter, nil when it is absent
+func optionalBoolQuery(c *gin.Context, name string) (*bool, error) {
+	if c.Query(name) == "" {
+		return nil, nil
+	}
+	v, err := boolQuery(c, name)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// timeQuery parses an optional RFC 3339 query parameter
+func timeQuery(c *gin.Context, name string) (*time.Time, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, domain.ErrInvalidInput
+	}
+	return &t, nil
+}
+
+// streamRetry is how long a browser waits before reconnecting to a dropped event stream
+const streamRetry = 3 * time.Second
+
+// /events/stream
+func (h *Handler) EventsStream(c *gin.Context) {
+	filter := domain.StreamFilter{
+		TeamName: c.Query("team_name"),
+		UserID:   c.Query("user_id"),
+	}
+
+	// Last-Event-ID браузер передает сам при переподключении, last_event_id — для первого подключения
+	var lastEventID *int64
+	rawID := c.GetHeader("Last-Event-ID")
+	if rawID == "" {
+		rawID = c.Query("last_event_id")
+	}
+	if rawID != "" {
+		id, err := strconv.ParseInt(rawID, 10, 64)
+		if err != nil {
+			h.handleError(c, domain.ErrInvalidInput)
+			return
+		}
+		lastEventID = &id
+	}
+
+	ctx := c.Request.Context()
+	afterSeq, err := h.streamService.Open(ctx, filter, lastEventID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// WriteTimeout сервера оборвал бы поток: для этого ответа дедлайн записи снимается
+	rc := http.NewResponseController(c.Writer)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Warn("failed to clear write deadline for event stream", slog.String("error", err.Error()))
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	if _, err := fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry.Milliseconds()); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	err = h.streamService.Stream(ctx, filter, afterSeq,
+		func(event domain.StreamEvent) error {
+			if _, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Type, event.Payload); err != nil {
+				return err
+			}
+			return rc.Flush()
+		},
+		func() error {
+			// Комментарий не виден клиенту и не дает прокси закрыть соединение
+			if _, err := io.WriteString(c.Writer, ": ping\n\n"); err != nil {
+				return err
+			}
+			return rc.Flush()
+		},
+	)
+	if err != nil {
+		h.logger.Warn("event stream closed", slog.String("error", err.Error()))
+	}
+}
+
+// /webhooks/subscribe
+func (h *Handler) WebhooksSubscribe(c *gin.Context) {
+	var req struct {
+		URL    string             `json:"url" binding:"required"`
+		Secret string             `json:"secret" binding:"required"`
+		Events []domain.EventType `json:"events"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	sub, err := h.webhookService.Subscribe(c.Request.Context(), &domain.WebhookSubscription{
+		URL:    req.URL,
+		Secret: req.Secret,
+		Events: req.Events,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"subscription": h.subscriptionToResponse(sub),
+	})
+}
+
+// /webhooks/list
+func (h *Handler) WebhooksList(c *gin.Context) {
+	subs, err := h.webhookService.ListSubscriptions(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Секрет подписки в ответах не возвращается
+	subList := make([]gin.H, len(subs))
+	for i := range subs {
+		subList[i] = h.subscriptionToResponse(&subs[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"subscriptions": subList,
+	})
+}
+
+// /webhooks/unsubscribe
+func (h *Handler) WebhooksUnsubscribe(c *gin.Context) {
+	var req struct {
+		ID int64 `json:"id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	if err := h.webhookService.Unsubscribe(c.Request.Context(), req.ID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"id": req.ID,
+	})
+}
+
+// /webhooks/failed
+func (h *Handler) WebhooksFailed(c *gin.Context) {
+	// subscription_id необязателен: без него возвращаются все неудачные доставки
+	var subscriptionID *int64
+	if raw := c.Query("subscription_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			h.handleError(c, domain.ErrInvalidInput)
+			return
+		}
+		subscriptionID = &id
+	}
+
+	letters, err := h.webhookService.ListDeadLetters(c.Request.Context(), subscriptionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"failed": letters,
+	})
+}
+
+// /webhooks/redeliver
+func (h *Handler) WebhooksRedeliver(c *gin.Context) {
+	var req struct {
+		ID int64 `json:"id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	letter, err := h.webhookService.Redeliver(c.Request.Context(), req.ID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"redelivered": letter,
+	})
+}
+
+// /integrations/github/webhook
+func (h *Handler) GitHubWebhook(c *gin.Context) {
+	// Подпись считается по телу как есть, поэтому тело читается целиком, а не через ShouldBindJSON
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, integrations.MaxPayloadSize))
+	if err != nil {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	// Изменения PR из GitHub записываются в историю от имени интеграции
+	result, err := h.githubReceiver.Handle(
+		domain.WithActor(c.Request.Context(), domain.ActorGitHub),
+		c.GetHeader(github.EventHeader),
+		c.GetHeader(github.SignatureHeader),
+		body,
+	)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": result,
+	})
+}
+
+// /integrations/gitlab/webhook
+func (h *Handler) GitLabWebhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, integrations.MaxPayloadSize))
+	if err != nil {
+		h.handleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	// Изменения PR из GitLab записываются в историю от имени интеграции
+	result, err := h.gitlabReceiver.Handle(
+		domain.WithActor(c.Request.Context(), domain.ActorGitLab),
+		c.GetHeader(gitlab.EventHeader),
+		c.GetHeader(gitlab.TokenHeader),
+		body,
+	)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": result,
 	})
 }
 
 // Helper functions
 
+func (h *Handler) userToResponse(user *domain.User) gin.H {
+	return gin.H{
+		"user_id":          user.ID,
+		"username":         user.Username,
+		"team_name":        user.TeamName,
+		"is_active":        user.IsActive,
+		"max_open_reviews": user.MaxOpenReviews,
+		"email":            user.Email,
+		"email_opt_out":    user.EmailOptOut,
+		"quiet_hours":      user.QuietHours,
+		"timezone":         user.Timezone,
+	}
+}
+
+// directoryUserToResponse отдаёт пользователя без настроек уведомлений:
+// /users/get и /users/search доступны всем, а email и тихие часы — личные данные
+func (h *Handler) directoryUserToResponse(user *domain.User) gin.H {
+	return gin.H{
+		"user_id":          user.ID,
+		"username":         user.Username,
+		"team_name":        user.TeamName,
+		"is_active":        user.IsActive,
+		"max_open_reviews": user.MaxOpenReviews,
+	}
+}
+
 func (h *Handler) prToResponse(pr *domain.PullRequest) gin.H {
 	return gin.H{
 		"pull_request_id":    pr.ID,
@@ -344,8 +1247,34 @@ func (h *Handler) prToResponse(pr *domain.PullRequest) gin.H {
 		"author_id":          pr.AuthorID,
 		"status":             pr.Status,
 		"assigned_reviewers": pr.AssignedReviewers,
+		"reviews":            pr.Reviews,
 		"createdAt":          pr.CreatedAt,
 		"mergedAt":           pr.MergedAt,
+		"closedAt":           pr.ClosedAt,
+		"force_merged":       pr.ForceMerged,
+	}
+}
+
+func (h *Handler) prSummaryToResponse(pr *domain.PRSummary) gin.H {
+	return gin.H{
+		"pull_request_id":    pr.ID,
+		"pull_request_name":  pr.Name,
+		"author_id":          pr.AuthorID,
+		"team_name":          pr.TeamName,
+		"status":             pr.Status,
+		"assigned_reviewers": pr.AssignedReviewers,
+		"createdAt":          pr.CreatedAt,
+		"mergedAt":           pr.MergedAt,
+		"closedAt":           pr.ClosedAt,
+	}
+}
+
+func (h *Handler) subscriptionToResponse(sub *domain.WebhookSubscription) gin.H {
+	return gin.H{
+		"id":         sub.ID,
+		"url":        sub.URL,
+		"events":     sub.Events,
+		"created_at": sub.CreatedAt,
 	}
 }
 
@@ -356,9 +1285,15 @@ func (h *Handler) handleError(c *gin.Context, err error) {
 	switch apiErr.Code {
 	case domain.CodeBadRequest:
 		statusCode = http.StatusBadRequest
+	case domain.CodeUnauthorized:
+		statusCode = http.StatusUnauthorized
+	case domain.CodeForbidden:
+		statusCode = http.StatusForbidden
 	case domain.CodeNotFound:
 		statusCode = http.StatusNotFound
-	case domain.CodePRExists, domain.CodePRMerged, domain.CodeNotAssigned, domain.CodeNoCandidate, domain.CodeReviewersAssigned:
+	case domain.CodePRExists, domain.CodePRMerged, domain.CodeNotAssigned, domain.CodeNoCandidate, domain.CodeReviewersAssigned,
+		domain.CodeReviewerAtCapacity, domain.CodeNotApproved, domain.CodeUserInOtherTeam, domain.CodeHasOpenReviews,
+		domain.CodeUserIsTeamLead, domain.CodePRClosed, domain.CodePRDraft, domain.CodeInvalidTransition:
 		statusCode = http.StatusConflict
 	case domain.CodeUnsupportedMediaType:
 		statusCode = http.StatusUnsupportedMediaType
@@ -387,12 +1322,43 @@ func (h *Handler) RegisterRoutes(r *gin.Engine) {
 	r.POST("/team/add", h.TeamAdd)
 	r.POST("/team/deactivate", h.TeamDeactivate)
 	r.GET("/team/get", h.TeamGet)
+	r.GET("/team/list", h.TeamList)
 
 	r.POST("/users/setIsActive", h.UsersSetIsActive)
+	r.POST("/users/update", h.UsersUpdate)
 	r.GET("/users/getReview", h.UsersGetReview)
+	r.GET("/users/get", h.UsersGet)
+	r.GET("/users/search", h.UsersSearch)
+	r.POST("/users/transfer", h.UsersTransfer)
+	r.GET("/users/teamHistory", h.UsersTeamHistory)
 
 	r.POST("/pullRequest/create", h.PullRequestCreate)
 	r.POST("/pullRequest/merge", h.PullRequestMerge)
+	r.POST("/pullRequest/ready", h.PullRequestReady)
+	r.POST("/pullRequest/close", h.PullRequestClose)
+	r.POST("/pullRequest/reopen", h.PullRequestReopen)
 	r.POST("/pullRequest/reassign", h.PullRequestReassign)
 	r.POST("/pullRequest/assign", h.PullRequestAssign)
+	r.POST("/pullRequest/addReviewer", h.PullRequestAddReviewer)
+	r.POST("/pullRequest/removeReviewer", h.PullRequestRemoveReviewer)
+	r.POST("/pullRequest/review", h.PullRequestReview)
+	r.GET("/pullRequest/history", h.PullRequestHistory)
+	r.GET("/pullRequest/get", h.PullRequestGet)
+	r.GET("/pullRequest/list", h.PullRequestList)
+
+	r.POST("/webhooks/subscribe", h.WebhooksSubscribe)
+	r.GET("/webhooks/list", h.WebhooksList)
+	r.POST("/webhooks/unsubscribe", h.WebhooksUnsubscribe)
+	r.GET("/webhooks/failed", h.WebhooksFailed)
+	r.POST("/webhooks/redeliver", h.WebhooksRedeliver)
+
+	r.GET("/events/stream", h.EventsStream)
+
+	// Прием событий GitHub и GitLab включается только при заданном секрете
+	if h.githubReceiver != nil {
+		r.POST("/integrations/github/webhook", h.GitHubWebhook)
+	}
+	if h.gitlabReceiver != nil {
+		r.POST("/integrations/gitlab/webhook", h.GitLabWebhook)
+	}
 }
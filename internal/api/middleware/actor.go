@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"unicode/utf8"
+
+	"test_avito/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Actor берет из заголовка X-Actor того, кто выполняет запрос (пользователь или сервис),
+// и кладет в контекст запроса: изменения PR записываются в историю от его имени
+// Слишком длинный актор и имена, зарезервированные за самим сервисом (github, gitlab, review_sla), — 400
+func Actor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if actor := c.GetHeader("X-Actor"); actor != "" {
+			if utf8.RuneCountInString(actor) > domain.MaxActorLength || domain.IsReservedActor(actor) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"error": domain.NewAPIError(domain.CodeBadRequest, "invalid X-Actor header"),
+				})
+				return
+			}
+			c.Request = c.Request.WithContext(domain.WithActor(c.Request.Context(), actor))
+		}
+
+		c.Next()
+	}
+}
@@ -1,6 +1,11 @@
 package middleware
 
 import (
+	"context"
+	"net/http"
+	"unicode/utf8"
+
+	"test_avito/internal/domain"
 	"test_avito/pkg/logger"
 
 	"github.com/gin-gonic/gin"
@@ -8,17 +13,29 @@ import (
 )
 
 // RequestID добавляем уникальный идентификатор запроса к каждому запрос
+// Слишком длинный X-Request-ID клиента — 400: он пишется в историю PR
 func RequestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := c.GetHeader("X-Request-ID")
-		if requestID == "" {
+		invalid := utf8.RuneCountInString(requestID) > domain.MaxRequestIDLength
+		if requestID == "" || invalid {
 			requestID = uuid.New().String()
 		}
 
 		c.Set(string(logger.RequestIDKey), requestID)
 
+		// Сервисы получают только context.Context запроса: request ID пишется в историю PR
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), logger.RequestIDKey, requestID))
+
 		c.Header("X-Request-ID", requestID)
 
+		if invalid {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": domain.NewAPIError(domain.CodeBadRequest, "invalid X-Request-ID header"),
+			})
+			return
+		}
+
 		c.Next()
 	}
 }
@@ -15,6 +15,7 @@ func NewRouter(handler *handlers.Handler, logger *slog.Logger) *gin.Engine {
 	r := gin.New()
 
 	r.Use(middleware.RequestID())
+	r.Use(middleware.Actor())
 	r.Use(middleware.Recovery(logger))
 	r.Use(middleware.Logging(logger))
 
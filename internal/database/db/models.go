@@ -8,28 +8,135 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type PrReviewer struct {
+type EventStream struct {
+	Seq       int64              `json:"seq"`
+	EventID   string             `json:"event_id"`
+	EventType string             `json:"event_type"`
+	TeamName  *string            `json:"team_name"`
+	UserIds   []string           `json:"user_ids"`
+	Payload   []byte             `json:"payload"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Outbox struct {
+	ID            int64              `json:"id"`
+	EventID       string             `json:"event_id"`
+	EventType     string             `json:"event_type"`
+	Payload       []byte             `json:"payload"`
+	Attempts      int32              `json:"attempts"`
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+	LastError     *string            `json:"last_error"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	PublishedAt   pgtype.Timestamptz `json:"published_at"`
+}
+
+type PrEvent struct {
+	ID            int64              `json:"id"`
 	PullRequestID string             `json:"pull_request_id"`
-	ReviewerID    string             `json:"reviewer_id"`
-	AssignedAt    pgtype.Timestamptz `json:"assigned_at"`
+	EventType     string             `json:"event_type"`
+	Actor         *string            `json:"actor"`
+	RequestID     *string            `json:"request_id"`
+	ReviewerID    *string            `json:"reviewer_id"`
+	OldReviewerID *string            `json:"old_reviewer_id"`
+	Reason        *string            `json:"reason"`
+	FromStatus    *string            `json:"from_status"`
+	ToStatus      *string            `json:"to_status"`
+	ReviewState   *string            `json:"review_state"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type PrReviewer struct {
+	PullRequestID  string             `json:"pull_request_id"`
+	ReviewerID     string             `json:"reviewer_id"`
+	AssignedAt     pgtype.Timestamptz `json:"assigned_at"`
+	State          string             `json:"state"`
+	StateUpdatedAt pgtype.Timestamptz `json:"state_updated_at"`
+	EscalatedAt    pgtype.Timestamptz `json:"escalated_at"`
 }
 
 type PullRequest struct {
-	ID        string             `json:"id"`
-	Name      string             `json:"name"`
-	AuthorID  string             `json:"author_id"`
-	Status    string             `json:"status"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	MergedAt  pgtype.Timestamptz `json:"merged_at"`
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	AuthorID    string             `json:"author_id"`
+	Status      string             `json:"status"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	MergedAt    pgtype.Timestamptz `json:"merged_at"`
+	ForceMerged bool               `json:"force_merged"`
+	ClosedAt    pgtype.Timestamptz `json:"closed_at"`
 }
 
 type Team struct {
-	Name string `json:"name"`
+	Name                    string  `json:"name"`
+	MinReviewers            int32   `json:"min_reviewers"`
+	MaxReviewers            int32   `json:"max_reviewers"`
+	RequiredApprovals       int32   `json:"required_approvals"`
+	BlockOnChangesRequested bool    `json:"block_on_changes_requested"`
+	ReviewSlaMinutes        int32   `json:"review_sla_minutes"`
+	SlaAction               string  `json:"sla_action"`
+	LeadUserID              *string `json:"lead_user_id"`
+	ChatChannel             *string `json:"chat_channel"`
+	ChatTemplate            *string `json:"chat_template"`
+}
+
+type TeamMembership struct {
+	ID           int64              `json:"id"`
+	UserID       string             `json:"user_id"`
+	FromTeam     string             `json:"from_team"`
+	ToTeam       string             `json:"to_team"`
+	Source       string             `json:"source"`
+	ReviewPolicy *string            `json:"review_policy"`
+	Actor        *string            `json:"actor"`
+	RequestID    *string            `json:"request_id"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type TeamReviewerCursor struct {
+	TeamName       string             `json:"team_name"`
+	LastReviewerID string             `json:"last_reviewer_id"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
 }
 
 type User struct {
-	ID       string `json:"id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	ID              string             `json:"id"`
+	Username        string             `json:"username"`
+	TeamName        string             `json:"team_name"`
+	IsActive        bool               `json:"is_active"`
+	MaxOpenReviews  *int32             `json:"max_open_reviews"`
+	Email           *string            `json:"email"`
+	EmailOptOut     bool               `json:"email_opt_out"`
+	QuietHoursStart *int16             `json:"quiet_hours_start"`
+	QuietHoursEnd   *int16             `json:"quiet_hours_end"`
+	Timezone        string             `json:"timezone"`
+	LastDigestAt    pgtype.Timestamptz `json:"last_digest_at"`
+}
+
+type WebhookDeadLetter struct {
+	ID             int64              `json:"id"`
+	SubscriptionID int64              `json:"subscription_id"`
+	EventType      string             `json:"event_type"`
+	Payload        []byte             `json:"payload"`
+	Attempts       int32              `json:"attempts"`
+	LastError      string             `json:"last_error"`
+	FailedAt       pgtype.Timestamptz `json:"failed_at"`
+}
+
+type WebhookDelivery struct {
+	ID             int64              `json:"id"`
+	SubscriptionID int64              `json:"subscription_id"`
+	EventType      string             `json:"event_type"`
+	Payload        []byte             `json:"payload"`
+	Status         string             `json:"status"`
+	Attempts       int32              `json:"attempts"`
+	NextAttemptAt  pgtype.Timestamptz `json:"next_attempt_at"`
+	LastError      *string            `json:"last_error"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+}
+
+type WebhookSubscription struct {
+	ID        int64              `json:"id"`
+	Url       string             `json:"url"`
+	Secret    string             `json:"secret"`
+	Events    []string           `json:"events"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
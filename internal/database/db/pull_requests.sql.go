@@ -28,6 +28,22 @@ func (q *Queries) AddReviewer(ctx context.Context, arg AddReviewerParams) error
 	return err
 }
 
+const addReviewersBulk = `-- name: AddReviewersBulk :exec
+INSERT INTO pr_reviewers (pull_request_id, reviewer_id, assigned_at)
+SELECT unnest($1::text[]), unnest($2::text[]), NOW()
+ON CONFLICT (pull_request_id, reviewer_id) DO NOTHING
+`
+
+type AddReviewersBulkParams struct {
+	PullRequestIds []string `json:"pull_request_ids"`
+	ReviewerIds    []string `json:"reviewer_ids"`
+}
+
+func (q *Queries) AddReviewersBulk(ctx context.Context, arg AddReviewersBulkParams) error {
+	_, err := q.db.Exec(ctx, addReviewersBulk, arg.PullRequestIds, arg.ReviewerIds)
+	return err
+}
+
 const countPullRequests = `-- name: CountPullRequests :one
 SELECT COUNT(*) FROM pull_requests
 `
@@ -76,6 +92,106 @@ func (q *Queries) CreatePullRequest(ctx context.Context, arg CreatePullRequestPa
 	return err
 }
 
+const getOpenReviewsByReviewers = `-- name: GetOpenReviewsByReviewers :many
+SELECT prr.pull_request_id, prr.reviewer_id, pr.author_id, a.team_name,
+       ARRAY(SELECT x.reviewer_id FROM pr_reviewers x WHERE x.pull_request_id = prr.pull_request_id)::text[] AS reviewer_ids
+FROM pr_reviewers prr
+INNER JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.status = 'OPEN'
+INNER JOIN users a ON a.id = pr.author_id
+WHERE prr.reviewer_id = ANY($1::text[])
+ORDER BY prr.pull_request_id, prr.reviewer_id
+`
+
+type GetOpenReviewsByReviewersRow struct {
+	PullRequestID string   `json:"pull_request_id"`
+	ReviewerID    string   `json:"reviewer_id"`
+	AuthorID      string   `json:"author_id"`
+	TeamName      string   `json:"team_name"`
+	ReviewerIds   []string `json:"reviewer_ids"`
+}
+
+// Reviews of the given users on OPEN PRs with the team of the PR author and everyone currently reviewing the PR
+func (q *Queries) GetOpenReviewsByReviewers(ctx context.Context, userIds []string) ([]GetOpenReviewsByReviewersRow, error) {
+	rows, err := q.db.Query(ctx, getOpenReviewsByReviewers, userIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetOpenReviewsByReviewersRow{}
+	for rows.Next() {
+		var i GetOpenReviewsByReviewersRow
+		if err := rows.Scan(
+			&i.PullRequestID,
+			&i.ReviewerID,
+			&i.AuthorID,
+			&i.TeamName,
+			&i.ReviewerIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOverdueReviews = `-- name: GetOverdueReviews :many
+SELECT prr.pull_request_id, prr.reviewer_id, prr.assigned_at, t.name AS team_name, t.sla_action, t.lead_user_id
+FROM pr_reviewers prr
+INNER JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.status = 'OPEN'
+INNER JOIN users a ON a.id = pr.author_id
+INNER JOIN teams t ON t.name = a.team_name
+WHERE prr.state = 'PENDING'
+  AND prr.escalated_at IS NULL
+  AND t.review_sla_minutes > 0
+  AND prr.assigned_at + make_interval(mins => t.review_sla_minutes) <= $1::timestamptz
+ORDER BY prr.assigned_at
+LIMIT $2
+`
+
+type GetOverdueReviewsParams struct {
+	Now     pgtype.Timestamptz `json:"now"`
+	MaxRows int32              `json:"max_rows"`
+}
+
+type GetOverdueReviewsRow struct {
+	PullRequestID string             `json:"pull_request_id"`
+	ReviewerID    string             `json:"reviewer_id"`
+	AssignedAt    pgtype.Timestamptz `json:"assigned_at"`
+	TeamName      string             `json:"team_name"`
+	SlaAction     string             `json:"sla_action"`
+	LeadUserID    *string            `json:"lead_user_id"`
+}
+
+func (q *Queries) GetOverdueReviews(ctx context.Context, arg GetOverdueReviewsParams) ([]GetOverdueReviewsRow, error) {
+	rows, err := q.db.Query(ctx, getOverdueReviews, arg.Now, arg.MaxRows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetOverdueReviewsRow{}
+	for rows.Next() {
+		var i GetOverdueReviewsRow
+		if err := rows.Scan(
+			&i.PullRequestID,
+			&i.ReviewerID,
+			&i.AssignedAt,
+			&i.TeamName,
+			&i.SlaAction,
+			&i.LeadUserID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPRsByReviewer = `-- name: GetPRsByReviewer :many
 SELECT DISTINCT pr.id, pr.name, pr.author_id, pr.status, pr.created_at
 FROM pull_requests pr
@@ -118,8 +234,96 @@ func (q *Queries) GetPRsByReviewer(ctx context.Context, reviewerID string) ([]Ge
 	return items, nil
 }
 
+const getPendingPRsByReviewer = `-- name: GetPendingPRsByReviewer :many
+SELECT pr.id, pr.name, pr.author_id, pr.status, pr.created_at
+FROM pull_requests pr
+INNER JOIN pr_reviewers prr ON pr.id = prr.pull_request_id
+WHERE prr.reviewer_id = $1 AND prr.state = 'PENDING' AND pr.status = 'OPEN'
+ORDER BY pr.created_at DESC
+`
+
+type GetPendingPRsByReviewerRow struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	AuthorID  string             `json:"author_id"`
+	Status    string             `json:"status"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) GetPendingPRsByReviewer(ctx context.Context, reviewerID string) ([]GetPendingPRsByReviewerRow, error) {
+	rows, err := q.db.Query(ctx, getPendingPRsByReviewer, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPendingPRsByReviewerRow{}
+	for rows.Next() {
+		var i GetPendingPRsByReviewerRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AuthorID,
+			&i.Status,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPendingReviewsWithSLA = `-- name: GetPendingReviewsWithSLA :many
+SELECT pr.id, pr.name, pr.author_id, pr.status, prr.assigned_at, t.review_sla_minutes
+FROM pull_requests pr
+INNER JOIN pr_reviewers prr ON pr.id = prr.pull_request_id
+INNER JOIN users a ON a.id = pr.author_id
+INNER JOIN teams t ON t.name = a.team_name
+WHERE prr.reviewer_id = $1 AND prr.state = 'PENDING' AND pr.status = 'OPEN'
+ORDER BY prr.assigned_at
+`
+
+type GetPendingReviewsWithSLARow struct {
+	ID               string             `json:"id"`
+	Name             string             `json:"name"`
+	AuthorID         string             `json:"author_id"`
+	Status           string             `json:"status"`
+	AssignedAt       pgtype.Timestamptz `json:"assigned_at"`
+	ReviewSlaMinutes int32              `json:"review_sla_minutes"`
+}
+
+func (q *Queries) GetPendingReviewsWithSLA(ctx context.Context, reviewerID string) ([]GetPendingReviewsWithSLARow, error) {
+	rows, err := q.db.Query(ctx, getPendingReviewsWithSLA, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPendingReviewsWithSLARow{}
+	for rows.Next() {
+		var i GetPendingReviewsWithSLARow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AuthorID,
+			&i.Status,
+			&i.AssignedAt,
+			&i.ReviewSlaMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPullRequestByID = `-- name: GetPullRequestByID :one
-SELECT id, name, author_id, status, created_at, merged_at
+SELECT id, name, author_id, status, created_at, merged_at, force_merged, closed_at
 FROM pull_requests
 WHERE id = $1
 `
@@ -134,6 +338,8 @@ func (q *Queries) GetPullRequestByID(ctx context.Context, id string) (PullReques
 		&i.Status,
 		&i.CreatedAt,
 		&i.MergedAt,
+		&i.ForceMerged,
+		&i.ClosedAt,
 	)
 	return i, err
 }
@@ -165,20 +371,440 @@ func (q *Queries) GetReviewersByPRID(ctx context.Context, pullRequestID string)
 	return items, nil
 }
 
+const getReviewsByPRID = `-- name: GetReviewsByPRID :many
+SELECT reviewer_id, state, state_updated_at, escalated_at
+FROM pr_reviewers
+WHERE pull_request_id = $1
+ORDER BY assigned_at
+`
+
+type GetReviewsByPRIDRow struct {
+	ReviewerID     string             `json:"reviewer_id"`
+	State          string             `json:"state"`
+	StateUpdatedAt pgtype.Timestamptz `json:"state_updated_at"`
+	EscalatedAt    pgtype.Timestamptz `json:"escalated_at"`
+}
+
+func (q *Queries) GetReviewsByPRID(ctx context.Context, pullRequestID string) ([]GetReviewsByPRIDRow, error) {
+	rows, err := q.db.Query(ctx, getReviewsByPRID, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetReviewsByPRIDRow{}
+	for rows.Next() {
+		var i GetReviewsByPRIDRow
+		if err := rows.Scan(
+			&i.ReviewerID,
+			&i.State,
+			&i.StateUpdatedAt,
+			&i.EscalatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPRsByReviewer = `-- name: ListPRsByReviewer :many
+SELECT pr.id, pr.name, pr.author_id, pr.status, pr.created_at
+FROM pull_requests pr
+INNER JOIN pr_reviewers prr ON pr.id = prr.pull_request_id
+INNER JOIN users a ON a.id = pr.author_id
+WHERE prr.reviewer_id = $1
+  AND ($2::varchar IS NULL OR pr.status = $2)
+  AND (NOT $3::boolean OR (prr.state = 'PENDING' AND pr.status = 'OPEN'))
+  AND ($4::timestamptz IS NULL OR pr.created_at >= $4)
+  AND ($5::timestamptz IS NULL OR pr.created_at < $5)
+  AND ($6::varchar IS NULL OR a.team_name = $6)
+  AND ($7::timestamptz IS NULL
+       OR (pr.created_at, pr.id) < ($7, $8::varchar))
+ORDER BY pr.created_at DESC, pr.id DESC
+LIMIT $9
+`
+
+type ListPRsByReviewerParams struct {
+	ReviewerID     string             `json:"reviewer_id"`
+	Status         *string            `json:"status"`
+	PendingOnly    bool               `json:"pending_only"`
+	CreatedAfter   pgtype.Timestamptz `json:"created_after"`
+	CreatedBefore  pgtype.Timestamptz `json:"created_before"`
+	TeamName       *string            `json:"team_name"`
+	AfterCreatedAt pgtype.Timestamptz `json:"after_created_at"`
+	AfterID        *string            `json:"after_id"`
+	MaxRows        int32              `json:"max_rows"`
+}
+
+type ListPRsByReviewerRow struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	AuthorID  string             `json:"author_id"`
+	Status    string             `json:"status"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// Newest first; a page continues after the (created_at, id) of the previous page's last row
+func (q *Queries) ListPRsByReviewer(ctx context.Context, arg ListPRsByReviewerParams) ([]ListPRsByReviewerRow, error) {
+	rows, err := q.db.Query(ctx, listPRsByReviewer,
+		arg.ReviewerID,
+		arg.Status,
+		arg.PendingOnly,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.TeamName,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.MaxRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPRsByReviewerRow{}
+	for rows.Next() {
+		var i ListPRsByReviewerRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AuthorID,
+			&i.Status,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPRsByReviewerAsc = `-- name: ListPRsByReviewerAsc :many
+SELECT pr.id, pr.name, pr.author_id, pr.status, pr.created_at
+FROM pull_requests pr
+INNER JOIN pr_reviewers prr ON pr.id = prr.pull_request_id
+INNER JOIN users a ON a.id = pr.author_id
+WHERE prr.reviewer_id = $1
+  AND ($2::varchar IS NULL OR pr.status = $2)
+  AND (NOT $3::boolean OR (prr.state = 'PENDING' AND pr.status = 'OPEN'))
+  AND ($4::timestamptz IS NULL OR pr.created_at >= $4)
+  AND ($5::timestamptz IS NULL OR pr.created_at < $5)
+  AND ($6::varchar IS NULL OR a.team_name = $6)
+  AND ($7::timestamptz IS NULL
+       OR (pr.created_at, pr.id) > ($7, $8::varchar))
+ORDER BY pr.created_at ASC, pr.id ASC
+LIMIT $9
+`
+
+type ListPRsByReviewerAscParams struct {
+	ReviewerID     string             `json:"reviewer_id"`
+	Status         *string            `json:"status"`
+	PendingOnly    bool               `json:"pending_only"`
+	CreatedAfter   pgtype.Timestamptz `json:"created_after"`
+	CreatedBefore  pgtype.Timestamptz `json:"created_before"`
+	TeamName       *string            `json:"team_name"`
+	AfterCreatedAt pgtype.Timestamptz `json:"after_created_at"`
+	AfterID        *string            `json:"after_id"`
+	MaxRows        int32              `json:"max_rows"`
+}
+
+type ListPRsByReviewerAscRow struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	AuthorID  string             `json:"author_id"`
+	Status    string             `json:"status"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// Oldest first; a page continues after the (created_at, id) of the previous page's last row
+func (q *Queries) ListPRsByReviewerAsc(ctx context.Context, arg ListPRsByReviewerAscParams) ([]ListPRsByReviewerAscRow, error) {
+	rows, err := q.db.Query(ctx, listPRsByReviewerAsc,
+		arg.ReviewerID,
+		arg.Status,
+		arg.PendingOnly,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.TeamName,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.MaxRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPRsByReviewerAscRow{}
+	for rows.Next() {
+		var i ListPRsByReviewerAscRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AuthorID,
+			&i.Status,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPullRequests = `-- name: ListPullRequests :many
+SELECT pr.id, pr.name, pr.author_id, a.team_name, pr.status, pr.created_at, pr.merged_at, pr.closed_at,
+       COALESCE(r.reviewer_ids, '{}')::text[] AS reviewer_ids
+FROM pull_requests pr
+INNER JOIN users a ON a.id = pr.author_id
+LEFT JOIN LATERAL (
+    SELECT array_agg(prr.reviewer_id ORDER BY prr.assigned_at) AS reviewer_ids
+    FROM pr_reviewers prr
+    WHERE prr.pull_request_id = pr.id
+) r ON true
+WHERE ($1::varchar IS NULL OR pr.author_id = $1)
+  AND ($2::varchar IS NULL OR a.team_name = $2)
+  AND ($3::varchar IS NULL OR pr.status = $3)
+  AND ($4::varchar IS NULL OR EXISTS (
+      SELECT 1 FROM pr_reviewers x
+      WHERE x.pull_request_id = pr.id AND x.reviewer_id = $4
+  ))
+  AND (NOT $5::boolean OR r.reviewer_ids IS NULL)
+  AND ($6::timestamptz IS NULL OR pr.created_at >= $6)
+  AND ($7::timestamptz IS NULL OR pr.created_at < $7)
+  AND ($8::varchar IS NULL OR pr.name ILIKE $8)
+  AND ($9::varchar IS NULL
+       OR to_tsvector('simple', pr.name) @@ websearch_to_tsquery('simple', $9))
+  AND ($10::timestamptz IS NULL
+       OR (pr.created_at, pr.id) < ($10, $11::varchar))
+ORDER BY pr.created_at DESC, pr.id DESC
+LIMIT $12
+`
+
+type ListPullRequestsParams struct {
+	AuthorID       *string            `json:"author_id"`
+	TeamName       *string            `json:"team_name"`
+	Status         *string            `json:"status"`
+	ReviewerID     *string            `json:"reviewer_id"`
+	NoReviewers    bool               `json:"no_reviewers"`
+	CreatedAfter   pgtype.Timestamptz `json:"created_after"`
+	CreatedBefore  pgtype.Timestamptz `json:"created_before"`
+	NamePattern    *string            `json:"name_pattern"`
+	Search         *string            `json:"search"`
+	AfterCreatedAt pgtype.Timestamptz `json:"after_created_at"`
+	AfterID        *string            `json:"after_id"`
+	MaxRows        int32              `json:"max_rows"`
+}
+
+type ListPullRequestsRow struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	AuthorID    string             `json:"author_id"`
+	TeamName    string             `json:"team_name"`
+	Status      string             `json:"status"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	MergedAt    pgtype.Timestamptz `json:"merged_at"`
+	ClosedAt    pgtype.Timestamptz `json:"closed_at"`
+	ReviewerIds []string           `json:"reviewer_ids"`
+}
+
+// Newest first with reviewers in assignment order; name_pattern is an ILIKE pattern, search a web-style full-text query
+func (q *Queries) ListPullRequests(ctx context.Context, arg ListPullRequestsParams) ([]ListPullRequestsRow, error) {
+	rows, err := q.db.Query(ctx, listPullRequests,
+		arg.AuthorID,
+		arg.TeamName,
+		arg.Status,
+		arg.ReviewerID,
+		arg.NoReviewers,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.NamePattern,
+		arg.Search,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.MaxRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPullRequestsRow{}
+	for rows.Next() {
+		var i ListPullRequestsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AuthorID,
+			&i.TeamName,
+			&i.Status,
+			&i.CreatedAt,
+			&i.MergedAt,
+			&i.ClosedAt,
+			&i.ReviewerIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPullRequestsAsc = `-- name: ListPullRequestsAsc :many
+SELECT pr.id, pr.name, pr.author_id, a.team_name, pr.status, pr.created_at, pr.merged_at, pr.closed_at,
+       COALESCE(r.reviewer_ids, '{}')::text[] AS reviewer_ids
+FROM pull_requests pr
+INNER JOIN users a ON a.id = pr.author_id
+LEFT JOIN LATERAL (
+    SELECT array_agg(prr.reviewer_id ORDER BY prr.assigned_at) AS reviewer_ids
+    FROM pr_reviewers prr
+    WHERE prr.pull_request_id = pr.id
+) r ON true
+WHERE ($1::varchar IS NULL OR pr.author_id = $1)
+  AND ($2::varchar IS NULL OR a.team_name = $2)
+  AND ($3::varchar IS NULL OR pr.status = $3)
+  AND ($4::varchar IS NULL OR EXISTS (
+      SELECT 1 FROM pr_reviewers x
+      WHERE x.pull_request_id = pr.id AND x.reviewer_id = $4
+  ))
+  AND (NOT $5::boolean OR r.reviewer_ids IS NULL)
+  AND ($6::timestamptz IS NULL OR pr.created_at >= $6)
+  AND ($7::timestamptz IS NULL OR pr.created_at < $7)
+  AND ($8::varchar IS NULL OR pr.name ILIKE $8)
+  AND ($9::varchar IS NULL
+       OR to_tsvector('simple', pr.name) @@ websearch_to_tsquery('simple', $9))
+  AND ($10::timestamptz IS NULL
+       OR (pr.created_at, pr.id) > ($10, $11::varchar))
+ORDER BY pr.created_at ASC, pr.id ASC
+LIMIT $12
+`
+
+type ListPullRequestsAscParams struct {
+	AuthorID       *string            `json:"author_id"`
+	TeamName       *string            `json:"team_name"`
+	Status         *string            `json:"status"`
+	ReviewerID     *string            `json:"reviewer_id"`
+	NoReviewers    bool               `json:"no_reviewers"`
+	CreatedAfter   pgtype.Timestamptz `json:"created_after"`
+	CreatedBefore  pgtype.Timestamptz `json:"created_before"`
+	NamePattern    *string            `json:"name_pattern"`
+	Search         *string            `json:"search"`
+	AfterCreatedAt pgtype.Timestamptz `json:"after_created_at"`
+	AfterID        *string            `json:"after_id"`
+	MaxRows        int32              `json:"max_rows"`
+}
+
+type ListPullRequestsAscRow struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	AuthorID    string             `json:"author_id"`
+	TeamName    string             `json:"team_name"`
+	Status      string             `json:"status"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	MergedAt    pgtype.Timestamptz `json:"merged_at"`
+	ClosedAt    pgtype.Timestamptz `json:"closed_at"`
+	ReviewerIds []string           `json:"reviewer_ids"`
+}
+
+// Oldest first; otherwise the same as ListPullRequests
+func (q *Queries) ListPullRequestsAsc(ctx context.Context, arg ListPullRequestsAscParams) ([]ListPullRequestsAscRow, error) {
+	rows, err := q.db.Query(ctx, listPullRequestsAsc,
+		arg.AuthorID,
+		arg.TeamName,
+		arg.Status,
+		arg.ReviewerID,
+		arg.NoReviewers,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.NamePattern,
+		arg.Search,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.MaxRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPullRequestsAscRow{}
+	for rows.Next() {
+		var i ListPullRequestsAscRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AuthorID,
+			&i.TeamName,
+			&i.Status,
+			&i.CreatedAt,
+			&i.MergedAt,
+			&i.ClosedAt,
+			&i.ReviewerIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockPullRequest = `-- name: LockPullRequest :one
+SELECT status FROM pull_requests WHERE id = $1 FOR UPDATE
+`
+
+// Locks the PR row, so a reviewer count check and the change that follows can't interleave with another change
+func (q *Queries) LockPullRequest(ctx context.Context, id string) (string, error) {
+	row := q.db.QueryRow(ctx, lockPullRequest, id)
+	var status string
+	err := row.Scan(&status)
+	return status, err
+}
+
+const markReviewEscalated = `-- name: MarkReviewEscalated :execrows
+UPDATE pr_reviewers
+SET escalated_at = $3
+WHERE pull_request_id = $1 AND reviewer_id = $2 AND escalated_at IS NULL
+`
+
+type MarkReviewEscalatedParams struct {
+	PullRequestID string             `json:"pull_request_id"`
+	ReviewerID    string             `json:"reviewer_id"`
+	EscalatedAt   pgtype.Timestamptz `json:"escalated_at"`
+}
+
+func (q *Queries) MarkReviewEscalated(ctx context.Context, arg MarkReviewEscalatedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markReviewEscalated, arg.PullRequestID, arg.ReviewerID, arg.EscalatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const mergePullRequest = `-- name: MergePullRequest :one
 UPDATE pull_requests
-SET status = 'MERGED', merged_at = $2
-WHERE id = $1 AND status != 'MERGED'
-RETURNING id, name, author_id, status, created_at, merged_at
+SET status = 'MERGED', merged_at = $2, force_merged = $3
+WHERE id = $1 AND status = 'OPEN'
+RETURNING id, name, author_id, status, created_at, merged_at, force_merged, closed_at
 `
 
 type MergePullRequestParams struct {
-	ID       string             `json:"id"`
-	MergedAt pgtype.Timestamptz `json:"merged_at"`
+	ID          string             `json:"id"`
+	MergedAt    pgtype.Timestamptz `json:"merged_at"`
+	ForceMerged bool               `json:"force_merged"`
 }
 
 func (q *Queries) MergePullRequest(ctx context.Context, arg MergePullRequestParams) (PullRequest, error) {
-	row := q.db.QueryRow(ctx, mergePullRequest, arg.ID, arg.MergedAt)
+	row := q.db.QueryRow(ctx, mergePullRequest, arg.ID, arg.MergedAt, arg.ForceMerged)
 	var i PullRequest
 	err := row.Scan(
 		&i.ID,
@@ -187,6 +813,8 @@ func (q *Queries) MergePullRequest(ctx context.Context, arg MergePullRequestPara
 		&i.Status,
 		&i.CreatedAt,
 		&i.MergedAt,
+		&i.ForceMerged,
+		&i.ClosedAt,
 	)
 	return i, err
 }
@@ -202,6 +830,22 @@ func (q *Queries) PullRequestExists(ctx context.Context, id string) (bool, error
 	return exists, err
 }
 
+const removeOpenReviewsByReviewers = `-- name: RemoveOpenReviewsByReviewers :execrows
+DELETE FROM pr_reviewers prr
+USING pull_requests pr
+WHERE pr.id = prr.pull_request_id
+  AND pr.status = 'OPEN'
+  AND prr.reviewer_id = ANY($1::text[])
+`
+
+func (q *Queries) RemoveOpenReviewsByReviewers(ctx context.Context, reviewerIds []string) (int64, error) {
+	result, err := q.db.Exec(ctx, removeOpenReviewsByReviewers, reviewerIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const removeReviewer = `-- name: RemoveReviewer :exec
 DELETE FROM pr_reviewers
 WHERE pull_request_id = $1 AND reviewer_id = $2
@@ -217,6 +861,58 @@ func (q *Queries) RemoveReviewer(ctx context.Context, arg RemoveReviewerParams)
 	return err
 }
 
+const setReviewState = `-- name: SetReviewState :execrows
+UPDATE pr_reviewers
+SET state = $3, state_updated_at = $4
+WHERE pull_request_id = $1 AND reviewer_id = $2
+`
+
+type SetReviewStateParams struct {
+	PullRequestID  string             `json:"pull_request_id"`
+	ReviewerID     string             `json:"reviewer_id"`
+	State          string             `json:"state"`
+	StateUpdatedAt pgtype.Timestamptz `json:"state_updated_at"`
+}
+
+func (q *Queries) SetReviewState(ctx context.Context, arg SetReviewStateParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setReviewState,
+		arg.PullRequestID,
+		arg.ReviewerID,
+		arg.State,
+		arg.StateUpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const transitionPullRequest = `-- name: TransitionPullRequest :execrows
+UPDATE pull_requests
+SET status = $1, closed_at = $2
+WHERE id = $3 AND status = $4
+`
+
+type TransitionPullRequestParams struct {
+	Status     string             `json:"status"`
+	ClosedAt   pgtype.Timestamptz `json:"closed_at"`
+	ID         string             `json:"id"`
+	FromStatus string             `json:"from_status"`
+}
+
+func (q *Queries) TransitionPullRequest(ctx context.Context, arg TransitionPullRequestParams) (int64, error) {
+	result, err := q.db.Exec(ctx, transitionPullRequest,
+		arg.Status,
+		arg.ClosedAt,
+		arg.ID,
+		arg.FromStatus,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updatePullRequest = `-- name: UpdatePullRequest :exec
 UPDATE pull_requests
 SET name = $2, author_id = $3, status = $4, merged_at = $5
@@ -6,11 +6,20 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
 	AddReviewer(ctx context.Context, arg AddReviewerParams) error
+	AddReviewersBulk(ctx context.Context, arg AddReviewersBulkParams) error
+	// Moves the team's round-robin cursor past the next picks of the sorted candidate_ids in one statement,
+	// so concurrent selections never start from the same cursor; returns the new cursor
+	AdvanceReviewerCursor(ctx context.Context, arg AdvanceReviewerCursorParams) (string, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]ClaimDueWebhookDeliveriesRow, error)
+	ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]ClaimOutboxEventsRow, error)
 	CountActiveUsers(ctx context.Context) (int64, error)
+	CountOpenReviewsByUsers(ctx context.Context, userIds []string) ([]CountOpenReviewsByUsersRow, error)
 	CountPullRequests(ctx context.Context) (int64, error)
 	CountPullRequestsByStatus(ctx context.Context, status string) (int64, error)
 	CountTeams(ctx context.Context) (int64, error)
@@ -18,22 +27,91 @@ type Querier interface {
 	CreatePullRequest(ctx context.Context, arg CreatePullRequestParams) error
 	CreateTeam(ctx context.Context, name string) error
 	CreateUser(ctx context.Context, arg CreateUserParams) error
+	CreateWebhookDeadLetter(ctx context.Context, arg CreateWebhookDeadLetterParams) error
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
 	DeactivateTeamUsers(ctx context.Context, teamName string) (int64, error)
+	// Deactivates active members of the team that are not in keep_ids
+	DeactivateTeamUsersExcept(ctx context.Context, arg DeactivateTeamUsersExceptParams) ([]string, error)
+	DeleteDeliveredWebhooksBefore(ctx context.Context, deliveredAt pgtype.Timestamptz) (int64, error)
+	DeletePublishedOutboxEvents(ctx context.Context, publishedAt pgtype.Timestamptz) (int64, error)
+	DeleteStreamEventsBefore(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
+	DeleteWebhookDeadLetter(ctx context.Context, id int64) (WebhookDeadLetter, error)
+	DeleteWebhookDelivery(ctx context.Context, id int64) error
+	DeleteWebhookSubscription(ctx context.Context, id int64) (int64, error)
+	EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) error
+	EnsureTeam(ctx context.Context, name string) error
 	GetActiveUsersByTeam(ctx context.Context, arg GetActiveUsersByTeamParams) ([]User, error)
+	GetDigestRecipients(ctx context.Context) ([]User, error)
+	GetLatestStreamSeq(ctx context.Context) (int64, error)
+	// Reviews of the given users on OPEN PRs with the team of the PR author and everyone currently reviewing the PR
+	GetOpenReviewsByReviewers(ctx context.Context, userIds []string) ([]GetOpenReviewsByReviewersRow, error)
+	GetOverdueReviews(ctx context.Context, arg GetOverdueReviewsParams) ([]GetOverdueReviewsRow, error)
+	GetPREvents(ctx context.Context, pullRequestID string) ([]PrEvent, error)
 	GetPRsByReviewer(ctx context.Context, reviewerID string) ([]GetPRsByReviewerRow, error)
+	GetPendingPRsByReviewer(ctx context.Context, reviewerID string) ([]GetPendingPRsByReviewerRow, error)
+	GetPendingReviewsWithSLA(ctx context.Context, reviewerID string) ([]GetPendingReviewsWithSLARow, error)
 	GetPullRequestByID(ctx context.Context, id string) (PullRequest, error)
+	// Active members of the teams below their max_open_reviews, with their OPEN review load
+	GetReplacementCandidates(ctx context.Context, arg GetReplacementCandidatesParams) ([]GetReplacementCandidatesRow, error)
+	GetReviewCandidates(ctx context.Context, arg GetReviewCandidatesParams) ([]GetReviewCandidatesRow, error)
 	GetReviewersByPRID(ctx context.Context, pullRequestID string) ([]string, error)
+	GetReviewsByPRID(ctx context.Context, pullRequestID string) ([]GetReviewsByPRIDRow, error)
 	GetStats(ctx context.Context) (GetStatsRow, error)
 	GetTeamByName(ctx context.Context, name string) (string, error)
+	GetTeamMemberships(ctx context.Context, userID string) ([]TeamMembership, error)
+	GetTeamPolicy(ctx context.Context, name string) (GetTeamPolicyRow, error)
+	// Teams whose lead_user_id is one of the given users
+	GetTeamsLedBy(ctx context.Context, leadIds []string) ([]string, error)
 	GetUserByID(ctx context.Context, id string) (User, error)
+	GetUsersByIDs(ctx context.Context, ids []string) ([]User, error)
+	// Locks the rows until the end of the transaction
+	GetUsersByIDsForUpdate(ctx context.Context, ids []string) ([]User, error)
 	GetUsersByTeam(ctx context.Context, teamName string) ([]User, error)
+	InsertOutboxEvent(ctx context.Context, arg InsertOutboxEventParams) error
+	// Empty strings in the arrays are stored as NULL
+	InsertPREvents(ctx context.Context, arg InsertPREventsParams) error
+	InsertStreamEvent(ctx context.Context, arg InsertStreamEventParams) (int64, error)
+	// Records that each user moved from the team at the same position in from_teams to to_team
+	InsertTeamMemberships(ctx context.Context, arg InsertTeamMembershipsParams) error
+	// Newest first; a page continues after the (created_at, id) of the previous page's last row
+	ListPRsByReviewer(ctx context.Context, arg ListPRsByReviewerParams) ([]ListPRsByReviewerRow, error)
+	// Oldest first; a page continues after the (created_at, id) of the previous page's last row
+	ListPRsByReviewerAsc(ctx context.Context, arg ListPRsByReviewerAscParams) ([]ListPRsByReviewerAscRow, error)
+	// Newest first with reviewers in assignment order; name_pattern is an ILIKE pattern, search a web-style full-text query
+	ListPullRequests(ctx context.Context, arg ListPullRequestsParams) ([]ListPullRequestsRow, error)
+	// Oldest first; otherwise the same as ListPullRequests
+	ListPullRequestsAsc(ctx context.Context, arg ListPullRequestsAscParams) ([]ListPullRequestsAscRow, error)
+	ListStreamEventsAfter(ctx context.Context, arg ListStreamEventsAfterParams) ([]EventStream, error)
+	// A team is active while it has at least one active member
+	ListTeams(ctx context.Context, isActive *bool) ([]ListTeamsRow, error)
+	ListWebhookDeadLetters(ctx context.Context, subscriptionID *int64) ([]WebhookDeadLetter, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error)
+	// Locks the PR row, so a reviewer count check and the change that follows can't interleave with another change
+	LockPullRequest(ctx context.Context, id string) (string, error)
+	MarkOutboxPublished(ctx context.Context, arg MarkOutboxPublishedParams) error
+	MarkReviewEscalated(ctx context.Context, arg MarkReviewEscalatedParams) (int64, error)
+	MarkWebhookDelivered(ctx context.Context, arg MarkWebhookDeliveredParams) error
 	MergePullRequest(ctx context.Context, arg MergePullRequestParams) (PullRequest, error)
 	PullRequestExists(ctx context.Context, id string) (bool, error)
+	RemoveOpenReviewsByReviewers(ctx context.Context, reviewerIds []string) (int64, error)
 	RemoveReviewer(ctx context.Context, arg RemoveReviewerParams) error
+	ScheduleOutboxRetry(ctx context.Context, arg ScheduleOutboxRetryParams) error
+	ScheduleWebhookRetry(ctx context.Context, arg ScheduleWebhookRetryParams) error
+	// pattern and prefix are ILIKE patterns matching the query anywhere and at the start;
+	// an exact ID match comes first, then ID or username prefixes, then other matches by username
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error)
+	SetReviewState(ctx context.Context, arg SetReviewStateParams) (int64, error)
+	SetUserEmailSettings(ctx context.Context, arg SetUserEmailSettingsParams) error
 	SetUserIsActive(ctx context.Context, arg SetUserIsActiveParams) error
+	SetUserLastDigestAt(ctx context.Context, arg SetUserLastDigestAtParams) error
+	SetUserMaxOpenReviews(ctx context.Context, arg SetUserMaxOpenReviewsParams) error
+	SetUserTeam(ctx context.Context, arg SetUserTeamParams) error
 	TeamExists(ctx context.Context, name string) (bool, error)
+	TransitionPullRequest(ctx context.Context, arg TransitionPullRequestParams) (int64, error)
 	UpdatePullRequest(ctx context.Context, arg UpdatePullRequestParams) error
+	UpdateTeamPolicy(ctx context.Context, arg UpdateTeamPolicyParams) error
 	UpdateUser(ctx context.Context, arg UpdateUserParams) error
+	// A NULL max_open_reviews keeps the stored limit, so /team/add can't clear it; /users/update can
 	UpsertUser(ctx context.Context, arg UpsertUserParams) error
 	UserExists(ctx context.Context, id string) (bool, error)
 }
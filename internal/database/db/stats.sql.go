@@ -14,6 +14,8 @@ SELECT
     (SELECT COUNT(*) FROM pull_requests) as total_prs,
     (SELECT COUNT(*) FROM pull_requests WHERE status = 'OPEN') as open_prs,
     (SELECT COUNT(*) FROM pull_requests WHERE status = 'MERGED') as merged_prs,
+    (SELECT COUNT(*) FROM pull_requests WHERE status = 'DRAFT') as draft_prs,
+    (SELECT COUNT(*) FROM pull_requests WHERE status = 'CLOSED') as closed_prs,
     (SELECT COUNT(*) FROM teams) as total_teams,
     (SELECT COUNT(*) FROM users) as total_users,
     (SELECT COUNT(*) FROM users WHERE is_active = true) as active_users
@@ -23,6 +25,8 @@ type GetStatsRow struct {
 	TotalPrs    int64 `json:"total_prs"`
 	OpenPrs     int64 `json:"open_prs"`
 	MergedPrs   int64 `json:"merged_prs"`
+	DraftPrs    int64 `json:"draft_prs"`
+	ClosedPrs   int64 `json:"closed_prs"`
 	TotalTeams  int64 `json:"total_teams"`
 	TotalUsers  int64 `json:"total_users"`
 	ActiveUsers int64 `json:"active_users"`
@@ -35,6 +39,8 @@ func (q *Queries) GetStats(ctx context.Context) (GetStatsRow, error) {
 		&i.TotalPrs,
 		&i.OpenPrs,
 		&i.MergedPrs,
+		&i.DraftPrs,
+		&i.ClosedPrs,
 		&i.TotalTeams,
 		&i.TotalUsers,
 		&i.ActiveUsers,
@@ -9,6 +9,34 @@ import (
 	"context"
 )
 
+const advanceReviewerCursor = `-- name: AdvanceReviewerCursor :one
+INSERT INTO team_reviewer_cursors AS c (team_name, last_reviewer_id, updated_at)
+VALUES ($1, ($2::text[])[$3::int], NOW())
+ON CONFLICT (team_name)
+DO UPDATE SET
+    last_reviewer_id = ($2::text[])[
+        ((SELECT COUNT(*) FROM unnest($2::text[]) AS id WHERE id COLLATE "C" <= c.last_reviewer_id)::int
+            + $3::int - 1) % cardinality($2::text[]) + 1
+    ],
+    updated_at = EXCLUDED.updated_at
+RETURNING last_reviewer_id
+`
+
+type AdvanceReviewerCursorParams struct {
+	TeamName     string   `json:"team_name"`
+	CandidateIds []string `json:"candidate_ids"`
+	Picks        int32    `json:"picks"`
+}
+
+// Moves the team's round-robin cursor past the next picks of the sorted candidate_ids in one statement,
+// so concurrent selections never start from the same cursor; returns the new cursor
+func (q *Queries) AdvanceReviewerCursor(ctx context.Context, arg AdvanceReviewerCursorParams) (string, error) {
+	row := q.db.QueryRow(ctx, advanceReviewerCursor, arg.TeamName, arg.CandidateIds, arg.Picks)
+	var last_reviewer_id string
+	err := row.Scan(&last_reviewer_id)
+	return last_reviewer_id, err
+}
+
 const countTeams = `-- name: CountTeams :one
 SELECT COUNT(*) FROM teams
 `
@@ -29,6 +57,16 @@ func (q *Queries) CreateTeam(ctx context.Context, name string) error {
 	return err
 }
 
+const ensureTeam = `-- name: EnsureTeam :exec
+INSERT INTO teams (name) VALUES ($1)
+ON CONFLICT (name) DO NOTHING
+`
+
+func (q *Queries) EnsureTeam(ctx context.Context, name string) error {
+	_, err := q.db.Exec(ctx, ensureTeam, name)
+	return err
+}
+
 const getTeamByName = `-- name: GetTeamByName :one
 SELECT name FROM teams WHERE name = $1
 `
@@ -39,6 +77,108 @@ func (q *Queries) GetTeamByName(ctx context.Context, name string) (string, error
 	return name, err
 }
 
+const getTeamPolicy = `-- name: GetTeamPolicy :one
+SELECT min_reviewers, max_reviewers, required_approvals, block_on_changes_requested,
+       review_sla_minutes, sla_action, lead_user_id, chat_channel, chat_template
+FROM teams
+WHERE name = $1
+`
+
+type GetTeamPolicyRow struct {
+	MinReviewers            int32   `json:"min_reviewers"`
+	MaxReviewers            int32   `json:"max_reviewers"`
+	RequiredApprovals       int32   `json:"required_approvals"`
+	BlockOnChangesRequested bool    `json:"block_on_changes_requested"`
+	ReviewSlaMinutes        int32   `json:"review_sla_minutes"`
+	SlaAction               string  `json:"sla_action"`
+	LeadUserID              *string `json:"lead_user_id"`
+	ChatChannel             *string `json:"chat_channel"`
+	ChatTemplate            *string `json:"chat_template"`
+}
+
+func (q *Queries) GetTeamPolicy(ctx context.Context, name string) (GetTeamPolicyRow, error) {
+	row := q.db.QueryRow(ctx, getTeamPolicy, name)
+	var i GetTeamPolicyRow
+	err := row.Scan(
+		&i.MinReviewers,
+		&i.MaxReviewers,
+		&i.RequiredApprovals,
+		&i.BlockOnChangesRequested,
+		&i.ReviewSlaMinutes,
+		&i.SlaAction,
+		&i.LeadUserID,
+		&i.ChatChannel,
+		&i.ChatTemplate,
+	)
+	return i, err
+}
+
+const getTeamsLedBy = `-- name: GetTeamsLedBy :many
+SELECT name FROM teams
+WHERE lead_user_id = ANY($1::text[])
+ORDER BY name
+`
+
+// Teams whose lead_user_id is one of the given users
+func (q *Queries) GetTeamsLedBy(ctx context.Context, leadIds []string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getTeamsLedBy, leadIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTeams = `-- name: ListTeams :many
+SELECT t.name,
+       COUNT(u.id)::int AS member_count,
+       (COUNT(u.id) FILTER (WHERE u.is_active))::int AS active_count
+FROM teams t
+LEFT JOIN users u ON u.team_name = t.name
+GROUP BY t.name
+HAVING $1::boolean IS NULL
+    OR (COUNT(u.id) FILTER (WHERE u.is_active) > 0) = $1
+ORDER BY t.name
+`
+
+type ListTeamsRow struct {
+	Name        string `json:"name"`
+	MemberCount int32  `json:"member_count"`
+	ActiveCount int32  `json:"active_count"`
+}
+
+// A team is active while it has at least one active member
+func (q *Queries) ListTeams(ctx context.Context, isActive *bool) ([]ListTeamsRow, error) {
+	rows, err := q.db.Query(ctx, listTeams, isActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTeamsRow{}
+	for rows.Next() {
+		var i ListTeamsRow
+		if err := rows.Scan(&i.Name, &i.MemberCount, &i.ActiveCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const teamExists = `-- name: TeamExists :one
 SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1)
 `
@@ -49,3 +189,40 @@ func (q *Queries) TeamExists(ctx context.Context, name string) (bool, error) {
 	err := row.Scan(&exists)
 	return exists, err
 }
+
+const updateTeamPolicy = `-- name: UpdateTeamPolicy :exec
+UPDATE teams
+SET min_reviewers = $2, max_reviewers = $3, required_approvals = $4, block_on_changes_requested = $5,
+    review_sla_minutes = $6, sla_action = $7, lead_user_id = $8,
+    chat_channel = $9, chat_template = $10
+WHERE name = $1
+`
+
+type UpdateTeamPolicyParams struct {
+	Name                    string  `json:"name"`
+	MinReviewers            int32   `json:"min_reviewers"`
+	MaxReviewers            int32   `json:"max_reviewers"`
+	RequiredApprovals       int32   `json:"required_approvals"`
+	BlockOnChangesRequested bool    `json:"block_on_changes_requested"`
+	ReviewSlaMinutes        int32   `json:"review_sla_minutes"`
+	SlaAction               string  `json:"sla_action"`
+	LeadUserID              *string `json:"lead_user_id"`
+	ChatChannel             *string `json:"chat_channel"`
+	ChatTemplate            *string `json:"chat_template"`
+}
+
+func (q *Queries) UpdateTeamPolicy(ctx context.Context, arg UpdateTeamPolicyParams) error {
+	_, err := q.db.Exec(ctx, updateTeamPolicy,
+		arg.Name,
+		arg.MinReviewers,
+		arg.MaxReviewers,
+		arg.RequiredApprovals,
+		arg.BlockOnChangesRequested,
+		arg.ReviewSlaMinutes,
+		arg.SlaAction,
+		arg.LeadUserID,
+		arg.ChatChannel,
+		arg.ChatTemplate,
+	)
+	return err
+}
@@ -7,6 +7,8 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const countActiveUsers = `-- name: CountActiveUsers :one
@@ -20,6 +22,39 @@ func (q *Queries) CountActiveUsers(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countOpenReviewsByUsers = `-- name: CountOpenReviewsByUsers :many
+SELECT prr.reviewer_id, COUNT(*)::int AS open_reviews
+FROM pr_reviewers prr
+INNER JOIN pull_requests pr ON pr.id = prr.pull_request_id
+WHERE prr.reviewer_id = ANY($1::text[]) AND pr.status = 'OPEN'
+GROUP BY prr.reviewer_id
+`
+
+type CountOpenReviewsByUsersRow struct {
+	ReviewerID  string `json:"reviewer_id"`
+	OpenReviews int32  `json:"open_reviews"`
+}
+
+func (q *Queries) CountOpenReviewsByUsers(ctx context.Context, userIds []string) ([]CountOpenReviewsByUsersRow, error) {
+	rows, err := q.db.Query(ctx, countOpenReviewsByUsers, userIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountOpenReviewsByUsersRow{}
+	for rows.Next() {
+		var i CountOpenReviewsByUsersRow
+		if err := rows.Scan(&i.ReviewerID, &i.OpenReviews); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countUsers = `-- name: CountUsers :one
 SELECT COUNT(*) FROM users
 `
@@ -32,15 +67,16 @@ func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
 }
 
 const createUser = `-- name: CreateUser :exec
-INSERT INTO users (id, username, team_name, is_active)
-VALUES ($1, $2, $3, $4)
+INSERT INTO users (id, username, team_name, is_active, max_open_reviews)
+VALUES ($1, $2, $3, $4, $5)
 `
 
 type CreateUserParams struct {
-	ID       string `json:"id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	ID             string `json:"id"`
+	Username       string `json:"username"`
+	TeamName       string `json:"team_name"`
+	IsActive       bool   `json:"is_active"`
+	MaxOpenReviews *int32 `json:"max_open_reviews"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
@@ -49,6 +85,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
 		arg.Username,
 		arg.TeamName,
 		arg.IsActive,
+		arg.MaxOpenReviews,
 	)
 	return err
 }
@@ -65,8 +102,41 @@ func (q *Queries) DeactivateTeamUsers(ctx context.Context, teamName string) (int
 	return result.RowsAffected(), nil
 }
 
+const deactivateTeamUsersExcept = `-- name: DeactivateTeamUsersExcept :many
+UPDATE users SET is_active = false
+WHERE team_name = $1 AND is_active = true
+  AND NOT (id = ANY($2::text[]))
+RETURNING id
+`
+
+type DeactivateTeamUsersExceptParams struct {
+	TeamName string   `json:"team_name"`
+	KeepIds  []string `json:"keep_ids"`
+}
+
+// Deactivates active members of the team that are not in keep_ids
+func (q *Queries) DeactivateTeamUsersExcept(ctx context.Context, arg DeactivateTeamUsersExceptParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, deactivateTeamUsersExcept, arg.TeamName, arg.KeepIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getActiveUsersByTeam = `-- name: GetActiveUsersByTeam :many
-SELECT id, username, team_name, is_active 
+SELECT id, username, team_name, is_active, max_open_reviews, email, email_opt_out, quiet_hours_start, quiet_hours_end, timezone, last_digest_at
 FROM users 
 WHERE team_name = $1 AND is_active = true AND id != $2
 ORDER BY username
@@ -91,6 +161,165 @@ func (q *Queries) GetActiveUsersByTeam(ctx context.Context, arg GetActiveUsersBy
 			&i.Username,
 			&i.TeamName,
 			&i.IsActive,
+			&i.MaxOpenReviews,
+			&i.Email,
+			&i.EmailOptOut,
+			&i.QuietHoursStart,
+			&i.QuietHoursEnd,
+			&i.Timezone,
+			&i.LastDigestAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDigestRecipients = `-- name: GetDigestRecipients :many
+SELECT id, username, team_name, is_active, max_open_reviews, email, email_opt_out, quiet_hours_start, quiet_hours_end, timezone, last_digest_at
+FROM users u
+WHERE u.is_active = true
+  AND u.email IS NOT NULL
+  AND u.email_opt_out = false
+  AND EXISTS (
+      SELECT 1
+      FROM pr_reviewers prr
+      INNER JOIN pull_requests pr ON pr.id = prr.pull_request_id
+      WHERE prr.reviewer_id = u.id AND prr.state = 'PENDING' AND pr.status = 'OPEN'
+  )
+ORDER BY u.id
+`
+
+func (q *Queries) GetDigestRecipients(ctx context.Context) ([]User, error) {
+	rows, err := q.db.Query(ctx, getDigestRecipients)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.TeamName,
+			&i.IsActive,
+			&i.MaxOpenReviews,
+			&i.Email,
+			&i.EmailOptOut,
+			&i.QuietHoursStart,
+			&i.QuietHoursEnd,
+			&i.Timezone,
+			&i.LastDigestAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReplacementCandidates = `-- name: GetReplacementCandidates :many
+SELECT u.id, u.team_name, u.max_open_reviews, COUNT(pr.id)::int AS open_reviews
+FROM users u
+LEFT JOIN pr_reviewers prr ON prr.reviewer_id = u.id
+LEFT JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.status = 'OPEN'
+WHERE u.team_name = ANY($1::text[])
+  AND u.is_active = true
+  AND NOT (u.id = ANY($2::text[]))
+GROUP BY u.id
+HAVING u.max_open_reviews IS NULL OR COUNT(pr.id) < u.max_open_reviews
+ORDER BY u.id
+`
+
+type GetReplacementCandidatesParams struct {
+	TeamNames  []string `json:"team_names"`
+	ExcludeIds []string `json:"exclude_ids"`
+}
+
+type GetReplacementCandidatesRow struct {
+	ID             string `json:"id"`
+	TeamName       string `json:"team_name"`
+	MaxOpenReviews *int32 `json:"max_open_reviews"`
+	OpenReviews    int32  `json:"open_reviews"`
+}
+
+// Active members of the teams below their max_open_reviews, with their OPEN review load
+func (q *Queries) GetReplacementCandidates(ctx context.Context, arg GetReplacementCandidatesParams) ([]GetReplacementCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, getReplacementCandidates, arg.TeamNames, arg.ExcludeIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetReplacementCandidatesRow{}
+	for rows.Next() {
+		var i GetReplacementCandidatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamName,
+			&i.MaxOpenReviews,
+			&i.OpenReviews,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReviewCandidates = `-- name: GetReviewCandidates :many
+SELECT u.id, u.username, u.team_name, u.is_active, u.max_open_reviews, COUNT(pr.id)::int AS open_reviews
+FROM users u
+LEFT JOIN pr_reviewers prr ON prr.reviewer_id = u.id
+LEFT JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.status = 'OPEN'
+WHERE u.team_name = $1
+  AND u.is_active = true
+  AND NOT (u.id = ANY($2::text[]))
+GROUP BY u.id
+HAVING u.max_open_reviews IS NULL OR COUNT(pr.id) < u.max_open_reviews
+ORDER BY open_reviews, u.id
+`
+
+type GetReviewCandidatesParams struct {
+	TeamName   string   `json:"team_name"`
+	ExcludeIds []string `json:"exclude_ids"`
+}
+
+type GetReviewCandidatesRow struct {
+	ID             string `json:"id"`
+	Username       string `json:"username"`
+	TeamName       string `json:"team_name"`
+	IsActive       bool   `json:"is_active"`
+	MaxOpenReviews *int32 `json:"max_open_reviews"`
+	OpenReviews    int32  `json:"open_reviews"`
+}
+
+func (q *Queries) GetReviewCandidates(ctx context.Context, arg GetReviewCandidatesParams) ([]GetReviewCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, getReviewCandidates, arg.TeamName, arg.ExcludeIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetReviewCandidatesRow{}
+	for rows.Next() {
+		var i GetReviewCandidatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.TeamName,
+			&i.IsActive,
+			&i.MaxOpenReviews,
+			&i.OpenReviews,
 		); err != nil {
 			return nil, err
 		}
@@ -103,7 +332,7 @@ func (q *Queries) GetActiveUsersByTeam(ctx context.Context, arg GetActiveUsersBy
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, username, team_name, is_active 
+SELECT id, username, team_name, is_active, max_open_reviews, email, email_opt_out, quiet_hours_start, quiet_hours_end, timezone, last_digest_at
 FROM users 
 WHERE id = $1
 `
@@ -116,12 +345,99 @@ func (q *Queries) GetUserByID(ctx context.Context, id string) (User, error) {
 		&i.Username,
 		&i.TeamName,
 		&i.IsActive,
+		&i.MaxOpenReviews,
+		&i.Email,
+		&i.EmailOptOut,
+		&i.QuietHoursStart,
+		&i.QuietHoursEnd,
+		&i.Timezone,
+		&i.LastDigestAt,
 	)
 	return i, err
 }
 
+const getUsersByIDs = `-- name: GetUsersByIDs :many
+SELECT id, username, team_name, is_active, max_open_reviews, email, email_opt_out, quiet_hours_start, quiet_hours_end, timezone, last_digest_at
+FROM users
+WHERE id = ANY($1::text[])
+ORDER BY id
+`
+
+func (q *Queries) GetUsersByIDs(ctx context.Context, ids []string) ([]User, error) {
+	rows, err := q.db.Query(ctx, getUsersByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.TeamName,
+			&i.IsActive,
+			&i.MaxOpenReviews,
+			&i.Email,
+			&i.EmailOptOut,
+			&i.QuietHoursStart,
+			&i.QuietHoursEnd,
+			&i.Timezone,
+			&i.LastDigestAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsersByIDsForUpdate = `-- name: GetUsersByIDsForUpdate :many
+SELECT id, username, team_name, is_active, max_open_reviews, email, email_opt_out, quiet_hours_start, quiet_hours_end, timezone, last_digest_at
+FROM users
+WHERE id = ANY($1::text[])
+ORDER BY id
+FOR UPDATE
+`
+
+// Locks the rows until the end of the transaction
+func (q *Queries) GetUsersByIDsForUpdate(ctx context.Context, ids []string) ([]User, error) {
+	rows, err := q.db.Query(ctx, getUsersByIDsForUpdate, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.TeamName,
+			&i.IsActive,
+			&i.MaxOpenReviews,
+			&i.Email,
+			&i.EmailOptOut,
+			&i.QuietHoursStart,
+			&i.QuietHoursEnd,
+			&i.Timezone,
+			&i.LastDigestAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUsersByTeam = `-- name: GetUsersByTeam :many
-SELECT id, username, team_name, is_active 
+SELECT id, username, team_name, is_active, max_open_reviews, email, email_opt_out, quiet_hours_start, quiet_hours_end, timezone, last_digest_at
 FROM users 
 WHERE team_name = $1
 ORDER BY username
@@ -141,6 +457,76 @@ func (q *Queries) GetUsersByTeam(ctx context.Context, teamName string) ([]User,
 			&i.Username,
 			&i.TeamName,
 			&i.IsActive,
+			&i.MaxOpenReviews,
+			&i.Email,
+			&i.EmailOptOut,
+			&i.QuietHoursStart,
+			&i.QuietHoursEnd,
+			&i.Timezone,
+			&i.LastDigestAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchUsers = `-- name: SearchUsers :many
+SELECT id, username, team_name, is_active, max_open_reviews, email, email_opt_out, quiet_hours_start, quiet_hours_end, timezone, last_digest_at
+FROM users
+WHERE (id ILIKE $1::varchar OR username ILIKE $1::varchar)
+  AND ($2::boolean IS NULL OR is_active = $2)
+ORDER BY
+    CASE
+        WHEN lower(id) = lower($3::varchar) THEN 0
+        WHEN id ILIKE $4::varchar OR username ILIKE $4::varchar THEN 1
+        ELSE 2
+    END,
+    username, id
+LIMIT $5
+`
+
+type SearchUsersParams struct {
+	Pattern  string `json:"pattern"`
+	IsActive *bool  `json:"is_active"`
+	Query    string `json:"query"`
+	Prefix   string `json:"prefix"`
+	MaxRows  int32  `json:"max_rows"`
+}
+
+// pattern and prefix are ILIKE patterns matching the query anywhere and at the start;
+// an exact ID match comes first, then ID or username prefixes, then other matches by username
+func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, searchUsers,
+		arg.Pattern,
+		arg.IsActive,
+		arg.Query,
+		arg.Prefix,
+		arg.MaxRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.TeamName,
+			&i.IsActive,
+			&i.MaxOpenReviews,
+			&i.Email,
+			&i.EmailOptOut,
+			&i.QuietHoursStart,
+			&i.QuietHoursEnd,
+			&i.Timezone,
+			&i.LastDigestAt,
 		); err != nil {
 			return nil, err
 		}
@@ -152,6 +538,33 @@ func (q *Queries) GetUsersByTeam(ctx context.Context, teamName string) ([]User,
 	return items, nil
 }
 
+const setUserEmailSettings = `-- name: SetUserEmailSettings :exec
+UPDATE users
+SET email = $2, email_opt_out = $3, quiet_hours_start = $4, quiet_hours_end = $5, timezone = $6
+WHERE id = $1
+`
+
+type SetUserEmailSettingsParams struct {
+	ID              string  `json:"id"`
+	Email           *string `json:"email"`
+	EmailOptOut     bool    `json:"email_opt_out"`
+	QuietHoursStart *int16  `json:"quiet_hours_start"`
+	QuietHoursEnd   *int16  `json:"quiet_hours_end"`
+	Timezone        string  `json:"timezone"`
+}
+
+func (q *Queries) SetUserEmailSettings(ctx context.Context, arg SetUserEmailSettingsParams) error {
+	_, err := q.db.Exec(ctx, setUserEmailSettings,
+		arg.ID,
+		arg.Email,
+		arg.EmailOptOut,
+		arg.QuietHoursStart,
+		arg.QuietHoursEnd,
+		arg.Timezone,
+	)
+	return err
+}
+
 const setUserIsActive = `-- name: SetUserIsActive :exec
 UPDATE users SET is_active = $2 WHERE id = $1
 `
@@ -166,17 +579,60 @@ func (q *Queries) SetUserIsActive(ctx context.Context, arg SetUserIsActiveParams
 	return err
 }
 
+const setUserLastDigestAt = `-- name: SetUserLastDigestAt :exec
+UPDATE users SET last_digest_at = $2 WHERE id = $1
+`
+
+type SetUserLastDigestAtParams struct {
+	ID           string             `json:"id"`
+	LastDigestAt pgtype.Timestamptz `json:"last_digest_at"`
+}
+
+func (q *Queries) SetUserLastDigestAt(ctx context.Context, arg SetUserLastDigestAtParams) error {
+	_, err := q.db.Exec(ctx, setUserLastDigestAt, arg.ID, arg.LastDigestAt)
+	return err
+}
+
+const setUserMaxOpenReviews = `-- name: SetUserMaxOpenReviews :exec
+UPDATE users SET max_open_reviews = $2 WHERE id = $1
+`
+
+type SetUserMaxOpenReviewsParams struct {
+	ID             string `json:"id"`
+	MaxOpenReviews *int32 `json:"max_open_reviews"`
+}
+
+func (q *Queries) SetUserMaxOpenReviews(ctx context.Context, arg SetUserMaxOpenReviewsParams) error {
+	_, err := q.db.Exec(ctx, setUserMaxOpenReviews, arg.ID, arg.MaxOpenReviews)
+	return err
+}
+
+const setUserTeam = `-- name: SetUserTeam :exec
+UPDATE users SET team_name = $2 WHERE id = $1
+`
+
+type SetUserTeamParams struct {
+	ID       string `json:"id"`
+	TeamName string `json:"team_name"`
+}
+
+func (q *Queries) SetUserTeam(ctx context.Context, arg SetUserTeamParams) error {
+	_, err := q.db.Exec(ctx, setUserTeam, arg.ID, arg.TeamName)
+	return err
+}
+
 const updateUser = `-- name: UpdateUser :exec
 UPDATE users 
-SET username = $2, team_name = $3, is_active = $4
+SET username = $2, team_name = $3, is_active = $4, max_open_reviews = $5
 WHERE id = $1
 `
 
 type UpdateUserParams struct {
-	ID       string `json:"id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	ID             string `json:"id"`
+	Username       string `json:"username"`
+	TeamName       string `json:"team_name"`
+	IsActive       bool   `json:"is_active"`
+	MaxOpenReviews *int32 `json:"max_open_reviews"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
@@ -185,33 +641,38 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
 		arg.Username,
 		arg.TeamName,
 		arg.IsActive,
+		arg.MaxOpenReviews,
 	)
 	return err
 }
 
 const upsertUser = `-- name: UpsertUser :exec
-INSERT INTO users (id, username, team_name, is_active)
-VALUES ($1, $2, $3, $4)
+INSERT INTO users (id, username, team_name, is_active, max_open_reviews)
+VALUES ($1, $2, $3, $4, $5)
 ON CONFLICT (id) 
 DO UPDATE SET 
     username = EXCLUDED.username,
     team_name = EXCLUDED.team_name,
-    is_active = EXCLUDED.is_active
+    is_active = EXCLUDED.is_active,
+    max_open_reviews = COALESCE(EXCLUDED.max_open_reviews, users.max_open_reviews)
 `
 
 type UpsertUserParams struct {
-	ID       string `json:"id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	ID             string `json:"id"`
+	Username       string `json:"username"`
+	TeamName       string `json:"team_name"`
+	IsActive       bool   `json:"is_active"`
+	MaxOpenReviews *int32 `json:"max_open_reviews"`
 }
 
+// A NULL max_open_reviews keeps the stored limit, so /team/add can't clear it; /users/update can
 func (q *Queries) UpsertUser(ctx context.Context, arg UpsertUserParams) error {
 	_, err := q.db.Exec(ctx, upsertUser,
 		arg.ID,
 		arg.Username,
 		arg.TeamName,
 		arg.IsActive,
+		arg.MaxOpenReviews,
 	)
 	return err
 }
@@ -0,0 +1,19 @@
+-- name: InsertStreamEvent :one
+INSERT INTO event_stream (event_id, event_type, team_name, user_ids, payload)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING seq;
+
+-- name: ListStreamEventsAfter :many
+SELECT seq, event_id, event_type, team_name, user_ids, payload, created_at
+FROM event_stream
+WHERE seq > sqlc.arg(after_seq)
+ORDER BY seq
+LIMIT sqlc.arg(max_rows);
+
+-- name: GetLatestStreamSeq :one
+SELECT COALESCE(MAX(seq), 0)::bigint AS seq
+FROM event_stream;
+
+-- name: DeleteStreamEventsBefore :execrows
+DELETE FROM event_stream
+WHERE created_at < $1;
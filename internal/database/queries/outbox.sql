@@ -0,0 +1,32 @@
+-- name: InsertOutboxEvent :exec
+INSERT INTO outbox (event_id, event_type, payload)
+VALUES ($1, $2, $3);
+
+-- name: ClaimOutboxEvents :many
+WITH due AS (
+    SELECT id
+    FROM outbox
+    WHERE published_at IS NULL AND next_attempt_at <= sqlc.arg(now)::timestamptz
+    ORDER BY id
+    LIMIT sqlc.arg(max_rows)
+    FOR UPDATE SKIP LOCKED
+)
+UPDATE outbox o
+SET next_attempt_at = sqlc.arg(lease_until)::timestamptz
+FROM due
+WHERE o.id = due.id
+RETURNING o.id, o.event_id, o.event_type, o.payload, o.attempts, o.created_at;
+
+-- name: MarkOutboxPublished :exec
+UPDATE outbox
+SET published_at = $2, attempts = attempts + 1, last_error = NULL
+WHERE id = $1;
+
+-- name: ScheduleOutboxRetry :exec
+UPDATE outbox
+SET attempts = $2, next_attempt_at = $3, last_error = $4
+WHERE id = $1;
+
+-- name: DeletePublishedOutboxEvents :execrows
+DELETE FROM outbox
+WHERE published_at IS NOT NULL AND published_at < $1;
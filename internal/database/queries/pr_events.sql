@@ -0,0 +1,26 @@
+-- name: InsertPREvents :exec
+-- Empty strings in the arrays are stored as NULL
+INSERT INTO pr_events (
+    pull_request_id, event_type, actor, request_id,
+    reviewer_id, old_reviewer_id, reason, from_status, to_status, review_state
+)
+SELECT e.pull_request_id, e.event_type, sqlc.narg(actor)::varchar, sqlc.narg(request_id)::varchar,
+       NULLIF(e.reviewer_id, ''), NULLIF(e.old_reviewer_id, ''), NULLIF(e.reason, ''),
+       NULLIF(e.from_status, ''), NULLIF(e.to_status, ''), NULLIF(e.review_state, '')
+FROM unnest(
+    sqlc.arg(pull_request_ids)::text[],
+    sqlc.arg(event_types)::text[],
+    sqlc.arg(reviewer_ids)::text[],
+    sqlc.arg(old_reviewer_ids)::text[],
+    sqlc.arg(reasons)::text[],
+    sqlc.arg(from_statuses)::text[],
+    sqlc.arg(to_statuses)::text[],
+    sqlc.arg(review_states)::text[]
+) AS e(pull_request_id, event_type, reviewer_id, old_reviewer_id, reason, from_status, to_status, review_state);
+
+-- name: GetPREvents :many
+SELECT id, pull_request_id, event_type, actor, request_id,
+       reviewer_id, old_reviewer_id, reason, from_status, to_status, review_state, created_at
+FROM pr_events
+WHERE pull_request_id = $1
+ORDER BY id;
@@ -3,7 +3,7 @@ INSERT INTO pull_requests (id, name, author_id, status, created_at, merged_at)
 VALUES ($1, $2, $3, $4, $5, $6);
 
 -- name: GetPullRequestByID :one
-SELECT id, name, author_id, status, created_at, merged_at
+SELECT id, name, author_id, status, created_at, merged_at, force_merged, closed_at
 FROM pull_requests
 WHERE id = $1;
 
@@ -14,9 +14,14 @@ WHERE id = $1;
 
 -- name: MergePullRequest :one
 UPDATE pull_requests
-SET status = 'MERGED', merged_at = $2
-WHERE id = $1 AND status != 'MERGED'
-RETURNING id, name, author_id, status, created_at, merged_at;
+SET status = 'MERGED', merged_at = $2, force_merged = $3
+WHERE id = $1 AND status = 'OPEN'
+RETURNING id, name, author_id, status, created_at, merged_at, force_merged, closed_at;
+
+-- name: TransitionPullRequest :execrows
+UPDATE pull_requests
+SET status = sqlc.arg(status), closed_at = sqlc.arg(closed_at)
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status);
 
 -- name: PullRequestExists :one
 SELECT EXISTS(SELECT 1 FROM pull_requests WHERE id = $1);
@@ -32,6 +37,10 @@ INSERT INTO pr_reviewers (pull_request_id, reviewer_id, assigned_at)
 VALUES ($1, $2, $3)
 ON CONFLICT (pull_request_id, reviewer_id) DO NOTHING;
 
+-- name: LockPullRequest :one
+-- Locks the PR row, so a reviewer count check and the change that follows can't interleave with another change
+SELECT status FROM pull_requests WHERE id = $1 FOR UPDATE;
+
 -- name: RemoveReviewer :exec
 DELETE FROM pr_reviewers
 WHERE pull_request_id = $1 AND reviewer_id = $2;
@@ -42,9 +51,168 @@ FROM pr_reviewers
 WHERE pull_request_id = $1
 ORDER BY assigned_at;
 
+-- name: GetReviewsByPRID :many
+SELECT reviewer_id, state, state_updated_at, escalated_at
+FROM pr_reviewers
+WHERE pull_request_id = $1
+ORDER BY assigned_at;
+
+-- name: SetReviewState :execrows
+UPDATE pr_reviewers
+SET state = $3, state_updated_at = $4
+WHERE pull_request_id = $1 AND reviewer_id = $2;
+
+-- name: MarkReviewEscalated :execrows
+UPDATE pr_reviewers
+SET escalated_at = $3
+WHERE pull_request_id = $1 AND reviewer_id = $2 AND escalated_at IS NULL;
+
 -- name: GetPRsByReviewer :many
 SELECT DISTINCT pr.id, pr.name, pr.author_id, pr.status, pr.created_at
 FROM pull_requests pr
 INNER JOIN pr_reviewers prr ON pr.id = prr.pull_request_id
 WHERE prr.reviewer_id = $1
 ORDER BY pr.created_at DESC;
+
+-- name: GetPendingPRsByReviewer :many
+SELECT pr.id, pr.name, pr.author_id, pr.status, pr.created_at
+FROM pull_requests pr
+INNER JOIN pr_reviewers prr ON pr.id = prr.pull_request_id
+WHERE prr.reviewer_id = $1 AND prr.state = 'PENDING' AND pr.status = 'OPEN'
+ORDER BY pr.created_at DESC;
+
+-- name: ListPRsByReviewer :many
+-- Newest first; a page continues after the (created_at, id) of the previous page's last row
+SELECT pr.id, pr.name, pr.author_id, pr.status, pr.created_at
+FROM pull_requests pr
+INNER JOIN pr_reviewers prr ON pr.id = prr.pull_request_id
+INNER JOIN users a ON a.id = pr.author_id
+WHERE prr.reviewer_id = sqlc.arg(reviewer_id)
+  AND (sqlc.narg(status)::varchar IS NULL OR pr.status = sqlc.narg(status))
+  AND (NOT sqlc.arg(pending_only)::boolean OR (prr.state = 'PENDING' AND pr.status = 'OPEN'))
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR pr.created_at >= sqlc.narg(created_after))
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR pr.created_at < sqlc.narg(created_before))
+  AND (sqlc.narg(team_name)::varchar IS NULL OR a.team_name = sqlc.narg(team_name))
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+       OR (pr.created_at, pr.id) < (sqlc.narg(after_created_at), sqlc.narg(after_id)::varchar))
+ORDER BY pr.created_at DESC, pr.id DESC
+LIMIT sqlc.arg(max_rows);
+
+-- name: ListPRsByReviewerAsc :many
+-- Oldest first; a page continues after the (created_at, id) of the previous page's last row
+SELECT pr.id, pr.name, pr.author_id, pr.status, pr.created_at
+FROM pull_requests pr
+INNER JOIN pr_reviewers prr ON pr.id = prr.pull_request_id
+INNER JOIN users a ON a.id = pr.author_id
+WHERE prr.reviewer_id = sqlc.arg(reviewer_id)
+  AND (sqlc.narg(status)::varchar IS NULL OR pr.status = sqlc.narg(status))
+  AND (NOT sqlc.arg(pending_only)::boolean OR (prr.state = 'PENDING' AND pr.status = 'OPEN'))
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR pr.created_at >= sqlc.narg(created_after))
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR pr.created_at < sqlc.narg(created_before))
+  AND (sqlc.narg(team_name)::varchar IS NULL OR a.team_name = sqlc.narg(team_name))
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+       OR (pr.created_at, pr.id) > (sqlc.narg(after_created_at), sqlc.narg(after_id)::varchar))
+ORDER BY pr.created_at ASC, pr.id ASC
+LIMIT sqlc.arg(max_rows);
+
+-- name: ListPullRequests :many
+-- Newest first with reviewers in assignment order; name_pattern is an ILIKE pattern, search a web-style full-text query
+SELECT pr.id, pr.name, pr.author_id, a.team_name, pr.status, pr.created_at, pr.merged_at, pr.closed_at,
+       COALESCE(r.reviewer_ids, '{}')::text[] AS reviewer_ids
+FROM pull_requests pr
+INNER JOIN users a ON a.id = pr.author_id
+LEFT JOIN LATERAL (
+    SELECT array_agg(prr.reviewer_id ORDER BY prr.assigned_at) AS reviewer_ids
+    FROM pr_reviewers prr
+    WHERE prr.pull_request_id = pr.id
+) r ON true
+WHERE (sqlc.narg(author_id)::varchar IS NULL OR pr.author_id = sqlc.narg(author_id))
+  AND (sqlc.narg(team_name)::varchar IS NULL OR a.team_name = sqlc.narg(team_name))
+  AND (sqlc.narg(status)::varchar IS NULL OR pr.status = sqlc.narg(status))
+  AND (sqlc.narg(reviewer_id)::varchar IS NULL OR EXISTS (
+      SELECT 1 FROM pr_reviewers x
+      WHERE x.pull_request_id = pr.id AND x.reviewer_id = sqlc.narg(reviewer_id)
+  ))
+  AND (NOT sqlc.arg(no_reviewers)::boolean OR r.reviewer_ids IS NULL)
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR pr.created_at >= sqlc.narg(created_after))
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR pr.created_at < sqlc.narg(created_before))
+  AND (sqlc.narg(name_pattern)::varchar IS NULL OR pr.name ILIKE sqlc.narg(name_pattern))
+  AND (sqlc.narg(search)::varchar IS NULL
+       OR to_tsvector('simple', pr.name) @@ websearch_to_tsquery('simple', sqlc.narg(search)))
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+       OR (pr.created_at, pr.id) < (sqlc.narg(after_created_at), sqlc.narg(after_id)::varchar))
+ORDER BY pr.created_at DESC, pr.id DESC
+LIMIT sqlc.arg(max_rows);
+
+-- name: ListPullRequestsAsc :many
+-- Oldest first; otherwise the same as ListPullRequests
+SELECT pr.id, pr.name, pr.author_id, a.team_name, pr.status, pr.created_at, pr.merged_at, pr.closed_at,
+       COALESCE(r.reviewer_ids, '{}')::text[] AS reviewer_ids
+FROM pull_requests pr
+INNER JOIN users a ON a.id = pr.author_id
+LEFT JOIN LATERAL (
+    SELECT array_agg(prr.reviewer_id ORDER BY prr.assigned_at) AS reviewer_ids
+    FROM pr_reviewers prr
+    WHERE prr.pull_request_id = pr.id
+) r ON true
+WHERE (sqlc.narg(author_id)::varchar IS NULL OR pr.author_id = sqlc.narg(author_id))
+  AND (sqlc.narg(team_name)::varchar IS NULL OR a.team_name = sqlc.narg(team_name))
+  AND (sqlc.narg(status)::varchar IS NULL OR pr.status = sqlc.narg(status))
+  AND (sqlc.narg(reviewer_id)::varchar IS NULL OR EXISTS (
+      SELECT 1 FROM pr_reviewers x
+      WHERE x.pull_request_id = pr.id AND x.reviewer_id = sqlc.narg(reviewer_id)
+  ))
+  AND (NOT sqlc.arg(no_reviewers)::boolean OR r.reviewer_ids IS NULL)
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR pr.created_at >= sqlc.narg(created_after))
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR pr.created_at < sqlc.narg(created_before))
+  AND (sqlc.narg(name_pattern)::varchar IS NULL OR pr.name ILIKE sqlc.narg(name_pattern))
+  AND (sqlc.narg(search)::varchar IS NULL
+       OR to_tsvector('simple', pr.name) @@ websearch_to_tsquery('simple', sqlc.narg(search)))
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+       OR (pr.created_at, pr.id) > (sqlc.narg(after_created_at), sqlc.narg(after_id)::varchar))
+ORDER BY pr.created_at ASC, pr.id ASC
+LIMIT sqlc.arg(max_rows);
+
+-- name: GetPendingReviewsWithSLA :many
+SELECT pr.id, pr.name, pr.author_id, pr.status, prr.assigned_at, t.review_sla_minutes
+FROM pull_requests pr
+INNER JOIN pr_reviewers prr ON pr.id = prr.pull_request_id
+INNER JOIN users a ON a.id = pr.author_id
+INNER JOIN teams t ON t.name = a.team_name
+WHERE prr.reviewer_id = $1 AND prr.state = 'PENDING' AND pr.status = 'OPEN'
+ORDER BY prr.assigned_at;
+
+-- name: GetOverdueReviews :many
+SELECT prr.pull_request_id, prr.reviewer_id, prr.assigned_at, t.name AS team_name, t.sla_action, t.lead_user_id
+FROM pr_reviewers prr
+INNER JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.status = 'OPEN'
+INNER JOIN users a ON a.id = pr.author_id
+INNER JOIN teams t ON t.name = a.team_name
+WHERE prr.state = 'PENDING'
+  AND prr.escalated_at IS NULL
+  AND t.review_sla_minutes > 0
+  AND prr.assigned_at + make_interval(mins => t.review_sla_minutes) <= sqlc.arg(now)::timestamptz
+ORDER BY prr.assigned_at
+LIMIT sqlc.arg(max_rows);
+
+-- name: GetOpenReviewsByReviewers :many
+-- Reviews of the given users on OPEN PRs with the team of the PR author and everyone currently reviewing the PR
+SELECT prr.pull_request_id, prr.reviewer_id, pr.author_id, a.team_name,
+       ARRAY(SELECT x.reviewer_id FROM pr_reviewers x WHERE x.pull_request_id = prr.pull_request_id)::text[] AS reviewer_ids
+FROM pr_reviewers prr
+INNER JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.status = 'OPEN'
+INNER JOIN users a ON a.id = pr.author_id
+WHERE prr.reviewer_id = ANY(sqlc.arg(user_ids)::text[])
+ORDER BY prr.pull_request_id, prr.reviewer_id;
+
+-- name: RemoveOpenReviewsByReviewers :execrows
+DELETE FROM pr_reviewers prr
+USING pull_requests pr
+WHERE pr.id = prr.pull_request_id
+  AND pr.status = 'OPEN'
+  AND prr.reviewer_id = ANY(sqlc.arg(reviewer_ids)::text[]);
+
+-- name: AddReviewersBulk :exec
+INSERT INTO pr_reviewers (pull_request_id, reviewer_id, assigned_at)
+SELECT unnest(sqlc.arg(pull_request_ids)::text[]), unnest(sqlc.arg(reviewer_ids)::text[]), NOW()
+ON CONFLICT (pull_request_id, reviewer_id) DO NOTHING;
@@ -0,0 +1,15 @@
+-- name: InsertTeamMemberships :exec
+-- Records that each user moved from the team at the same position in from_teams to to_team
+INSERT INTO team_memberships (user_id, from_team, to_team, source, review_policy, actor, request_id)
+SELECT m.user_id, m.from_team, sqlc.arg(to_team)::varchar, sqlc.arg(source)::varchar,
+       sqlc.narg(review_policy)::varchar, sqlc.narg(actor)::varchar, sqlc.narg(request_id)::varchar
+FROM unnest(
+    sqlc.arg(user_ids)::text[],
+    sqlc.arg(from_teams)::text[]
+) AS m(user_id, from_team);
+
+-- name: GetTeamMemberships :many
+SELECT id, user_id, from_team, to_team, source, review_policy, actor, request_id, created_at
+FROM team_memberships
+WHERE user_id = $1
+ORDER BY id;
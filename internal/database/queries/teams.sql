@@ -1,11 +1,60 @@
 -- name: CreateTeam :exec
 INSERT INTO teams (name) VALUES ($1);
 
+-- name: EnsureTeam :exec
+INSERT INTO teams (name) VALUES ($1)
+ON CONFLICT (name) DO NOTHING;
+
 -- name: GetTeamByName :one
 SELECT name FROM teams WHERE name = $1;
 
+-- name: GetTeamPolicy :one
+SELECT min_reviewers, max_reviewers, required_approvals, block_on_changes_requested,
+       review_sla_minutes, sla_action, lead_user_id, chat_channel, chat_template
+FROM teams
+WHERE name = $1;
+
+-- name: GetTeamsLedBy :many
+-- Teams whose lead_user_id is one of the given users
+SELECT name FROM teams
+WHERE lead_user_id = ANY(sqlc.arg(lead_ids)::text[])
+ORDER BY name;
+
+-- name: UpdateTeamPolicy :exec
+UPDATE teams
+SET min_reviewers = $2, max_reviewers = $3, required_approvals = $4, block_on_changes_requested = $5,
+    review_sla_minutes = $6, sla_action = $7, lead_user_id = $8,
+    chat_channel = $9, chat_template = $10
+WHERE name = $1;
+
 -- name: TeamExists :one
 SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1);
 
 -- name: CountTeams :one
 SELECT COUNT(*) FROM teams;
+
+-- name: AdvanceReviewerCursor :one
+-- Moves the team's round-robin cursor past the next picks of the sorted candidate_ids in one statement,
+-- so concurrent selections never start from the same cursor; returns the new cursor
+INSERT INTO team_reviewer_cursors AS c (team_name, last_reviewer_id, updated_at)
+VALUES (sqlc.arg(team_name), (sqlc.arg(candidate_ids)::text[])[sqlc.arg(picks)::int], NOW())
+ON CONFLICT (team_name)
+DO UPDATE SET
+    last_reviewer_id = (sqlc.arg(candidate_ids)::text[])[
+        ((SELECT COUNT(*) FROM unnest(sqlc.arg(candidate_ids)::text[]) AS id WHERE id COLLATE "C" <= c.last_reviewer_id)::int
+            + sqlc.arg(picks)::int - 1) % cardinality(sqlc.arg(candidate_ids)::text[]) + 1
+    ],
+    updated_at = EXCLUDED.updated_at
+RETURNING last_reviewer_id;
+
+-- name: ListTeams :many
+-- A team is active while it has at least one active member
+SELECT t.name,
+       COUNT(u.id)::int AS member_count,
+       (COUNT(u.id) FILTER (WHERE u.is_active))::int AS active_count
+FROM teams t
+LEFT JOIN users u ON u.team_name = t.name
+GROUP BY t.name
+HAVING sqlc.narg(is_active)::boolean IS NULL
+    OR (COUNT(u.id) FILTER (WHERE u.is_active) > 0) = sqlc.narg(is_active)
+ORDER BY t.name;
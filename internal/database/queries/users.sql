@@ -1,40 +1,91 @@
 -- name: CreateUser :exec
-INSERT INTO users (id, username, team_name, is_active)
-VALUES ($1, $2, $3, $4);
+INSERT INTO users (id, username, team_name, is_active, max_open_reviews)
+VALUES ($1, $2, $3, $4, $5);
 
 -- name: UpdateUser :exec
 UPDATE users 
-SET username = $2, team_name = $3, is_active = $4
+SET username = $2, team_name = $3, is_active = $4, max_open_reviews = $5
 WHERE id = $1;
 
 -- name: UpsertUser :exec
-INSERT INTO users (id, username, team_name, is_active)
-VALUES ($1, $2, $3, $4)
+-- A NULL max_open_reviews keeps the stored limit, so /team/add can't clear it; /users/update can
+INSERT INTO users (id, username, team_name, is_active, max_open_reviews)
+VALUES ($1, $2, $3, $4, $5)
 ON CONFLICT (id) 
 DO UPDATE SET 
     username = EXCLUDED.username,
     team_name = EXCLUDED.team_name,
-    is_active = EXCLUDED.is_active;
+    is_active = EXCLUDED.is_active,
+    max_open_reviews = COALESCE(EXCLUDED.max_open_reviews, users.max_open_reviews);
 
 -- name: GetUserByID :one
-SELECT id, username, team_name, is_active 
+SELECT id, username, team_name, is_active, max_open_reviews, email, email_opt_out, quiet_hours_start, quiet_hours_end, timezone, last_digest_at
 FROM users 
 WHERE id = $1;
 
 -- name: GetUsersByTeam :many
-SELECT id, username, team_name, is_active 
+SELECT id, username, team_name, is_active, max_open_reviews, email, email_opt_out, quiet_hours_start, quiet_hours_end, timezone, last_digest_at
 FROM users 
 WHERE team_name = $1
 ORDER BY username;
 
+-- name: GetUsersByIDs :many
+SELECT id, username, team_name, is_active, max_open_reviews, email, email_opt_out, quiet_hours_start, quiet_hours_end, timezone, last_digest_at
+FROM users
+WHERE id = ANY(sqlc.arg(ids)::text[])
+ORDER BY id;
+
+-- name: GetUsersByIDsForUpdate :many
+-- Locks the rows until the end of the transaction
+SELECT id, username, team_name, is_active, max_open_reviews, email, email_opt_out, quiet_hours_start, quiet_hours_end, timezone, last_digest_at
+FROM users
+WHERE id = ANY(sqlc.arg(ids)::text[])
+ORDER BY id
+FOR UPDATE;
+
 -- name: SetUserIsActive :exec
 UPDATE users SET is_active = $2 WHERE id = $1;
 
+-- name: SetUserMaxOpenReviews :exec
+UPDATE users SET max_open_reviews = $2 WHERE id = $1;
+
+-- name: SetUserTeam :exec
+UPDATE users SET team_name = $2 WHERE id = $1;
+
+-- name: SetUserEmailSettings :exec
+UPDATE users
+SET email = $2, email_opt_out = $3, quiet_hours_start = $4, quiet_hours_end = $5, timezone = $6
+WHERE id = $1;
+
+-- name: SetUserLastDigestAt :exec
+UPDATE users SET last_digest_at = $2 WHERE id = $1;
+
+-- name: GetDigestRecipients :many
+SELECT id, username, team_name, is_active, max_open_reviews, email, email_opt_out, quiet_hours_start, quiet_hours_end, timezone, last_digest_at
+FROM users u
+WHERE u.is_active = true
+  AND u.email IS NOT NULL
+  AND u.email_opt_out = false
+  AND EXISTS (
+      SELECT 1
+      FROM pr_reviewers prr
+      INNER JOIN pull_requests pr ON pr.id = prr.pull_request_id
+      WHERE prr.reviewer_id = u.id AND prr.state = 'PENDING' AND pr.status = 'OPEN'
+  )
+ORDER BY u.id;
+
 -- name: DeactivateTeamUsers :execrows
 UPDATE users SET is_active = false WHERE team_name = $1 AND is_active = true;
 
+-- name: DeactivateTeamUsersExcept :many
+-- Deactivates active members of the team that are not in keep_ids
+UPDATE users SET is_active = false
+WHERE team_name = sqlc.arg(team_name) AND is_active = true
+  AND NOT (id = ANY(sqlc.arg(keep_ids)::text[]))
+RETURNING id;
+
 -- name: GetActiveUsersByTeam :many
-SELECT id, username, team_name, is_active 
+SELECT id, username, team_name, is_active, max_open_reviews, email, email_opt_out, quiet_hours_start, quiet_hours_end, timezone, last_digest_at
 FROM users 
 WHERE team_name = $1 AND is_active = true AND id != $2
 ORDER BY username;
@@ -47,3 +98,51 @@ SELECT COUNT(*) FROM users;
 
 -- name: CountActiveUsers :one
 SELECT COUNT(*) FROM users WHERE is_active = true;
+
+-- name: CountOpenReviewsByUsers :many
+SELECT prr.reviewer_id, COUNT(*)::int AS open_reviews
+FROM pr_reviewers prr
+INNER JOIN pull_requests pr ON pr.id = prr.pull_request_id
+WHERE prr.reviewer_id = ANY(sqlc.arg(user_ids)::text[]) AND pr.status = 'OPEN'
+GROUP BY prr.reviewer_id;
+
+-- name: GetReplacementCandidates :many
+-- Active members of the teams below their max_open_reviews, with their OPEN review load
+SELECT u.id, u.team_name, u.max_open_reviews, COUNT(pr.id)::int AS open_reviews
+FROM users u
+LEFT JOIN pr_reviewers prr ON prr.reviewer_id = u.id
+LEFT JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.status = 'OPEN'
+WHERE u.team_name = ANY(sqlc.arg(team_names)::text[])
+  AND u.is_active = true
+  AND NOT (u.id = ANY(sqlc.arg(exclude_ids)::text[]))
+GROUP BY u.id
+HAVING u.max_open_reviews IS NULL OR COUNT(pr.id) < u.max_open_reviews
+ORDER BY u.id;
+
+-- name: GetReviewCandidates :many
+SELECT u.id, u.username, u.team_name, u.is_active, u.max_open_reviews, COUNT(pr.id)::int AS open_reviews
+FROM users u
+LEFT JOIN pr_reviewers prr ON prr.reviewer_id = u.id
+LEFT JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.status = 'OPEN'
+WHERE u.team_name = sqlc.arg(team_name)
+  AND u.is_active = true
+  AND NOT (u.id = ANY(sqlc.arg(exclude_ids)::text[]))
+GROUP BY u.id
+HAVING u.max_open_reviews IS NULL OR COUNT(pr.id) < u.max_open_reviews
+ORDER BY open_reviews, u.id;
+
+-- name: SearchUsers :many
+-- pattern and prefix are ILIKE patterns matching the query anywhere and at the start;
+-- an exact ID match comes first, then ID or username prefixes, then other matches by username
+SELECT id, username, team_name, is_active, max_open_reviews, email, email_opt_out, quiet_hours_start, quiet_hours_end, timezone, last_digest_at
+FROM users
+WHERE (id ILIKE sqlc.arg(pattern)::varchar OR username ILIKE sqlc.arg(pattern)::varchar)
+  AND (sqlc.narg(is_active)::boolean IS NULL OR is_active = sqlc.narg(is_active))
+ORDER BY
+    CASE
+        WHEN lower(id) = lower(sqlc.arg(query)::varchar) THEN 0
+        WHEN id ILIKE sqlc.arg(prefix)::varchar OR username ILIKE sqlc.arg(prefix)::varchar THEN 1
+        ELSE 2
+    END,
+    username, id
+LIMIT sqlc.arg(max_rows);
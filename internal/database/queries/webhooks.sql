@@ -0,0 +1,69 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (url, secret, events)
+VALUES ($1, $2, $3)
+RETURNING id, url, secret, events, created_at;
+
+-- name: ListWebhookSubscriptions :many
+SELECT id, url, secret, events, created_at
+FROM webhook_subscriptions
+ORDER BY id;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions WHERE id = $1;
+
+-- name: EnqueueWebhookDeliveries :exec
+INSERT INTO webhook_deliveries (subscription_id, event_type, payload)
+SELECT unnest(sqlc.arg(subscription_ids)::bigint[]), sqlc.arg(event_type)::varchar, sqlc.arg(payload)::jsonb;
+
+-- name: ClaimDueWebhookDeliveries :many
+WITH due AS (
+    SELECT id
+    FROM webhook_deliveries
+    WHERE status = 'PENDING' AND next_attempt_at <= sqlc.arg(now)::timestamptz
+    ORDER BY next_attempt_at
+    LIMIT sqlc.arg(max_rows)
+    FOR UPDATE SKIP LOCKED
+),
+claimed AS (
+    UPDATE webhook_deliveries d
+    SET next_attempt_at = sqlc.arg(lease_until)::timestamptz
+    FROM due
+    WHERE d.id = due.id
+    RETURNING d.id, d.subscription_id, d.event_type, d.payload, d.attempts
+)
+SELECT c.id, c.subscription_id, c.event_type, c.payload, c.attempts, s.url, s.secret
+FROM claimed c
+INNER JOIN webhook_subscriptions s ON s.id = c.subscription_id
+ORDER BY c.id;
+
+-- name: MarkWebhookDelivered :exec
+UPDATE webhook_deliveries
+SET status = 'DELIVERED', attempts = attempts + 1, delivered_at = $2, last_error = NULL
+WHERE id = $1;
+
+-- name: DeleteDeliveredWebhooksBefore :execrows
+DELETE FROM webhook_deliveries
+WHERE status = 'DELIVERED' AND delivered_at < $1;
+
+-- name: ScheduleWebhookRetry :exec
+UPDATE webhook_deliveries
+SET attempts = $2, next_attempt_at = $3, last_error = $4
+WHERE id = $1;
+
+-- name: DeleteWebhookDelivery :exec
+DELETE FROM webhook_deliveries WHERE id = $1;
+
+-- name: CreateWebhookDeadLetter :exec
+INSERT INTO webhook_dead_letters (subscription_id, event_type, payload, attempts, last_error)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListWebhookDeadLetters :many
+SELECT id, subscription_id, event_type, payload, attempts, last_error, failed_at
+FROM webhook_dead_letters
+WHERE sqlc.narg(subscription_id)::bigint IS NULL OR subscription_id = sqlc.narg(subscription_id)
+ORDER BY failed_at DESC, id DESC;
+
+-- name: DeleteWebhookDeadLetter :one
+DELETE FROM webhook_dead_letters
+WHERE id = $1
+RETURNING id, subscription_id, event_type, payload, attempts, last_error, failed_at;
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"net/mail"
+	"time"
+)
+
+// DefaultTimezone is used for users who have not set their time zone
+const DefaultTimezone = "UTC"
+
+// EmailSettings are the user's email notification preferences
+// Emails are sent only to users with an Email who have not opted out
+type EmailSettings struct {
+	Email       *string `json:"email,omitempty"`
+	EmailOptOut bool    `json:"email_opt_out"`
+	// QuietHours is a daily window when no emails are sent (nil = any time)
+	QuietHours *QuietHours `json:"quiet_hours,omitempty"`
+	// Timezone is an IANA zone name used for quiet hours and the digest time
+	Timezone string `json:"timezone"`
+}
+
+// QuietHours is a window of whole hours in the user's time zone, from Start up to End
+// A window with Start > End wraps past midnight (22-7)
+type QuietHours struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// SLAStatus tells how a pending review stands against its team's review SLA
+type SLAStatus string
+
+const (
+	// SLAStatusNone means the author's team has no review SLA
+	SLAStatusNone SLAStatus = "none"
+	// SLAStatusOnTrack means the review is still within the SLA
+	SLAStatusOnTrack SLAStatus = "on_track"
+	// SLAStatusOverdue means the review outlived the SLA
+	SLAStatusOverdue SLAStatus = "overdue"
+)
+
+// PendingReview is a PR the reviewer has not reviewed yet, with what is needed to tell its age and SLA status
+type PendingReview struct {
+	PullRequestShort
+	AssignedAt time.Time
+	// SLA is the review SLA of the author's team (0 = none)
+	SLA time.Duration
+}
+
+// DigestRecipient is a user who may get the daily digest and when they last got one
+type DigestRecipient struct {
+	User
+	LastDigestAt *time.Time
+}
+
+// AcceptsEmail reports whether the user can be emailed at all
+func (s EmailSettings) AcceptsEmail() bool {
+	return s.Email != nil && !s.EmailOptOut
+}
+
+// Location returns the user's time zone, UTC if it is unset or unknown
+func (s EmailSettings) Location() *time.Location {
+	if s.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// InQuietHours reports whether t falls within the user's quiet hours
+func (s EmailSettings) InQuietHours(t time.Time) bool {
+	return s.QuietHours != nil && s.QuietHours.Contains(t.In(s.Location()).Hour())
+}
+
+func (s EmailSettings) Validate() error {
+	if s.Email != nil {
+		addr, err := mail.ParseAddress(*s.Email)
+		if err != nil || addr.Address != *s.Email {
+			return ErrInvalidInput
+		}
+	}
+	if s.QuietHours != nil && !s.QuietHours.IsValid() {
+		return ErrInvalidInput
+	}
+	if s.Timezone != "" {
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return ErrInvalidInput
+		}
+	}
+	return nil
+}
+
+func (q QuietHours) IsValid() bool {
+	return q.Start >= 0 && q.Start <= 23 && q.End >= 0 && q.End <= 23 && q.Start != q.End
+}
+
+// Contains reports whether the hour of day is inside the window
+func (q QuietHours) Contains(hour int) bool {
+	switch {
+	case q.Start == q.End:
+		return false
+	case q.Start < q.End:
+		return hour >= q.Start && hour < q.End
+	default:
+		return hour >= q.Start || hour < q.End
+	}
+}
+
+// Age is how long the review has been waiting at now
+func (r PendingReview) Age(now time.Time) time.Duration {
+	return now.Sub(r.AssignedAt)
+}
+
+// SLAStatus tells whether the review is past the team's review SLA at now
+func (r PendingReview) SLAStatus(now time.Time) SLAStatus {
+	switch {
+	case r.SLA <= 0:
+		return SLAStatusNone
+	case r.Age(now) >= r.SLA:
+		return SLAStatusOverdue
+	default:
+		return SLAStatusOnTrack
+	}
+}
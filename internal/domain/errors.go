@@ -5,18 +5,25 @@ import "errors"
 // Domain errors
 var (
 	// Team errors
-	ErrTeamNotFound = errors.New("team not found")
+	ErrTeamNotFound      = errors.New("team not found")
+	ErrInvalidTeamPolicy = errors.New("invalid team reviewer policy")
 
 	// User errors
 	ErrUserNotFound      = errors.New("user not found")
 	ErrUserAlreadyExists = errors.New("user already exists")
 	ErrInvalidUserStatus = errors.New("invalid user status")
 	ErrUserNotActive     = errors.New("user is not active")
+	ErrUserInOtherTeam   = errors.New("user belongs to another team")
+	ErrHasOpenReviews    = errors.New("user has reviews on open pull requests")
+	ErrUserIsTeamLead    = errors.New("user is the lead of their team")
 
 	// Pull Request errors
 	ErrPRExists                 = errors.New("pull request already exists")
 	ErrPRNotFound               = errors.New("pull request not found")
 	ErrPRMerged                 = errors.New("pull request is already merged")
+	ErrPRClosed                 = errors.New("pull request is closed")
+	ErrPRDraft                  = errors.New("pull request is a draft")
+	ErrInvalidTransition        = errors.New("transition is not allowed from the current PR status")
 	ErrReviewerNotFound         = errors.New("reviewer not assigned to this PR")
 	ErrNoAvailableReviewer      = errors.New("no available reviewer found")
 	ErrAuthorNotInTeam          = errors.New("author is not in any team")
@@ -24,6 +31,23 @@ var (
 	ErrReviewersAlreadyAssigned = errors.New("reviewers already assigned to this PR")
 	ErrReviewerNotInTeam        = errors.New("reviewer is not in author's team")
 	ErrAuthorAsReviewer         = errors.New("author cannot be a reviewer")
+	ErrReplacementNotInTeam     = errors.New("replacement is not in the replaced reviewer's team")
+	ErrAlreadyReviewer          = errors.New("user is already a reviewer of this PR")
+	ErrReviewerAtCapacity       = errors.New("reviewer has reached the open reviews limit")
+	ErrTooManyReviewers         = errors.New("too many reviewers for team policy")
+	ErrTooFewReviewers          = errors.New("too few reviewers for team policy")
+	ErrInvalidReviewState       = errors.New("invalid review state")
+	ErrNotApproved              = errors.New("pull request does not satisfy the team approval rule")
+	ErrForceMergeForbidden      = errors.New("force merge requires a valid admin token")
+
+	// Webhook errors
+	ErrInvalidWebhook     = errors.New("invalid webhook subscription")
+	ErrWebhookNotFound    = errors.New("webhook subscription not found")
+	ErrDeadLetterNotFound = errors.New("failed delivery not found")
+
+	// Integration errors
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidToken     = errors.New("invalid webhook token")
 
 	// General errors
 	ErrInvalidInput      = errors.New("invalid input")
@@ -38,11 +62,21 @@ type ErrorCode string
 const (
 	CodePRExists             ErrorCode = "PR_EXISTS"
 	CodePRMerged             ErrorCode = "PR_MERGED"
+	CodePRClosed             ErrorCode = "PR_CLOSED"
+	CodePRDraft              ErrorCode = "PR_DRAFT"
+	CodeInvalidTransition    ErrorCode = "INVALID_TRANSITION"
 	CodeNotAssigned          ErrorCode = "NOT_ASSIGNED"
 	CodeNoCandidate          ErrorCode = "NO_CANDIDATE"
 	CodeReviewersAssigned    ErrorCode = "REVIEWERS_ASSIGNED"
+	CodeReviewerAtCapacity   ErrorCode = "REVIEWER_AT_CAPACITY"
+	CodeNotApproved          ErrorCode = "NOT_APPROVED"
+	CodeUserInOtherTeam      ErrorCode = "USER_IN_OTHER_TEAM"
+	CodeHasOpenReviews       ErrorCode = "HAS_OPEN_REVIEWS"
+	CodeUserIsTeamLead       ErrorCode = "USER_IS_TEAM_LEAD"
 	CodeNotFound             ErrorCode = "NOT_FOUND"
 	CodeBadRequest           ErrorCode = "BAD_REQUEST"
+	CodeUnauthorized         ErrorCode = "UNAUTHORIZED"
+	CodeForbidden            ErrorCode = "FORBIDDEN"
 	CodeUnsupportedMediaType ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
 	CodeInternalError        ErrorCode = "INTERNAL_ERROR"
 )
@@ -73,17 +107,41 @@ func ToAPIError(err error) *APIError {
 		return NewAPIError(CodePRExists, err.Error())
 	case errors.Is(err, ErrPRMerged):
 		return NewAPIError(CodePRMerged, err.Error())
+	case errors.Is(err, ErrPRClosed):
+		return NewAPIError(CodePRClosed, err.Error())
+	case errors.Is(err, ErrPRDraft):
+		return NewAPIError(CodePRDraft, err.Error())
+	case errors.Is(err, ErrInvalidTransition):
+		return NewAPIError(CodeInvalidTransition, err.Error())
 	case errors.Is(err, ErrReviewerNotFound):
 		return NewAPIError(CodeNotAssigned, err.Error())
 	case errors.Is(err, ErrNoAvailableReviewer):
 		return NewAPIError(CodeNoCandidate, err.Error())
 	case errors.Is(err, ErrReviewersAlreadyAssigned):
 		return NewAPIError(CodeReviewersAssigned, err.Error())
-	case errors.Is(err, ErrTeamNotFound), errors.Is(err, ErrUserNotFound), errors.Is(err, ErrPRNotFound):
+	case errors.Is(err, ErrReviewerAtCapacity):
+		return NewAPIError(CodeReviewerAtCapacity, err.Error())
+	case errors.Is(err, ErrNotApproved):
+		return NewAPIError(CodeNotApproved, err.Error())
+	case errors.Is(err, ErrUserInOtherTeam):
+		return NewAPIError(CodeUserInOtherTeam, err.Error())
+	case errors.Is(err, ErrHasOpenReviews):
+		return NewAPIError(CodeHasOpenReviews, err.Error())
+	case errors.Is(err, ErrUserIsTeamLead):
+		return NewAPIError(CodeUserIsTeamLead, err.Error())
+	case errors.Is(err, ErrTeamNotFound), errors.Is(err, ErrUserNotFound), errors.Is(err, ErrPRNotFound),
+		errors.Is(err, ErrWebhookNotFound), errors.Is(err, ErrDeadLetterNotFound):
 		return NewAPIError(CodeNotFound, err.Error())
 	case errors.Is(err, ErrInvalidInput), errors.Is(err, ErrInvalidUserStatus), errors.Is(err, ErrInvalidPRStatus),
-		errors.Is(err, ErrUserNotActive), errors.Is(err, ErrReviewerNotInTeam), errors.Is(err, ErrAuthorAsReviewer):
+		errors.Is(err, ErrUserNotActive), errors.Is(err, ErrReviewerNotInTeam), errors.Is(err, ErrAuthorAsReviewer),
+		errors.Is(err, ErrInvalidTeamPolicy), errors.Is(err, ErrTooManyReviewers), errors.Is(err, ErrTooFewReviewers),
+		errors.Is(err, ErrReplacementNotInTeam), errors.Is(err, ErrAlreadyReviewer), errors.Is(err, ErrInvalidReviewState),
+		errors.Is(err, ErrInvalidWebhook):
 		return NewAPIError(CodeBadRequest, err.Error())
+	case errors.Is(err, ErrInvalidSignature), errors.Is(err, ErrInvalidToken):
+		return NewAPIError(CodeUnauthorized, err.Error())
+	case errors.Is(err, ErrForceMergeForbidden):
+		return NewAPIError(CodeForbidden, err.Error())
 	default:
 		return NewAPIError(CodeInternalError, "internal server error")
 	}
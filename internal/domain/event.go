@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// EventType names a change that is published to subscribers (webhooks)
+type EventType string
+
+const (
+	EventPRCreated          EventType = "pr.created"
+	EventReviewersAssigned  EventType = "pr.reviewers_assigned"
+	EventReviewerReassigned EventType = "pr.reviewer_reassigned"
+	EventPRMerged           EventType = "pr.merged"
+	EventUserDeactivated    EventType = "user.deactivated"
+	EventUserTransferred    EventType = "user.transferred"
+	EventTeamDeactivated    EventType = "team.deactivated"
+)
+
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventPRCreated, EventReviewersAssigned, EventReviewerReassigned, EventPRMerged,
+		EventUserDeactivated, EventUserTransferred, EventTeamDeactivated:
+		return true
+	}
+	return false
+}
+
+// Event is a change that already happened; Data is one of the *EventData types below
+type Event struct {
+	ID         string    `json:"id"`
+	Type       EventType `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+func NewEvent(eventType EventType, data any) Event {
+	return Event{
+		ID:         newEventID(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
+
+// PREventData is the payload of pr.created, pr.reviewers_assigned and pr.merged
+// Added lists reviewers assigned by the operation (pr.reviewers_assigned only)
+type PREventData struct {
+	PR    *PullRequest `json:"pr"`
+	Added []string     `json:"added_reviewers,omitempty"`
+}
+
+// ReviewerReassignedData is the payload of pr.reviewer_reassigned
+type ReviewerReassignedData struct {
+	PR            *PullRequest `json:"pr"`
+	OldReviewerID string       `json:"old_user_id"`
+	NewReviewerID string       `json:"new_user_id"`
+}
+
+// UserDeactivatedData is the payload of user.deactivated
+type UserDeactivatedData struct {
+	User         *User               `json:"user"`
+	Reassignment *ReassignmentReport `json:"reassignment"`
+}
+
+// UserTransferredData is the payload of user.transferred; User is already in the new team
+type UserTransferredData struct {
+	User         *User               `json:"user"`
+	FromTeam     string              `json:"from_team"`
+	Reassignment *ReassignmentReport `json:"reassignment"`
+}
+
+// TeamDeactivatedData is the payload of team.deactivated
+type TeamDeactivatedData struct {
+	TeamName         string              `json:"team_name"`
+	DeactivatedCount int                 `json:"deactivated_count"`
+	Reassignment     *ReassignmentReport `json:"reassignment"`
+}
+
+func newEventID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// PRHistoryType names a change recorded in a PR's history
+type PRHistoryType string
+
+const (
+	PRHistoryCreated            PRHistoryType = "created"
+	PRHistoryStatusChanged      PRHistoryType = "status_changed"
+	PRHistoryReviewerAssigned   PRHistoryType = "reviewer_assigned"
+	PRHistoryReviewerRemoved    PRHistoryType = "reviewer_removed"
+	PRHistoryReviewerReassigned PRHistoryType = "reviewer_reassigned"
+	PRHistoryReviewSubmitted    PRHistoryType = "review_submitted"
+	PRHistoryMerged             PRHistoryType = "merged"
+)
+
+// Reasons recorded in PR history besides the selector strategy names
+const (
+	// ReasonExplicit means the reviewer was named in the request
+	ReasonExplicit = "explicit"
+	// ReasonDeactivation means the change was made because the reviewer was deactivated
+	ReasonDeactivation = "deactivation"
+	// ReasonTransfer means the change was made because the reviewer moved to another team
+	ReasonTransfer = "transfer"
+	// ReasonForceMerge means the PR was merged bypassing the team merge rule
+	ReasonForceMerge = "force"
+)
+
+// PRHistoryEntry is one record of the append-only PR history
+// Only the fields relevant to Type are set: reviewers for reviewer changes,
+// statuses for creation, status changes and merge, ReviewState for submitted reviews
+// Reason tells why a reviewer was picked (selector strategy, explicit, deactivation or transfer)
+type PRHistoryEntry struct {
+	ID            int64         `json:"id"`
+	PullRequestID string        `json:"pull_request_id"`
+	Type          PRHistoryType `json:"event_type"`
+	Actor         string        `json:"actor,omitempty"`
+	RequestID     string        `json:"request_id,omitempty"`
+	ReviewerID    string        `json:"reviewer_id,omitempty"`
+	OldReviewerID string        `json:"old_reviewer_id,omitempty"`
+	Reason        string        `json:"reason,omitempty"`
+	FromStatus    PRStatus      `json:"from_status,omitempty"`
+	ToStatus      PRStatus      `json:"to_status,omitempty"`
+	ReviewState   ReviewState   `json:"review_state,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// Actors of changes the service makes on its own; clients can't pass them in X-Actor
+const (
+	ActorGitHub    = "github"
+	ActorGitLab    = "gitlab"
+	ActorReviewSLA = "review_sla"
+)
+
+// Limits of the actor and request ID recorded in history (VARCHAR(255) columns)
+const (
+	MaxActorLength     = 255
+	MaxRequestIDLength = 255
+)
+
+// IsReservedActor reports whether actor names one of the service's own actors, ignoring case
+func IsReservedActor(actor string) bool {
+	for _, reserved := range []string{ActorGitHub, ActorGitLab, ActorReviewSLA} {
+		if strings.EqualFold(strings.TrimSpace(actor), reserved) {
+			return true
+		}
+	}
+	return false
+}
+
+type actorKey struct{}
+
+// WithActor returns a context whose PR changes are recorded in history as made by actor
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, empty if there is none
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// TransferReviewPolicy tells /users/transfer what to do with the user's reviews on OPEN PRs of the old team
+type TransferReviewPolicy string
+
+const (
+	// TransferKeepReviews leaves the user assigned to the PRs
+	TransferKeepReviews TransferReviewPolicy = "keep"
+	// TransferReassignReviews hands the reviews over to active members of the PR author's team, as on deactivation
+	TransferReassignReviews TransferReviewPolicy = "reassign"
+	// TransferFailOnReviews rejects the transfer with ErrHasOpenReviews while the user has any
+	TransferFailOnReviews TransferReviewPolicy = "fail"
+)
+
+func (p TransferReviewPolicy) IsValid() bool {
+	switch p {
+	case TransferKeepReviews, TransferReassignReviews, TransferFailOnReviews:
+		return true
+	}
+	return false
+}
+
+// Ways a user can change teams, recorded as MembershipChange.Source
+const (
+	MembershipSourceTransfer = "transfer"
+	MembershipSourceTeamAdd  = "team_add"
+)
+
+// MembershipChange is one record of a user's append-only team membership history
+// ReviewPolicy is set for transfers only
+type MembershipChange struct {
+	ID           int64                `json:"id"`
+	UserID       string               `json:"user_id"`
+	FromTeam     string               `json:"from_team"`
+	ToTeam       string               `json:"to_team"`
+	Source       string               `json:"source"`
+	ReviewPolicy TransferReviewPolicy `json:"review_policy,omitempty"`
+	Actor        string               `json:"actor,omitempty"`
+	RequestID    string               `json:"request_id,omitempty"`
+	CreatedAt    time.Time            `json:"created_at"`
+}
+
+// TransferResult describes a completed /users/transfer
+// Reassignment is empty unless the reviews were reassigned
+type TransferResult struct {
+	User         *User               `json:"user"`
+	FromTeam     string              `json:"from_team"`
+	Reassignment *ReassignmentReport `json:"reassignment"`
+}
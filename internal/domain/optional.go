@@ -0,0 +1,31 @@
+package domain
+
+import "encoding/json"
+
+// Optional is a value of a partial update that tells "not provided" apart from
+// an explicit null: Set is true whenever the field is present in the JSON payload
+type Optional[T any] struct {
+	Set   bool
+	Value *T
+}
+
+// NewOptional returns an Optional that is set to value (nil means explicit null)
+func NewOptional[T any](value *T) Optional[T] {
+	return Optional[T]{Set: true, Value: value}
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Value = nil
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	o.Value = &value
+	return nil
+}
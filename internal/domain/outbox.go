@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxEvent is an Event stored in the outbox in the same transaction as the change it describes
+// Payload is the JSON-encoded Event; EventID is stable across retries and can be used to deduplicate
+type OutboxEvent struct {
+	ID        int64           `json:"id"`
+	EventID   string          `json:"event_id"`
+	Type      EventType       `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	Attempts  int             `json:"attempts"`
+	CreatedAt time.Time       `json:"created_at"`
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// PRSort orders PR lists by creation time; ties are broken by PR ID in the same direction
+type PRSort string
+
+const (
+	PRSortNewest PRSort = "created_at_desc"
+	PRSortOldest PRSort = "created_at_asc"
+)
+
+func (s PRSort) IsValid() bool {
+	return s == PRSortNewest || s == PRSortOldest
+}
+
+// Page sizes of PR lists
+const (
+	DefaultPRPageLimit = 50
+	MaxPRPageLimit     = 200
+)
+
+// PRCursor is the position of the last PR of a page; the next page starts right after it
+// Clients get it as an opaque string and must not build it themselves
+type PRCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"i"`
+	Sort      PRSort    `json:"s"`
+}
+
+// Encode returns the cursor as an opaque URL-safe string
+func (c PRCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodePRCursor parses a cursor returned by Encode; anything else is ErrInvalidInput
+func DecodePRCursor(s string) (*PRCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidInput
+	}
+	var c PRCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" || !c.Sort.IsValid() {
+		return nil, ErrInvalidInput
+	}
+	return &c, nil
+}
+
+// ReviewerPRFilter selects PRs assigned to a reviewer; zero fields match everything
+type ReviewerPRFilter struct {
+	ReviewerID string
+	Status     PRStatus
+	// PendingOnly keeps OPEN PRs where the reviewer has not submitted a review yet
+	PendingOnly bool
+	// CreatedAfter is inclusive, CreatedBefore is exclusive
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	// TeamName is the team of the PR author
+	TeamName string
+	Sort     PRSort
+	Limit    int
+	// After continues the list from a previous page; its sort must match Sort
+	After *PRCursor
+}
+
+// PRPage is one page of a PR list
+// NextCursor is empty on the last page
+type PRPage struct {
+	PullRequests []PullRequestShort
+	NextCursor   string
+}
+
+// PRFilter selects PRs for /pullRequest/list; zero fields match everything
+type PRFilter struct {
+	AuthorID string
+	// TeamName is the team of the PR author
+	TeamName   string
+	Status     PRStatus
+	ReviewerID string
+	// NoReviewers keeps PRs without any assigned reviewer
+	NoReviewers bool
+	// CreatedAfter is inclusive, CreatedBefore is exclusive
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	// NameContains matches a case-insensitive substring of the PR name
+	NameContains string
+	// Search is a full-text query over the words of the PR name (web search syntax: "quoted", -excluded, or)
+	Search string
+	Sort   PRSort
+	Limit  int
+	// After continues the list from a previous page; its sort must match Sort
+	After *PRCursor
+}
+
+// PRSummary is a PR as shown in lists: with its reviewers and the author's team, without reviews
+type PRSummary struct {
+	PullRequestShort
+	TeamName          string
+	AssignedReviewers []string
+	CreatedAt         time.Time
+	MergedAt          *time.Time
+	ClosedAt          *time.Time
+}
+
+// PRSummaryPage is one page of /pullRequest/list
+// NextCursor is empty on the last page
+type PRSummaryPage struct {
+	PullRequests []PRSummary
+	NextCursor   string
+}
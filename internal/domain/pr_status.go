@@ -0,0 +1,94 @@
+package domain
+
+import "time"
+
+type PRStatus string
+
+const (
+	PRStatusDraft  PRStatus = "DRAFT"
+	PRStatusOpen   PRStatus = "OPEN"
+	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
+)
+
+// PRAction is a lifecycle transition requested for a PR
+type PRAction string
+
+const (
+	PRActionReady  PRAction = "ready"
+	PRActionMerge  PRAction = "merge"
+	PRActionClose  PRAction = "close"
+	PRActionReopen PRAction = "reopen"
+)
+
+// prTransitions is the PR lifecycle state machine: status -> action -> next status
+// MERGED is terminal; CLOSED can only be reopened
+var prTransitions = map[PRStatus]map[PRAction]PRStatus{
+	PRStatusDraft: {
+		PRActionReady: PRStatusOpen,
+		PRActionClose: PRStatusClosed,
+	},
+	PRStatusOpen: {
+		PRActionMerge: PRStatusMerged,
+		PRActionClose: PRStatusClosed,
+	},
+	PRStatusClosed: {
+		PRActionReopen: PRStatusOpen,
+	},
+}
+
+func (s PRStatus) IsValid() bool {
+	switch s {
+	case PRStatusDraft, PRStatusOpen, PRStatusMerged, PRStatusClosed:
+		return true
+	}
+	return false
+}
+
+// Next returns the status the action leads to from s
+func (s PRStatus) Next(action PRAction) (PRStatus, error) {
+	if s == PRStatusMerged {
+		return s, ErrPRMerged
+	}
+	next, ok := prTransitions[s][action]
+	if !ok {
+		return s, ErrInvalidTransition
+	}
+	return next, nil
+}
+
+// Apply moves the PR through the state machine and stamps the matching timestamp
+func (pr *PullRequest) Apply(action PRAction) error {
+	next, err := pr.Status.Next(action)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	switch next {
+	case PRStatusMerged:
+		pr.MergedAt = &now
+	case PRStatusClosed:
+		pr.ClosedAt = &now
+	case PRStatusOpen:
+		pr.ClosedAt = nil
+	}
+	pr.Status = next
+	return nil
+}
+
+// EnsureOpen reports why reviewers of the PR can't be changed, if they can't
+// Reviewers are frozen on MERGED and CLOSED PRs and not assigned to drafts
+func (pr *PullRequest) EnsureOpen() error {
+	switch pr.Status {
+	case PRStatusOpen:
+		return nil
+	case PRStatusMerged:
+		return ErrPRMerged
+	case PRStatusClosed:
+		return ErrPRClosed
+	case PRStatusDraft:
+		return ErrPRDraft
+	}
+	return ErrInvalidPRStatus
+}
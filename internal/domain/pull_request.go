@@ -2,21 +2,17 @@ package domain
 
 import "time"
 
-type PRStatus string
-
-const (
-	PRStatusOpen   PRStatus = "OPEN"
-	PRStatusMerged PRStatus = "MERGED"
-)
-
 type PullRequest struct {
 	ID                string     `json:"pull_request_id"`
 	Name              string     `json:"pull_request_name"`
 	AuthorID          string     `json:"author_id"`
 	Status            PRStatus   `json:"status"`
 	AssignedReviewers []string   `json:"assigned_reviewers"`
+	Reviews           []Review   `json:"reviews"`
 	CreatedAt         *time.Time `json:"createdAt,omitempty"`
 	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	ClosedAt          *time.Time `json:"closedAt,omitempty"`
+	ForceMerged       bool       `json:"force_merged"`
 }
 
 type PullRequestShort struct {
@@ -33,7 +29,8 @@ func NewPullRequest(id, name, authorID string) *PullRequest {
 		Name:              name,
 		AuthorID:          authorID,
 		Status:            PRStatusOpen,
-		AssignedReviewers: make([]string, 0, 2),
+		AssignedReviewers: make([]string, 0, DefaultMaxReviewers),
+		Reviews:           make([]Review, 0, DefaultMaxReviewers),
 		CreatedAt:         &now,
 	}
 }
@@ -48,7 +45,7 @@ func (pr *PullRequest) Validate() error {
 	if pr.AuthorID == "" {
 		return ErrInvalidInput
 	}
-	if pr.Status != PRStatusOpen && pr.Status != PRStatusMerged {
+	if !pr.Status.IsValid() {
 		return ErrInvalidPRStatus
 	}
 	return nil
@@ -62,10 +59,7 @@ func (pr *PullRequest) Merge() error {
 	if pr.IsMerged() {
 		return nil // Idempotent
 	}
-	now := time.Now()
-	pr.Status = PRStatusMerged
-	pr.MergedAt = &now
-	return nil
+	return pr.Apply(PRActionMerge)
 }
 
 func (pr *PullRequest) HasReviewer(userID string) bool {
@@ -77,23 +71,25 @@ func (pr *PullRequest) HasReviewer(userID string) bool {
 	return false
 }
 
-func (pr *PullRequest) AddReviewer(userID string) error {
-	if pr.IsMerged() {
-		return ErrPRMerged
+// AddReviewer assigns a reviewer keeping at most maxReviewers (team policy)
+func (pr *PullRequest) AddReviewer(userID string, maxReviewers int) error {
+	if err := pr.EnsureOpen(); err != nil {
+		return err
 	}
 	if pr.HasReviewer(userID) {
 		return nil // Already assigned
 	}
-	if len(pr.AssignedReviewers) >= 2 {
-		return ErrInvalidInput
+	if len(pr.AssignedReviewers) >= maxReviewers {
+		return ErrTooManyReviewers
 	}
 	pr.AssignedReviewers = append(pr.AssignedReviewers, userID)
+	pr.Reviews = append(pr.Reviews, Review{ReviewerID: userID, State: ReviewStatePending})
 	return nil
 }
 
 func (pr *PullRequest) RemoveReviewer(userID string) error {
-	if pr.IsMerged() {
-		return ErrPRMerged
+	if err := pr.EnsureOpen(); err != nil {
+		return err
 	}
 	if !pr.HasReviewer(userID) {
 		return ErrReviewerNotFound
@@ -106,9 +102,60 @@ func (pr *PullRequest) RemoveReviewer(userID string) error {
 		}
 	}
 	pr.AssignedReviewers = newReviewers
+
+	newReviews := make([]Review, 0, len(pr.Reviews))
+	for _, review := range pr.Reviews {
+		if review.ReviewerID != userID {
+			newReviews = append(newReviews, review)
+		}
+	}
+	pr.Reviews = newReviews
+	return nil
+}
+
+// SubmitReview records a reviewer's verdict on an OPEN PR
+func (pr *PullRequest) SubmitReview(userID string, state ReviewState) error {
+	if !state.IsSubmitted() {
+		return ErrInvalidReviewState
+	}
+	if err := pr.EnsureOpen(); err != nil {
+		return err
+	}
+	if !pr.HasReviewer(userID) {
+		return ErrReviewerNotFound
+	}
+
+	now := time.Now()
+	for i := range pr.Reviews {
+		if pr.Reviews[i].ReviewerID == userID {
+			pr.Reviews[i].State = state
+			pr.Reviews[i].UpdatedAt = &now
+		}
+	}
 	return nil
 }
 
+// Approvals returns the number of reviewers who approved the PR
+func (pr *PullRequest) Approvals() int {
+	count := 0
+	for _, review := range pr.Reviews {
+		if review.State == ReviewStateApproved {
+			count++
+		}
+	}
+	return count
+}
+
+// HasChangesRequested reports whether any reviewer requested changes
+func (pr *PullRequest) HasChangesRequested() bool {
+	for _, review := range pr.Reviews {
+		if review.State == ReviewStateChangesRequested {
+			return true
+		}
+	}
+	return false
+}
+
 func (pr *PullRequest) ToShort() *PullRequestShort {
 	return &PullRequestShort{
 		ID:       pr.ID,
@@ -0,0 +1,45 @@
+package domain
+
+// ReviewerReassignment describes a reviewer taken off an OPEN PR
+// NewReviewerID is empty when no replacement was found
+type ReviewerReassignment struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+}
+
+// ReassignmentReport summarizes a bulk reassignment of OPEN reviews
+// LeftShort lists PRs that lost a reviewer without getting a replacement
+type ReassignmentReport struct {
+	Reassigned []ReviewerReassignment `json:"reassigned"`
+	LeftShort  []ReviewerReassignment `json:"left_short"`
+}
+
+func NewReassignmentReport() *ReassignmentReport {
+	return &ReassignmentReport{
+		Reassigned: []ReviewerReassignment{},
+		LeftShort:  []ReviewerReassignment{},
+	}
+}
+
+// ForReviewer returns the part of the report about reviews taken from reviewerID
+func (r *ReassignmentReport) ForReviewer(reviewerID string) *ReassignmentReport {
+	part := NewReassignmentReport()
+	for _, item := range r.Reassigned {
+		if item.OldReviewerID == reviewerID {
+			part.Reassigned = append(part.Reassigned, item)
+		}
+	}
+	for _, item := range r.LeftShort {
+		if item.OldReviewerID == reviewerID {
+			part.LeftShort = append(part.LeftShort, item)
+		}
+	}
+	return part
+}
+
+// Merge appends the items of other to the report
+func (r *ReassignmentReport) Merge(other *ReassignmentReport) {
+	r.Reassigned = append(r.Reassigned, other.Reassigned...)
+	r.LeftShort = append(r.LeftShort, other.LeftShort...)
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+type ReviewState string
+
+const (
+	ReviewStatePending          ReviewState = "PENDING"
+	ReviewStateApproved         ReviewState = "APPROVED"
+	ReviewStateChangesRequested ReviewState = "CHANGES_REQUESTED"
+	ReviewStateCommented        ReviewState = "COMMENTED"
+)
+
+// Review is the state of a single reviewer assignment on a PR
+// UpdatedAt is nil until the reviewer submits a review
+// EscalatedAt is set when the review breached the team SLA and nobody could take it over
+type Review struct {
+	ReviewerID  string      `json:"user_id"`
+	State       ReviewState `json:"state"`
+	UpdatedAt   *time.Time  `json:"state_updated_at,omitempty"`
+	EscalatedAt *time.Time  `json:"escalated_at,omitempty"`
+}
+
+func (s ReviewState) IsValid() bool {
+	switch s {
+	case ReviewStatePending, ReviewStateApproved, ReviewStateChangesRequested, ReviewStateCommented:
+		return true
+	}
+	return false
+}
+
+// IsSubmitted reports whether the state can be set by a reviewer (anything but PENDING)
+func (s ReviewState) IsSubmitted() bool {
+	return s.IsValid() && s != ReviewStatePending
+}
+
+// PendingReviews builds PENDING reviews for freshly assigned reviewers
+func PendingReviews(reviewerIDs []string) []Review {
+	reviews := make([]Review, len(reviewerIDs))
+	for i, reviewerID := range reviewerIDs {
+		reviews[i] = Review{ReviewerID: reviewerID, State: ReviewStatePending}
+	}
+	return reviews
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// SLAAction is what happens to a review left PENDING longer than the team's review SLA
+type SLAAction string
+
+const (
+	// SLAActionReassign replaces the overdue reviewer like ReassignReviewer does
+	SLAActionReassign SLAAction = "reassign"
+	// SLAActionEscalate hands the overdue review over to the team lead
+	SLAActionEscalate SLAAction = "escalate"
+)
+
+func (a SLAAction) IsValid() bool {
+	switch a {
+	case SLAActionReassign, SLAActionEscalate:
+		return true
+	}
+	return false
+}
+
+// OrDefault returns SLAActionReassign for an unset action
+func (a SLAAction) OrDefault() SLAAction {
+	if a == "" {
+		return SLAActionReassign
+	}
+	return a
+}
+
+// OverdueReview is a PENDING review on an OPEN PR that outlived the review SLA of the author's team
+type OverdueReview struct {
+	PullRequestID string
+	ReviewerID    string
+	AssignedAt    time.Time
+	TeamName      string
+	Action        SLAAction
+	LeadUserID    *string
+}
+
+// SLAReport summarizes one pass over overdue reviews
+// Escalated entries have an empty NewReviewerID when the lead could not take the review over
+type SLAReport struct {
+	Reassigned []ReviewerReassignment `json:"reassigned"`
+	Escalated  []ReviewerReassignment `json:"escalated"`
+}
+
+func NewSLAReport() *SLAReport {
+	return &SLAReport{
+		Reassigned: []ReviewerReassignment{},
+		Escalated:  []ReviewerReassignment{},
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// StreamEvent is an Event persisted to the event stream
+// Seq grows with every event and is the SSE event ID clients resume from
+type StreamEvent struct {
+	Seq       int64
+	EventID   string
+	Type      EventType
+	TeamName  *string
+	UserIDs   []string
+	Payload   json.RawMessage
+	CreatedAt time.Time
+}
+
+// StreamFilter selects events of one team and/or one user; empty fields match everything
+type StreamFilter struct {
+	TeamName string
+	UserID   string
+}
+
+// Matches reports whether the event passes the filter
+func (f StreamFilter) Matches(e StreamEvent) bool {
+	if f.TeamName != "" && (e.TeamName == nil || *e.TeamName != f.TeamName) {
+		return false
+	}
+	if f.UserID != "" && !slices.Contains(e.UserIDs, f.UserID) {
+		return false
+	}
+	return true
+}
+
+// InvolvedUsers returns the users an event is about: the PR author and reviewers,
+// or the deactivated or transferred user; team.deactivated involves no single user
+func (e Event) InvolvedUsers() []string {
+	var ids []string
+	add := func(values ...string) {
+		for _, id := range values {
+			if id != "" && !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	switch data := e.Data.(type) {
+	case PREventData:
+		if data.PR != nil {
+			add(data.PR.AuthorID)
+			add(data.PR.AssignedReviewers...)
+		}
+		add(data.Added...)
+	case ReviewerReassignedData:
+		if data.PR != nil {
+			add(data.PR.AuthorID)
+			add(data.PR.AssignedReviewers...)
+		}
+		add(data.OldReviewerID, data.NewReviewerID)
+	case UserDeactivatedData:
+		if data.User != nil {
+			add(data.User.ID)
+		}
+	case UserTransferredData:
+		if data.User != nil {
+			add(data.User.ID)
+		}
+	}
+	return ids
+}
@@ -1,8 +1,97 @@
 package domain
 
+import "text/template"
+
+// Bounds of the team reviewer policy
+const (
+	DefaultMinReviewers = 1
+	DefaultMaxReviewers = 2
+	MaxReviewersLimit   = 10
+)
+
 type Team struct {
-	Name    string `json:"team_name"`
-	Members []User `json:"members"`
+	Name    string      `json:"team_name"`
+	Members []User      `json:"members"`
+	Policy  *TeamPolicy `json:"policy,omitempty"`
+	// AllowMoves lets /team/add take members over from other teams;
+	// otherwise listing a member of another team fails with ErrUserInOtherTeam
+	AllowMoves bool `json:"-"`
+	// MoveReviewPolicy decides what happens to the reviews moved members have on OPEN PRs,
+	// as with /users/transfer; required with AllowMoves
+	MoveReviewPolicy TransferReviewPolicy `json:"-"`
+}
+
+// TeamPolicy describes how many reviewers a PR authored in the team gets,
+// which reviews it needs before it can be merged and how long a review may stay PENDING
+// RequiredApprovals = 0 and BlockOnChangesRequested = false leave merges unrestricted
+// ReviewSLAMinutes = 0 disables the review SLA
+// ChatChannel and ChatTemplate route reviewer chat notifications; nil falls back to the configured defaults
+type TeamPolicy struct {
+	MinReviewers            int       `json:"min_reviewers"`
+	MaxReviewers            int       `json:"max_reviewers"`
+	RequiredApprovals       int       `json:"required_approvals"`
+	BlockOnChangesRequested bool      `json:"block_on_changes_requested"`
+	ReviewSLAMinutes        int       `json:"review_sla_minutes"`
+	SLAAction               SLAAction `json:"sla_action"`
+	LeadUserID              *string   `json:"lead_user_id"`
+	ChatChannel             *string   `json:"chat_channel"`
+	ChatTemplate            *string   `json:"chat_template"`
+}
+
+// TeamSummary is a team as listed by /team/list
+// A team is active while it has at least one active member
+type TeamSummary struct {
+	Name        string `json:"team_name"`
+	MemberCount int    `json:"member_count"`
+	ActiveCount int    `json:"active_count"`
+}
+
+// TeamSyncMode tells /team/add what to do with stored members missing from the request
+type TeamSyncMode string
+
+const (
+	// TeamSyncUpsert creates and updates the listed members and leaves the others as they are
+	TeamSyncUpsert TeamSyncMode = "upsert"
+	// TeamSyncReplace also deactivates active members missing from the request
+	TeamSyncReplace TeamSyncMode = "replace"
+)
+
+func (m TeamSyncMode) IsValid() bool {
+	return m == TeamSyncUpsert || m == TeamSyncReplace
+}
+
+// MembershipChanges lists the user IDs affected by a replace sync of a team
+// Moved members came from another team (FromTeams) and had their OPEN reviews handled by the team's
+// MoveReviewPolicy; removed members were deactivated and their OPEN reviews reassigned,
+// as were the reviews of Deactivated members, listed with is_active=false after being active
+// Reassignment describes all of them
+// Members listed with unchanged attributes appear in none of the lists
+type MembershipChanges struct {
+	Added        []string            `json:"added"`
+	Updated      []string            `json:"updated"`
+	Moved        []string            `json:"moved"`
+	Removed      []string            `json:"removed"`
+	Reassignment *ReassignmentReport `json:"reassignment"`
+	FromTeams    map[string]string   `json:"-"`
+	Deactivated  []string            `json:"-"`
+}
+
+func NewMembershipChanges() *MembershipChanges {
+	return &MembershipChanges{
+		Added:        []string{},
+		Updated:      []string{},
+		Moved:        []string{},
+		Removed:      []string{},
+		Reassignment: NewReassignmentReport(),
+		FromTeams:    map[string]string{},
+	}
+}
+
+// MemberMoves describes the members an upsert sync took over from other teams:
+// the team each of them left and what happened to their reviews on OPEN PRs
+type MemberMoves struct {
+	FromTeams    map[string]string
+	Reassignment *ReassignmentReport
 }
 
 func NewTeam(name string, members []User) *Team {
@@ -12,9 +101,75 @@ func NewTeam(name string, members []User) *Team {
 	}
 }
 
+// DefaultTeamPolicy returns the policy applied to teams that never configured one
+func DefaultTeamPolicy() *TeamPolicy {
+	return &TeamPolicy{
+		MinReviewers: DefaultMinReviewers,
+		MaxReviewers: DefaultMaxReviewers,
+		SLAAction:    SLAActionReassign,
+	}
+}
+
 func (t *Team) Validate() error {
 	if t.Name == "" {
 		return ErrInvalidInput
 	}
+	if t.AllowMoves && !t.MoveReviewPolicy.IsValid() {
+		return ErrInvalidInput
+	}
+	if t.Policy != nil {
+		return t.Policy.Validate()
+	}
+	return nil
+}
+
+func (p *TeamPolicy) Validate() error {
+	if p.MinReviewers < 0 || p.MaxReviewers < 1 || p.MaxReviewers > MaxReviewersLimit {
+		return ErrInvalidTeamPolicy
+	}
+	if p.MinReviewers > p.MaxReviewers {
+		return ErrInvalidTeamPolicy
+	}
+	// More approvals than reviewers would make every PR unmergeable
+	if p.RequiredApprovals < 0 || p.RequiredApprovals > p.MaxReviewers {
+		return ErrInvalidTeamPolicy
+	}
+	if p.ReviewSLAMinutes < 0 || (p.SLAAction != "" && !p.SLAAction.IsValid()) {
+		return ErrInvalidTeamPolicy
+	}
+	if p.LeadUserID != nil && *p.LeadUserID == "" {
+		return ErrInvalidTeamPolicy
+	}
+	// Escalation needs someone to escalate to
+	if p.SLAAction == SLAActionEscalate && p.LeadUserID == nil {
+		return ErrInvalidTeamPolicy
+	}
+	if p.ChatTemplate != nil {
+		if _, err := template.New("chat").Parse(*p.ChatTemplate); err != nil {
+			return ErrInvalidTeamPolicy
+		}
+	}
+	return nil
+}
+
+// CheckMergeable validates the PR's reviews against the team's merge rule
+func (p *TeamPolicy) CheckMergeable(pr *PullRequest) error {
+	if pr.Approvals() < p.RequiredApprovals {
+		return ErrNotApproved
+	}
+	if p.BlockOnChangesRequested && pr.HasChangesRequested() {
+		return ErrNotApproved
+	}
+	return nil
+}
+
+// CheckReviewersCount validates the number of explicitly chosen reviewers
+func (p *TeamPolicy) CheckReviewersCount(count int) error {
+	if count > p.MaxReviewers {
+		return ErrTooManyReviewers
+	}
+	if count < p.MinReviewers {
+		return ErrTooFewReviewers
+	}
 	return nil
 }
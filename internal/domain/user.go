@@ -5,14 +5,52 @@ type User struct {
 	Username string `json:"username"`
 	TeamName string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
+	// MaxOpenReviews limits the number of OPEN PRs the user reviews at once (nil = unlimited)
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+	EmailSettings
+}
+
+// UserUpdate describes a partial update of user attributes
+// Only fields marked as Set are changed
+type UserUpdate struct {
+	MaxOpenReviews Optional[int]        `json:"max_open_reviews"`
+	Email          Optional[string]     `json:"email"`
+	EmailOptOut    Optional[bool]       `json:"email_opt_out"`
+	QuietHours     Optional[QuietHours] `json:"quiet_hours"`
+	Timezone       Optional[string]     `json:"timezone"`
+}
+
+// ReviewCandidate is an active team member eligible for review together with
+// the number of OPEN pull requests they are already reviewing
+type ReviewCandidate struct {
+	User
+	OpenReviews int `json:"open_reviews"`
+}
+
+// MaxOpenReviewsLimit bounds max_open_reviews; a larger limit is the same as no limit (nil)
+const MaxOpenReviewsLimit = 1000
+
+// Page sizes of /users/search
+const (
+	DefaultUserSearchLimit = 20
+	MaxUserSearchLimit     = 100
+)
+
+// UserSearch selects users whose ID or username contains Query (case-insensitive)
+// IsActive nil matches both active and inactive users
+type UserSearch struct {
+	Query    string
+	IsActive *bool
+	Limit    int
 }
 
 func NewUser(id, username, teamName string, isActive bool) *User {
 	return &User{
-		ID:       id,
-		Username: username,
-		TeamName: teamName,
-		IsActive: isActive,
+		ID:            id,
+		Username:      username,
+		TeamName:      teamName,
+		IsActive:      isActive,
+		EmailSettings: EmailSettings{Timezone: DefaultTimezone},
 	}
 }
 
@@ -26,5 +64,51 @@ func (u *User) Validate() error {
 	if u.TeamName == "" {
 		return ErrInvalidInput
 	}
+	if u.MaxOpenReviews != nil && (*u.MaxOpenReviews < 0 || *u.MaxOpenReviews > MaxOpenReviewsLimit) {
+		return ErrInvalidInput
+	}
+	return u.EmailSettings.Validate()
+}
+
+// HasCapacity reports whether the user can take one more review
+// given the number of OPEN PRs they are already reviewing
+func (u *User) HasCapacity(openReviews int) bool {
+	return u.MaxOpenReviews == nil || openReviews < *u.MaxOpenReviews
+}
+
+func (upd *UserUpdate) Validate() error {
+	if v := upd.MaxOpenReviews.Value; v != nil && (*v < 0 || *v > MaxOpenReviewsLimit) {
+		return ErrInvalidInput
+	}
+	// Opt-out is a flag, it can't be null
+	if upd.EmailOptOut.Set && upd.EmailOptOut.Value == nil {
+		return ErrInvalidInput
+	}
 	return nil
 }
+
+// HasEmailSettings reports whether the update changes any email preference
+func (upd *UserUpdate) HasEmailSettings() bool {
+	return upd.Email.Set || upd.EmailOptOut.Set || upd.QuietHours.Set || upd.Timezone.Set
+}
+
+// ApplyEmailSettings returns current with the email preferences of the update applied
+// A null timezone resets it to DefaultTimezone
+func (upd *UserUpdate) ApplyEmailSettings(current EmailSettings) EmailSettings {
+	if upd.Email.Set {
+		current.Email = upd.Email.Value
+	}
+	if upd.EmailOptOut.Set && upd.EmailOptOut.Value != nil {
+		current.EmailOptOut = *upd.EmailOptOut.Value
+	}
+	if upd.QuietHours.Set {
+		current.QuietHours = upd.QuietHours.Value
+	}
+	if upd.Timezone.Set {
+		current.Timezone = DefaultTimezone
+		if upd.Timezone.Value != nil {
+			current.Timezone = *upd.Timezone.Value
+		}
+	}
+	return current
+}
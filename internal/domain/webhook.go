@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+// WebhookSubscription is an HTTP endpoint that receives events as signed JSON POSTs
+// An empty Events list subscribes to every event type
+type WebhookSubscription struct {
+	ID        int64       `json:"id"`
+	URL       string      `json:"url"`
+	Secret    string      `json:"-"`
+	Events    []EventType `json:"events"`
+	CreatedAt *time.Time  `json:"created_at,omitempty"`
+}
+
+func (s *WebhookSubscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhook
+	}
+	if s.Secret == "" {
+		return ErrInvalidWebhook
+	}
+	for _, eventType := range s.Events {
+		if !eventType.IsValid() {
+			return ErrInvalidWebhook
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the subscription wants events of the given type
+func (s *WebhookSubscription) Matches(eventType EventType) bool {
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, t := range s.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event queued for one subscription
+// URL and Secret are copied from the subscription when the delivery is claimed
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventType      EventType       `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastError      string          `json:"last_error,omitempty"`
+	URL            string          `json:"-"`
+	Secret         string          `json:"-"`
+}
+
+// WebhookDeadLetter is a delivery that ran out of retry attempts
+type WebhookDeadLetter struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventType      EventType       `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Attempts       int             `json:"attempts"`
+	LastError      string          `json:"last_error"`
+	FailedAt       time.Time       `json:"failed_at"`
+}
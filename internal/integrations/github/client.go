@@ -0,0 +1,145 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"test_avito/internal/integrations"
+)
+
+// DefaultAPIURL is the REST API of github.com; GitHub Enterprise serves it at https://<host>/api/v3
+const DefaultAPIURL = "https://api.github.com"
+
+// apiVersion pins the REST API version the client was written against
+const apiVersion = "2022-11-28"
+
+// Client mirrors reviewer assignments to GitHub through the REST API
+// It implements service.CodeHostClient: only PRs with "owner/repo#number" IDs are synced,
+// users without a mapped login are skipped, and API errors are logged, not returned
+type Client struct {
+	baseURL string
+	token   string
+	logins  integrations.LoginMap
+	client  *http.Client
+	logger  *slog.Logger
+}
+
+func NewClient(
+	baseURL, token string,
+	logins integrations.LoginMap,
+	timeout time.Duration,
+	logger *slog.Logger,
+) *Client {
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		logins:  logins,
+		client:  &http.Client{Timeout: timeout},
+		logger:  logger,
+	}
+}
+
+// ParsePullRequestID splits an ID built by PullRequestID back into the repository and PR number
+func ParsePullRequestID(prID string) (string, int, bool) {
+	repo, rawNumber, ok := strings.Cut(prID, "#")
+	if !ok || strings.Count(repo, "/") != 1 || strings.HasPrefix(repo, "/") || strings.HasSuffix(repo, "/") {
+		return "", 0, false
+	}
+	number, err := strconv.Atoi(rawNumber)
+	if err != nil || number <= 0 {
+		return "", 0, false
+	}
+	return repo, number, true
+}
+
+// RequestReviewers asks GitHub to request reviews from the users on the PR
+func (c *Client) RequestReviewers(ctx context.Context, prID string, reviewerIDs []string) {
+	c.syncReviewers(ctx, http.MethodPost, prID, reviewerIDs)
+}
+
+// RemoveRequestedReviewers withdraws review requests of the users on the PR
+func (c *Client) RemoveRequestedReviewers(ctx context.Context, prID string, reviewerIDs []string) {
+	c.syncReviewers(ctx, http.MethodDelete, prID, reviewerIDs)
+}
+
+func (c *Client) syncReviewers(ctx context.Context, method, prID string, reviewerIDs []string) {
+	repo, number, ok := ParsePullRequestID(prID)
+	if !ok {
+		return
+	}
+
+	logins := make([]string, 0, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		login, ok := c.logins.Login(id)
+		if !ok {
+			c.logger.Warn("user has no github login, review request skipped",
+				slog.String("pr_id", prID),
+				slog.String("user_id", id),
+			)
+			continue
+		}
+		logins = append(logins, login)
+	}
+	if len(logins) == 0 {
+		return
+	}
+
+	if err := c.requestedReviewers(ctx, method, repo, number, logins); err != nil {
+		c.logger.Error("failed to sync reviewers to github",
+			slog.String("pr_id", prID),
+			slog.String("method", method),
+			slog.Any("logins", logins),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	c.logger.Info("reviewers synced to github",
+		slog.String("pr_id", prID),
+		slog.String("method", method),
+		slog.Any("logins", logins),
+	)
+}
+
+// requestedReviewers calls POST or DELETE /repos/{owner}/{repo}/pulls/{number}/requested_reviewers
+func (c *Client) requestedReviewers(ctx context.Context, method, repo string, number int, logins []string) error {
+	body, err := json.Marshal(map[string][]string{"reviewers": logins})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/pulls/%d/requested_reviewers", c.baseURL, repo, number)
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
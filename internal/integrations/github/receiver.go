@@ -0,0 +1,160 @@
+// Package github maps GitHub pull_request webhooks onto PullRequestService calls
+package github
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/integrations"
+	"test_avito/internal/service"
+)
+
+// Headers of a GitHub webhook delivery
+const (
+	EventHeader     = "X-GitHub-Event"
+	SignatureHeader = "X-Hub-Signature-256"
+	DeliveryHeader  = "X-GitHub-Delivery"
+)
+
+// pullRequestEvent is the part of the pull_request payload the receiver uses
+type pullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Title  string `json:"title"`
+		Draft  bool   `json:"draft"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+type Receiver struct {
+	secret    []byte
+	logins    integrations.LoginMap
+	prService *service.PullRequestService
+	logger    *slog.Logger
+}
+
+func NewReceiver(
+	secret string,
+	logins integrations.LoginMap,
+	prService *service.PullRequestService,
+	logger *slog.Logger,
+) *Receiver {
+	return &Receiver{
+		secret:    []byte(secret),
+		logins:    logins,
+		prService: prService,
+		logger:    logger,
+	}
+}
+
+// PullRequestID returns the ID a GitHub PR gets in the service: "owner/repo#number"
+func PullRequestID(repoFullName string, number int) string {
+	return fmt.Sprintf("%s#%d", repoFullName, number)
+}
+
+// VerifySignature checks X-Hub-Signature-256: "sha256=" + hex HMAC-SHA256 of the body with the webhook secret
+func (r *Receiver) VerifySignature(body []byte, signature string) bool {
+	hexSum, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(hexSum)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, r.secret)
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// Handle verifies a delivery and applies it
+// pull_request actions are mapped as follows:
+//   - opened: CreatePR, or CreateDraftPR for a draft
+//   - ready_for_review: MarkReady; a PR that is already OPEN gets reviewers assigned instead
+//   - closed with merged=true: MergePR; if the team rule isn't met the merge is recorded as forced,
+//     since it already happened on GitHub
+//   - closed without merge: ClosePR
+//   - reopened: ReopenPR
+//
+// Other events and actions, unknown authors and PRs the service doesn't know are ignored
+func (r *Receiver) Handle(ctx context.Context, event, signature string, body []byte) (*integrations.Result, error) {
+	if !r.VerifySignature(body, signature) {
+		r.logger.Warn("github webhook signature mismatch", slog.String("event", event))
+		return nil, domain.ErrInvalidSignature
+	}
+
+	if event != "pull_request" {
+		return integrations.Ignored(event, "", "", "unsupported event"), nil
+	}
+
+	var payload pullRequestEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, domain.ErrInvalidInput
+	}
+	if payload.Repository.FullName == "" || payload.Number <= 0 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	prID := PullRequestID(payload.Repository.FullName, payload.Number)
+	action := payload.Action
+
+	r.logger.Info("github pull_request event",
+		slog.String("action", action),
+		slog.String("pr_id", prID),
+	)
+
+	var err error
+	switch action {
+	case "opened":
+		authorID, ok := r.logins.UserID(payload.PullRequest.User.Login)
+		if !ok {
+			r.logger.Warn("github login is not mapped to a user",
+				slog.String("login", payload.PullRequest.User.Login),
+				slog.String("pr_id", prID),
+			)
+			return integrations.Ignored(event, action, prID, "unknown author login"), nil
+		}
+		if payload.PullRequest.Draft {
+			_, err = r.prService.CreateDraftPR(ctx, prID, payload.PullRequest.Title, authorID)
+		} else {
+			_, err = r.prService.CreatePR(ctx, prID, payload.PullRequest.Title, authorID)
+		}
+	case "ready_for_review":
+		_, err = r.prService.MarkReady(ctx, prID)
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			_, err = r.prService.AssignReviewersToPR(ctx, prID, nil)
+		}
+	case "closed":
+		if payload.PullRequest.Merged {
+			_, err = r.prService.MergePR(ctx, prID, false)
+			if errors.Is(err, domain.ErrNotApproved) {
+				r.logger.Warn("PR merged on github without satisfying team rule", slog.String("pr_id", prID))
+				_, err = r.prService.MergePR(ctx, prID, true)
+			}
+		} else {
+			_, err = r.prService.ClosePR(ctx, prID)
+		}
+	case "reopened":
+		_, err = r.prService.ReopenPR(ctx, prID)
+	default:
+		return integrations.Ignored(event, action, prID, "unsupported action"), nil
+	}
+
+	return integrations.Outcome(event, action, prID, err)
+}
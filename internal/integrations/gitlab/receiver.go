@@ -0,0 +1,206 @@
+// Package gitlab maps GitLab Merge Request Hook webhooks onto PullRequestService calls
+package gitlab
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/integrations"
+	"test_avito/internal/service"
+)
+
+// Headers of a GitLab webhook delivery
+const (
+	EventHeader = "X-Gitlab-Event"
+	TokenHeader = "X-Gitlab-Token"
+)
+
+// MergeRequestHook is the X-Gitlab-Event value of merge request events
+const MergeRequestHook = "Merge Request Hook"
+
+// draftPrefixes are the title prefixes GitLab treats as a draft marker (case-insensitive)
+var draftPrefixes = []string{"draft:", "[draft]", "(draft)"}
+
+type boolChange struct {
+	Previous bool `json:"previous"`
+	Current  bool `json:"current"`
+}
+
+type stringChange struct {
+	Previous string `json:"previous"`
+	Current  string `json:"current"`
+}
+
+// mergeRequestEvent is the part of the Merge Request Hook payload the receiver uses
+type mergeRequestEvent struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		ID int64 `json:"id"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID            int64  `json:"iid"`
+		Title          string `json:"title"`
+		Action         string `json:"action"`
+		Draft          bool   `json:"draft"`
+		WorkInProgress bool   `json:"work_in_progress"`
+	} `json:"object_attributes"`
+	Changes struct {
+		Draft          *boolChange   `json:"draft"`
+		WorkInProgress *boolChange   `json:"work_in_progress"`
+		Title          *stringChange `json:"title"`
+	} `json:"changes"`
+}
+
+// isDraft reports whether the merge request is a draft when the event was sent
+func (e *mergeRequestEvent) isDraft() bool {
+	return e.ObjectAttributes.Draft || e.ObjectAttributes.WorkInProgress || hasDraftPrefix(e.ObjectAttributes.Title)
+}
+
+// draftToggle reports whether an update changed the draft flag and its new value
+// Older GitLab versions report the flag as work_in_progress or only through the title prefix
+func (e *mergeRequestEvent) draftToggle() (draft, toggled bool) {
+	switch {
+	case e.Changes.Draft != nil && e.Changes.Draft.Previous != e.Changes.Draft.Current:
+		return e.Changes.Draft.Current, true
+	case e.Changes.WorkInProgress != nil && e.Changes.WorkInProgress.Previous != e.Changes.WorkInProgress.Current:
+		return e.Changes.WorkInProgress.Current, true
+	case e.Changes.Title != nil:
+		previous, current := hasDraftPrefix(e.Changes.Title.Previous), hasDraftPrefix(e.Changes.Title.Current)
+		return current, previous != current
+	}
+	return false, false
+}
+
+func hasDraftPrefix(title string) bool {
+	title = strings.ToLower(strings.TrimSpace(title))
+	for _, prefix := range draftPrefixes {
+		if strings.HasPrefix(title, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+type Receiver struct {
+	token     []byte
+	logins    integrations.LoginMap
+	prService *service.PullRequestService
+	logger    *slog.Logger
+}
+
+func NewReceiver(
+	token string,
+	logins integrations.LoginMap,
+	prService *service.PullRequestService,
+	logger *slog.Logger,
+) *Receiver {
+	return &Receiver{
+		token:     []byte(token),
+		logins:    logins,
+		prService: prService,
+		logger:    logger,
+	}
+}
+
+// PullRequestID returns the ID a GitLab merge request gets in the service: "project_id!iid"
+// Numeric project IDs survive project renames and transfers, unlike the project path
+func PullRequestID(projectID, iid int64) string {
+	return fmt.Sprintf("%d!%d", projectID, iid)
+}
+
+// VerifyToken checks X-Gitlab-Token against the configured secret token
+func (r *Receiver) VerifyToken(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), r.token) == 1
+}
+
+// Handle verifies a delivery and applies it
+// Merge request actions are mapped as follows:
+//   - open: CreatePR, or CreateDraftPR for a draft
+//   - update that removes the draft marker: MarkReady; a PR that is already OPEN gets reviewers assigned instead
+//   - merge: MergePR; if the team rule isn't met the merge is recorded as forced, since it already happened on GitLab
+//   - close: ClosePR
+//   - reopen: ReopenPR
+//
+// Marking a merge request as draft again, other updates and actions, other events,
+// unknown authors and PRs the service doesn't know are ignored
+func (r *Receiver) Handle(ctx context.Context, event, token string, body []byte) (*integrations.Result, error) {
+	if !r.VerifyToken(token) {
+		r.logger.Warn("gitlab webhook token mismatch", slog.String("event", event))
+		return nil, domain.ErrInvalidToken
+	}
+
+	if event != MergeRequestHook {
+		return integrations.Ignored(event, "", "", "unsupported event"), nil
+	}
+
+	var payload mergeRequestEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, domain.ErrInvalidInput
+	}
+	if payload.ObjectKind != "merge_request" || payload.Project.ID <= 0 || payload.ObjectAttributes.IID <= 0 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	prID := PullRequestID(payload.Project.ID, payload.ObjectAttributes.IID)
+	action := payload.ObjectAttributes.Action
+
+	r.logger.Info("gitlab merge request event",
+		slog.String("action", action),
+		slog.String("pr_id", prID),
+	)
+
+	var err error
+	switch action {
+	case "open":
+		// The user of an open event is the one who opened the merge request
+		authorID, ok := r.logins.UserID(payload.User.Username)
+		if !ok {
+			r.logger.Warn("gitlab username is not mapped to a user",
+				slog.String("username", payload.User.Username),
+				slog.String("pr_id", prID),
+			)
+			return integrations.Ignored(event, action, prID, "unknown author login"), nil
+		}
+		if payload.isDraft() {
+			_, err = r.prService.CreateDraftPR(ctx, prID, payload.ObjectAttributes.Title, authorID)
+		} else {
+			_, err = r.prService.CreatePR(ctx, prID, payload.ObjectAttributes.Title, authorID)
+		}
+	case "update":
+		draft, toggled := payload.draftToggle()
+		if !toggled {
+			return integrations.Ignored(event, action, prID, "no draft change"), nil
+		}
+		if draft {
+			// The service has no way back from OPEN to DRAFT
+			return integrations.Ignored(event, action, prID, "marking as draft is not supported"), nil
+		}
+		_, err = r.prService.MarkReady(ctx, prID)
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			_, err = r.prService.AssignReviewersToPR(ctx, prID, nil)
+		}
+	case "merge":
+		_, err = r.prService.MergePR(ctx, prID, false)
+		if errors.Is(err, domain.ErrNotApproved) {
+			r.logger.Warn("PR merged on gitlab without satisfying team rule", slog.String("pr_id", prID))
+			_, err = r.prService.MergePR(ctx, prID, true)
+		}
+	case "close":
+		_, err = r.prService.ClosePR(ctx, prID)
+	case "reopen":
+		_, err = r.prService.ReopenPR(ctx, prID)
+	default:
+		return integrations.Ignored(event, action, prID, "unsupported action"), nil
+	}
+
+	return integrations.Outcome(event, action, prID, err)
+}
@@ -0,0 +1,98 @@
+// Package integrations holds what inbound code host webhooks (GitHub, GitLab) share
+package integrations
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"test_avito/internal/domain"
+)
+
+// Result statuses
+const (
+	StatusApplied = "applied"
+	StatusIgnored = "ignored"
+)
+
+// MaxPayloadSize limits the body of an inbound webhook
+const MaxPayloadSize = 5 << 20
+
+// Result describes what an inbound event did
+// Ignored events are still acknowledged so the code host doesn't retry them
+type Result struct {
+	Event         string `json:"event"`
+	Action        string `json:"action,omitempty"`
+	PullRequestID string `json:"pull_request_id,omitempty"`
+	Status        string `json:"status"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// Applied returns a result for an event that changed a PR
+func Applied(event, action, prID string) *Result {
+	return &Result{Event: event, Action: action, PullRequestID: prID, Status: StatusApplied}
+}
+
+// Ignored returns a result for an event that was acknowledged without changes
+func Ignored(event, action, prID, reason string) *Result {
+	return &Result{Event: event, Action: action, PullRequestID: prID, Status: StatusIgnored, Reason: reason}
+}
+
+// Outcome turns the error of the service call an event was mapped to into a Result
+// Errors meaning the event is stale or already applied are acknowledged as ignored,
+// so redelivered and out of order events don't make the code host retry
+func Outcome(event, action, prID string, err error) (*Result, error) {
+	switch {
+	case err == nil:
+		return Applied(event, action, prID), nil
+	case errors.Is(err, domain.ErrPRNotFound):
+		return Ignored(event, action, prID, "unknown pull request"), nil
+	case errors.Is(err, domain.ErrPRExists), errors.Is(err, domain.ErrReviewersAlreadyAssigned),
+		errors.Is(err, domain.ErrInvalidTransition), errors.Is(err, domain.ErrPRMerged):
+		return Ignored(event, action, prID, "already applied"), nil
+	case errors.Is(err, domain.ErrPRClosed):
+		return Ignored(event, action, prID, "pull request is closed"), nil
+	case errors.Is(err, domain.ErrNoAvailableReviewer):
+		// Retrying won't help until the team changes
+		return Ignored(event, action, prID, "no available reviewer"), nil
+	default:
+		return nil, err
+	}
+}
+
+// LoginMap maps code host logins to users.id
+type LoginMap map[string]string
+
+// ParseLoginMap parses "login=user_id" pairs separated by commas, e.g. "octocat=u1,hubot=u2"
+func ParseLoginMap(s string) (LoginMap, error) {
+	logins := LoginMap{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		login, userID, ok := strings.Cut(pair, "=")
+		login, userID = strings.TrimSpace(login), strings.TrimSpace(userID)
+		if !ok || login == "" || userID == "" {
+			return nil, fmt.Errorf("invalid login mapping %q, expected login=user_id", pair)
+		}
+		logins[login] = userID
+	}
+	return logins, nil
+}
+
+// UserID returns the user mapped to login
+func (m LoginMap) UserID(login string) (string, bool) {
+	userID, ok := m[login]
+	return userID, ok
+}
+
+// Login returns the login mapped to userID
+func (m LoginMap) Login(userID string) (string, bool) {
+	for login, id := range m {
+		if id == userID {
+			return login, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,285 @@
+// Package notify tells people about review work outside the API: chat messages and emails to reviewers
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/repository"
+)
+
+// DefaultChatTemplate is used for teams without a chat_template; it receives ChatData
+const DefaultChatTemplate = `@{{.ReviewerName}}, you were {{if .Reassigned}}reassigned{{else}}assigned{{end}} ` +
+	`to review "{{.PullRequestName}}" by {{.AuthorName}}: {{.Link}}`
+
+// PRLinkPlaceholder is replaced with the query-escaped PR ID in ChatOptions.PRLinkTemplate
+const PRLinkPlaceholder = "{pull_request_id}"
+
+// ChatOptions controls the chat notifier
+type ChatOptions struct {
+	// WebhookURL is a Slack-compatible incoming webhook (Slack, Mattermost, a local stub)
+	WebhookURL string
+	// DefaultChannel is used for teams without a chat_channel; empty keeps the webhook's own channel
+	DefaultChannel string
+	// Username overrides the bot name shown in the chat, if the chat allows it
+	Username string
+	// PRLinkTemplate builds the PR link, e.g. "https://review.example.com/pr?id={pull_request_id}"
+	PRLinkTemplate string
+	// Timeout limits one request to the webhook
+	Timeout time.Duration
+}
+
+// ChatData is what chat templates can use
+type ChatData struct {
+	PullRequestID   string
+	PullRequestName string
+	Link            string
+	AuthorID        string
+	AuthorName      string
+	ReviewerID      string
+	ReviewerName    string
+	TeamName        string
+	Reassigned      bool
+}
+
+// chatMessage is the Slack incoming-webhook payload
+type chatMessage struct {
+	Text     string `json:"text"`
+	Channel  string `json:"channel,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// ChatNotifier sends a chat message to every newly assigned or reassigned reviewer
+// It implements service.EventEmitter; messages are sent in the background,
+// so a slow or failing chat never delays or fails the API call
+type ChatNotifier struct {
+	userRepo        repository.UserRepository
+	teamRepo        repository.TeamRepository
+	prRepo          repository.PullRequestRepository
+	opts            ChatOptions
+	defaultTemplate *template.Template
+	client          *http.Client
+	logger          *slog.Logger
+	wg              sync.WaitGroup
+}
+
+func NewChatNotifier(
+	userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository,
+	prRepo repository.PullRequestRepository,
+	opts ChatOptions,
+	logger *slog.Logger,
+) (*ChatNotifier, error) {
+	if opts.WebhookURL == "" {
+		return nil, fmt.Errorf("chat notifier requires a webhook url")
+	}
+	tmpl, err := template.New("chat").Parse(DefaultChatTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse default chat template: %w", err)
+	}
+	return &ChatNotifier{
+		userRepo:        userRepo,
+		teamRepo:        teamRepo,
+		prRepo:          prRepo,
+		opts:            opts,
+		defaultTemplate: tmpl,
+		client:          &http.Client{Timeout: opts.Timeout},
+		logger:          logger,
+	}, nil
+}
+
+// Emit notifies reviewers added by pr.reviewers_assigned and pr.reviewer_reassigned and replacements
+// picked by user.deactivated, user.transferred and team.deactivated; other events are ignored
+func (n *ChatNotifier) Emit(ctx context.Context, event domain.Event) {
+	if report := reassignmentOf(event.Data); report != nil {
+		if len(report.Reassigned) == 0 {
+			return
+		}
+		ctx = context.WithoutCancel(ctx)
+		n.wg.Add(1)
+		go func() {
+			defer n.wg.Done()
+			forEachReplacement(ctx, n.prRepo, report, n.logger, func(pr *domain.PullRequest, reviewerIDs []string) {
+				n.notify(ctx, pr, reviewerIDs, true)
+			})
+		}()
+		return
+	}
+
+	var (
+		pr         *domain.PullRequest
+		reviewers  []string
+		reassigned bool
+	)
+	switch data := event.Data.(type) {
+	case domain.PREventData:
+		if event.Type != domain.EventReviewersAssigned {
+			return
+		}
+		pr, reviewers = data.PR, data.Added
+	case domain.ReviewerReassignedData:
+		pr, reviewers, reassigned = data.PR, []string{data.NewReviewerID}, true
+	default:
+		return
+	}
+	if pr == nil || len(reviewers) == 0 {
+		return
+	}
+
+	// The request context is canceled once the response is written
+	ctx = context.WithoutCancel(ctx)
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		n.notify(ctx, pr, reviewers, reassigned)
+	}()
+}
+
+// Wait waits for messages that are still being sent
+func (n *ChatNotifier) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		n.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (n *ChatNotifier) notify(ctx context.Context, pr *domain.PullRequest, reviewerIDs []string, reassigned bool) {
+	author, err := n.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		n.logger.Error("failed to get PR author for chat notification",
+			slog.String("pr_id", pr.ID),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	channel := n.opts.DefaultChannel
+	tmpl := n.defaultTemplate
+	policy, err := n.teamRepo.GetPolicy(ctx, author.TeamName)
+	if err != nil {
+		n.logger.Warn("failed to get team chat settings, using defaults",
+			slog.String("team_name", author.TeamName),
+			slog.String("error", err.Error()),
+		)
+	} else {
+		if policy.ChatChannel != nil {
+			channel = *policy.ChatChannel
+		}
+		if policy.ChatTemplate != nil {
+			// Team templates are validated when the policy is saved
+			if teamTmpl, err := template.New("chat").Parse(*policy.ChatTemplate); err == nil {
+				tmpl = teamTmpl
+			}
+		}
+	}
+
+	for _, reviewerID := range reviewerIDs {
+		reviewer, err := n.userRepo.GetByID(ctx, reviewerID)
+		if err != nil {
+			n.logger.Error("failed to get reviewer for chat notification",
+				slog.String("pr_id", pr.ID),
+				slog.String("reviewer_id", reviewerID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+
+		data := ChatData{
+			PullRequestID:   pr.ID,
+			PullRequestName: pr.Name,
+			Link:            n.link(pr.ID),
+			AuthorID:        author.ID,
+			AuthorName:      author.Username,
+			ReviewerID:      reviewer.ID,
+			ReviewerName:    reviewer.Username,
+			TeamName:        author.TeamName,
+			Reassigned:      reassigned,
+		}
+		text, err := render(tmpl, data)
+		if err != nil {
+			n.logger.Warn("failed to render team chat template, using default",
+				slog.String("team_name", author.TeamName),
+				slog.String("error", err.Error()),
+			)
+			if text, err = render(n.defaultTemplate, data); err != nil {
+				continue
+			}
+		}
+
+		if err := n.send(ctx, chatMessage{Text: text, Channel: channel, Username: n.opts.Username}); err != nil {
+			n.logger.Error("failed to send chat notification",
+				slog.String("pr_id", pr.ID),
+				slog.String("reviewer_id", reviewerID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+
+		n.logger.Info("chat notification sent",
+			slog.String("pr_id", pr.ID),
+			slog.String("reviewer_id", reviewerID),
+			slog.String("channel", channel),
+		)
+	}
+}
+
+func (n *ChatNotifier) link(prID string) string {
+	return prLink(n.opts.PRLinkTemplate, prID)
+}
+
+func (n *ChatNotifier) send(ctx context.Context, msg chatMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.opts.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// prLink builds a PR link from a template with PRLinkPlaceholder
+func prLink(tmpl, prID string) string {
+	return strings.ReplaceAll(tmpl, PRLinkPlaceholder, url.QueryEscape(prID))
+}
+
+func render(tmpl *template.Template, data ChatData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
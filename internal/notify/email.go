@@ -0,0 +1,361 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/repository"
+)
+
+// EmailOptions controls the email notifier
+type EmailOptions struct {
+	// Host and Port of the SMTP server; STARTTLS is used when the server offers it
+	Host string
+	Port int
+	// Username and Password are used for AUTH PLAIN; an empty Username skips authentication
+	Username string
+	Password string
+	// From is the sender, e.g. "PR Reviewer <reviews@example.com>"
+	From string
+	// PRLinkTemplate builds the PR link, see ChatOptions.PRLinkTemplate
+	PRLinkTemplate string
+	// DigestHour is the hour of the user's local day (0-23) from which their daily digest may go out
+	DigestHour int
+	// Timeout limits one SMTP session
+	Timeout time.Duration
+}
+
+// EmailNotifier emails reviewers when they are assigned and sends each of them a daily digest
+// of pending reviews. Users without an email, who opted out or are in their quiet hours
+// get nothing; reviews skipped during quiet hours still show up in the next digest
+// It implements service.EventEmitter; assignment emails are sent in the background
+type EmailNotifier struct {
+	userRepo repository.UserRepository
+	prRepo   repository.PullRequestRepository
+	opts     EmailOptions
+	from     *mail.Address
+	logger   *slog.Logger
+	wg       sync.WaitGroup
+}
+
+func NewEmailNotifier(
+	userRepo repository.UserRepository,
+	prRepo repository.PullRequestRepository,
+	opts EmailOptions,
+	logger *slog.Logger,
+) (*EmailNotifier, error) {
+	if opts.Host == "" {
+		return nil, fmt.Errorf("email notifier requires an smtp host")
+	}
+	from, err := mail.ParseAddress(opts.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email sender %q: %w", opts.From, err)
+	}
+	return &EmailNotifier{
+		userRepo: userRepo,
+		prRepo:   prRepo,
+		opts:     opts,
+		from:     from,
+		logger:   logger,
+	}, nil
+}
+
+// Emit emails reviewers added by pr.reviewers_assigned and pr.reviewer_reassigned and replacements
+// picked by user.deactivated, user.transferred and team.deactivated; other events are ignored
+func (n *EmailNotifier) Emit(ctx context.Context, event domain.Event) {
+	if report := reassignmentOf(event.Data); report != nil {
+		if len(report.Reassigned) == 0 {
+			return
+		}
+		ctx = context.WithoutCancel(ctx)
+		now := event.OccurredAt
+		n.wg.Add(1)
+		go func() {
+			defer n.wg.Done()
+			forEachReplacement(ctx, n.prRepo, report, n.logger, func(pr *domain.PullRequest, reviewerIDs []string) {
+				n.notify(ctx, pr, reviewerIDs, true, now)
+			})
+		}()
+		return
+	}
+
+	var (
+		pr         *domain.PullRequest
+		reviewers  []string
+		reassigned bool
+	)
+	switch data := event.Data.(type) {
+	case domain.PREventData:
+		if event.Type != domain.EventReviewersAssigned {
+			return
+		}
+		pr, reviewers = data.PR, data.Added
+	case domain.ReviewerReassignedData:
+		pr, reviewers, reassigned = data.PR, []string{data.NewReviewerID}, true
+	default:
+		return
+	}
+	if pr == nil || len(reviewers) == 0 {
+		return
+	}
+
+	// The request context is canceled once the response is written
+	ctx = context.WithoutCancel(ctx)
+	now := event.OccurredAt
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		n.notify(ctx, pr, reviewers, reassigned, now)
+	}()
+}
+
+// Wait waits for emails that are still being sent
+func (n *EmailNotifier) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		n.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SendDigests emails the daily digest to every user it is due for at now and returns how many were sent
+// A digest is due once per local day of the user, from DigestHour on and outside quiet hours
+func (n *EmailNotifier) SendDigests(ctx context.Context, now time.Time) (int, error) {
+	recipients, err := n.userRepo.GetDigestRecipients(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get digest recipients: %w", err)
+	}
+
+	sent := 0
+	for _, recipient := range recipients {
+		if !n.digestDue(recipient, now) {
+			continue
+		}
+
+		reviews, err := n.prRepo.GetPendingReviewsWithSLA(ctx, recipient.ID)
+		if err != nil {
+			return sent, fmt.Errorf("failed to get pending reviews: %w", err)
+		}
+		if len(reviews) == 0 {
+			continue
+		}
+
+		subject := fmt.Sprintf("%d pending review(s)", len(reviews))
+		if err := n.send(ctx, *recipient.Email, subject, n.digestBody(recipient.User, reviews, now)); err != nil {
+			n.logger.Error("failed to send review digest",
+				slog.String("user_id", recipient.ID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if err := n.userRepo.SetLastDigestAt(ctx, recipient.ID, now); err != nil {
+			return sent, err
+		}
+		sent++
+
+		n.logger.Info("review digest sent",
+			slog.String("user_id", recipient.ID),
+			slog.Int("pending_reviews", len(reviews)),
+		)
+	}
+
+	return sent, nil
+}
+
+func (n *EmailNotifier) digestDue(recipient domain.DigestRecipient, now time.Time) bool {
+	local := now.In(recipient.Location())
+	if local.Hour() < n.opts.DigestHour || recipient.InQuietHours(now) {
+		return false
+	}
+	if recipient.LastDigestAt == nil {
+		return true
+	}
+
+	y, m, d := local.Date()
+	startOfDay := time.Date(y, m, d, 0, 0, 0, 0, local.Location())
+	return recipient.LastDigestAt.Before(startOfDay)
+}
+
+func (n *EmailNotifier) notify(ctx context.Context, pr *domain.PullRequest, reviewerIDs []string, reassigned bool, now time.Time) {
+	author, err := n.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		n.logger.Error("failed to get PR author for email notification",
+			slog.String("pr_id", pr.ID),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	for _, reviewerID := range reviewerIDs {
+		reviewer, err := n.userRepo.GetByID(ctx, reviewerID)
+		if err != nil {
+			n.logger.Error("failed to get reviewer for email notification",
+				slog.String("pr_id", pr.ID),
+				slog.String("reviewer_id", reviewerID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if !reviewer.AcceptsEmail() {
+			continue
+		}
+		if reviewer.InQuietHours(now) {
+			n.logger.Info("email notification skipped in quiet hours",
+				slog.String("pr_id", pr.ID),
+				slog.String("reviewer_id", reviewerID),
+			)
+			continue
+		}
+
+		verb := "assigned"
+		if reassigned {
+			verb = "reassigned"
+		}
+		subject := fmt.Sprintf("Review requested: %s", pr.Name)
+		body := fmt.Sprintf("Hi %s,\n\nyou were %s to review %q by %s.\n\n%s\n",
+			reviewer.Username, verb, pr.Name, author.Username, prLink(n.opts.PRLinkTemplate, pr.ID))
+
+		if err := n.send(ctx, *reviewer.Email, subject, body); err != nil {
+			n.logger.Error("failed to send email notification",
+				slog.String("pr_id", pr.ID),
+				slog.String("reviewer_id", reviewerID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+
+		n.logger.Info("email notification sent",
+			slog.String("pr_id", pr.ID),
+			slog.String("reviewer_id", reviewerID),
+		)
+	}
+}
+
+func (n *EmailNotifier) digestBody(user domain.User, reviews []domain.PendingReview, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Hi %s,\n\nthese pull requests are waiting for your review:\n\n", user.Username)
+	for _, review := range reviews {
+		fmt.Fprintf(&b, "- %q (%s) by %s, waiting %s", review.Name, review.ID, review.AuthorID, formatAge(review.Age(now)))
+		switch review.SLAStatus(now) {
+		case domain.SLAStatusOverdue:
+			fmt.Fprintf(&b, ", overdue (SLA %s)", formatAge(review.SLA))
+		case domain.SLAStatusOnTrack:
+			fmt.Fprintf(&b, ", due in %s", formatAge(review.SLA-review.Age(now)))
+		}
+		fmt.Fprintf(&b, "\n  %s\n", prLink(n.opts.PRLinkTemplate, review.ID))
+	}
+	return b.String()
+}
+
+// send delivers one plain text email over SMTP
+func (n *EmailNotifier) send(ctx context.Context, to, subject, body string) error {
+	msg, err := n.message(to, subject, body)
+	if err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{Timeout: n.opts.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(n.opts.Host, strconv.Itoa(n.opts.Port)))
+	if err != nil {
+		return err
+	}
+	if n.opts.Timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(n.opts.Timeout))
+	}
+
+	client, err := smtp.NewClient(conn, n.opts.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.opts.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+	if n.opts.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.opts.Username, n.opts.Password, n.opts.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(n.from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// message builds an RFC 5322 message with a quoted-printable UTF-8 body
+func (n *EmailNotifier) message(to, subject, body string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", n.from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// formatAge renders a duration in days, hours and minutes, e.g. "2d 3h" or "45m"
+func formatAge(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < 0 {
+		d = 0
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
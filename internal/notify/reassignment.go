@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"context"
+	"log/slog"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/repository"
+)
+
+// reassignmentOf returns the bulk reassignment carried by user.deactivated, user.transferred
+// and team.deactivated, or nil for other events
+func reassignmentOf(data any) *domain.ReassignmentReport {
+	switch data := data.(type) {
+	case domain.UserDeactivatedData:
+		return data.Reassignment
+	case domain.UserTransferredData:
+		return data.Reassignment
+	case domain.TeamDeactivatedData:
+		return data.Reassignment
+	}
+	return nil
+}
+
+// forEachReplacement calls fn with every PR that got replacement reviewers in the report
+// and those reviewers, in report order; bulk reassignments carry only IDs, so the PRs are loaded here
+func forEachReplacement(
+	ctx context.Context,
+	prRepo repository.PullRequestRepository,
+	report *domain.ReassignmentReport,
+	logger *slog.Logger,
+	fn func(pr *domain.PullRequest, reviewerIDs []string),
+) {
+	var prIDs []string
+	added := make(map[string][]string)
+	for _, item := range report.Reassigned {
+		if _, ok := added[item.PullRequestID]; !ok {
+			prIDs = append(prIDs, item.PullRequestID)
+		}
+		added[item.PullRequestID] = append(added[item.PullRequestID], item.NewReviewerID)
+	}
+
+	for _, prID := range prIDs {
+		pr, err := prRepo.GetByID(ctx, prID)
+		if err != nil {
+			logger.Error("failed to get reassigned PR for notification",
+				slog.String("pr_id", prID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		fn(pr, added[prID])
+	}
+}
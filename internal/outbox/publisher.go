@@ -0,0 +1,103 @@
+// Package outbox publishes events written to the outbox table by the repositories
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"test_avito/internal/domain"
+)
+
+// Publishers (configured via OUTBOX_PUBLISHER)
+const (
+	PublisherLog  = "log"
+	PublisherHTTP = "http"
+)
+
+// Headers set on every request of HTTPPublisher
+const (
+	EventIDHeader   = "X-Event-Id"
+	EventTypeHeader = "X-Event-Type"
+)
+
+// Publisher hands an outbox event to the outside world
+// Delivery is at least once: a returned error makes the relay retry the event later,
+// so consumers should deduplicate by EventID
+type Publisher interface {
+	Publish(ctx context.Context, event domain.OutboxEvent) error
+}
+
+// NewPublisher creates a publisher of the given kind
+// url and timeout are used by the http publisher only
+func NewPublisher(kind, url string, timeout time.Duration, logger *slog.Logger) (Publisher, error) {
+	switch kind {
+	case PublisherLog:
+		return NewLogPublisher(logger), nil
+	case PublisherHTTP:
+		if url == "" {
+			return nil, fmt.Errorf("outbox http publisher requires a url")
+		}
+		return NewHTTPPublisher(url, timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown outbox publisher: %s", kind)
+	}
+}
+
+// LogPublisher writes events to the application log
+type LogPublisher struct {
+	logger *slog.Logger
+}
+
+func NewLogPublisher(logger *slog.Logger) *LogPublisher {
+	return &LogPublisher{logger: logger}
+}
+
+func (p *LogPublisher) Publish(_ context.Context, event domain.OutboxEvent) error {
+	p.logger.Info("outbox event",
+		slog.String("event_id", event.EventID),
+		slog.String("event_type", string(event.Type)),
+		slog.String("payload", string(event.Payload)),
+	)
+	return nil
+}
+
+// HTTPPublisher POSTs the event payload as JSON to a single URL
+// Any non-2xx response is treated as a failure
+type HTTPPublisher struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPPublisher(url string, timeout time.Duration) *HTTPPublisher {
+	return &HTTPPublisher{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *HTTPPublisher) Publish(ctx context.Context, event domain.OutboxEvent) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(event.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, event.EventID)
+	req.Header.Set(EventTypeHeader, string(event.Type))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
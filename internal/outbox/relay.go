@@ -0,0 +1,152 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/repository"
+)
+
+// maxBackoff caps the delay between two publish attempts of one event
+const maxBackoff = 5 * time.Minute
+
+// Options controls the relay
+type Options struct {
+	// BatchSize limits how many events one RunOnce pass publishes
+	BatchSize int
+	// BackoffBase is the delay after the first failed publish; it doubles after every next one
+	BackoffBase time.Duration
+	// Timeout bounds a single Publish
+	Timeout time.Duration
+	// Lease hides claimed events from other relays while they are being published;
+	// by default it covers a whole batch published one by one: (BatchSize+1)*Timeout
+	Lease time.Duration
+	// Retention is how long published events are kept; 0 keeps them forever
+	Retention time.Duration
+}
+
+// DefaultOptions are used for zero fields of Options (except Lease and Retention)
+var DefaultOptions = Options{
+	BatchSize:   100,
+	BackoffBase: time.Second,
+	Timeout:     5 * time.Second,
+}
+
+// Relay moves events from the outbox table to a Publisher
+type Relay struct {
+	repo      repository.OutboxRepository
+	publisher Publisher
+	opts      Options
+	logger    *slog.Logger
+}
+
+func NewRelay(
+	repo repository.OutboxRepository,
+	publisher Publisher,
+	opts Options,
+	logger *slog.Logger,
+) *Relay {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultOptions.BatchSize
+	}
+	if opts.BackoffBase <= 0 {
+		opts.BackoffBase = DefaultOptions.BackoffBase
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultOptions.Timeout
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = time.Duration(opts.BatchSize+1) * opts.Timeout
+	}
+	return &Relay{
+		repo:      repo,
+		publisher: publisher,
+		opts:      opts,
+		logger:    logger,
+	}
+}
+
+// RunOnce publishes events due at now in the order they were written
+// A failed event is retried after BackoffBase*2^(attempts-1) (at most 5 minutes) and never dropped;
+// events after it are still published, so consumers must not rely on strict ordering
+// Returns the number of published events
+func (r *Relay) RunOnce(ctx context.Context, now time.Time) (int, error) {
+	events, err := r.repo.ClaimEvents(ctx, now, now.Add(r.opts.Lease), r.opts.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	started := time.Now()
+	published := 0
+	for i := range events {
+		if err := ctx.Err(); err != nil {
+			return published, err
+		}
+		// An event that could be published after the lease runs out would be published twice;
+		// the rest of the batch is claimed again once the lease expires
+		if time.Since(started) > r.opts.Lease-2*r.opts.Timeout {
+			r.logger.Warn("outbox lease running out, leaving the rest of the batch",
+				slog.Int("left", len(events)-i),
+			)
+			break
+		}
+
+		event := events[i]
+		pubErr := r.publish(ctx, event)
+		if pubErr == nil {
+			if err := r.repo.MarkPublished(ctx, event.ID, time.Now()); err != nil {
+				r.logger.Error("failed to mark outbox event published",
+					slog.String("event_id", event.EventID),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+			published++
+			continue
+		}
+
+		attempts := event.Attempts + 1
+		r.logger.Warn("failed to publish outbox event",
+			slog.String("event_id", event.EventID),
+			slog.String("event_type", string(event.Type)),
+			slog.Int("attempts", attempts),
+			slog.String("error", pubErr.Error()),
+		)
+		if err := r.repo.ScheduleRetry(ctx, event.ID, attempts, now.Add(r.backoff(attempts)), pubErr.Error()); err != nil {
+			r.logger.Error("failed to schedule outbox retry",
+				slog.String("event_id", event.EventID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	if r.opts.Retention > 0 {
+		if _, err := r.repo.DeletePublished(ctx, now.Add(-r.opts.Retention)); err != nil {
+			return published, fmt.Errorf("failed to delete published outbox events: %w", err)
+		}
+	}
+
+	return published, nil
+}
+
+// publish hands the event to the publisher within Timeout
+func (r *Relay) publish(ctx context.Context, event domain.OutboxEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+	return r.publisher.Publish(ctx, event)
+}
+
+// backoff returns the delay before the next attempt after the given number of failures
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.opts.BackoffBase
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,93 @@
+// Запись и чтение истории членства в командах (таблица team_memberships)
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"test_avito/internal/database/db"
+	"test_avito/internal/domain"
+	"test_avito/pkg/logger"
+)
+
+// prepareMoves applies policy to the reviews the given users have on OPEN PRs before they leave their teams
+// A team lead can't be moved: the old team would keep escalating overdue reviews to them,
+// so the team policy has to name another lead first
+// Must be called with queries bound to the transaction of the move, with the users locked
+func prepareMoves(ctx context.Context, q *db.Queries, userIDs []string, policy domain.TransferReviewPolicy) (*domain.ReassignmentReport, error) {
+	if len(userIDs) == 0 {
+		return domain.NewReassignmentReport(), nil
+	}
+
+	led, err := q.GetTeamsLedBy(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get led teams: %w", err)
+	}
+	if len(led) > 0 {
+		return nil, fmt.Errorf("lead of team %s: %w", led[0], domain.ErrUserIsTeamLead)
+	}
+
+	switch policy {
+	case domain.TransferFailOnReviews:
+		counts, err := q.CountOpenReviewsByUsers(ctx, userIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count open reviews: %w", err)
+		}
+		if len(counts) > 0 {
+			return nil, fmt.Errorf("user %s: %w", counts[0].ReviewerID, domain.ErrHasOpenReviews)
+		}
+	case domain.TransferReassignReviews:
+		return reassignOpenReviews(ctx, q, userIDs, domain.ReasonTransfer)
+	}
+
+	return domain.NewReassignmentReport(), nil
+}
+
+// moveRejected reports whether err is prepareMoves refusing the move rather than a failure
+func moveRejected(err error) bool {
+	return errors.Is(err, domain.ErrUserIsTeamLead) || errors.Is(err, domain.ErrHasOpenReviews)
+}
+
+// writeTeamAddMoves records a team_add membership change for every user moved into teamName
+// from the matching entry of fromTeams, with the review policy applied to their OPEN reviews
+// q must be bound to the transaction of the upsert
+func writeTeamAddMoves(ctx context.Context, q *db.Queries, teamName string, policy domain.TransferReviewPolicy, userIDs, fromTeams []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	policyName := string(policy)
+	return writeMemberships(ctx, q, db.InsertTeamMembershipsParams{
+		ToTeam:       teamName,
+		Source:       domain.MembershipSourceTeamAdd,
+		ReviewPolicy: &policyName,
+		UserIds:      userIDs,
+		FromTeams:    fromTeams,
+	})
+}
+
+// writeMemberships appends membership changes with the actor and request ID taken from ctx
+func writeMemberships(ctx context.Context, q *db.Queries, arg db.InsertTeamMembershipsParams) error {
+	arg.Actor = optionalString(domain.ActorFromContext(ctx))
+	arg.RequestID = optionalString(logger.RequestIDFromContext(ctx))
+
+	if err := q.InsertTeamMemberships(ctx, arg); err != nil {
+		return fmt.Errorf("failed to write membership history: %w", err)
+	}
+	return nil
+}
+
+func membershipFromDB(row db.TeamMembership) domain.MembershipChange {
+	return domain.MembershipChange{
+		ID:           row.ID,
+		UserID:       row.UserID,
+		FromTeam:     row.FromTeam,
+		ToTeam:       row.ToTeam,
+		Source:       row.Source,
+		ReviewPolicy: domain.TransferReviewPolicy(stringValue(row.ReviewPolicy)),
+		Actor:        stringValue(row.Actor),
+		RequestID:    stringValue(row.RequestID),
+		CreatedAt:    row.CreatedAt.Time,
+	}
+}
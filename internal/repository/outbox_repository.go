@@ -0,0 +1,121 @@
+// Имплементация репозитория для работы с transactional outbox в базе данных postgresql
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"test_avito/internal/database/db"
+	"test_avito/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OutboxRepositoryImpl struct {
+	queries *db.Queries
+	pool    *pgxpool.Pool
+	logger  *slog.Logger
+}
+
+func NewOutboxRepository(pool *pgxpool.Pool, logger *slog.Logger) *OutboxRepositoryImpl {
+	return &OutboxRepositoryImpl{
+		queries: db.New(pool),
+		pool:    pool,
+		logger:  logger,
+	}
+}
+
+// ClaimEvents takes up to limit unpublished events due at now and hides them from other relays until leaseUntil
+// UPDATE ... RETURNING does not keep the order of the subquery, so the events are sorted by id here
+func (r *OutboxRepositoryImpl) ClaimEvents(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.OutboxEvent, error) {
+	rows, err := r.queries.ClaimOutboxEvents(ctx, db.ClaimOutboxEventsParams{
+		Now:        pgtype.Timestamptz{Time: now, Valid: true},
+		MaxRows:    int32(limit), // #nosec G115 -- batch size comes from config
+		LeaseUntil: pgtype.Timestamptz{Time: leaseUntil, Valid: true},
+	})
+	if err != nil {
+		r.logger.Error("failed to claim outbox events", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	events := make([]domain.OutboxEvent, len(rows))
+	for i, row := range rows {
+		events[i] = domain.OutboxEvent{
+			ID:        row.ID,
+			EventID:   row.EventID,
+			Type:      domain.EventType(row.EventType),
+			Payload:   row.Payload,
+			Attempts:  int(row.Attempts),
+			CreatedAt: row.CreatedAt.Time,
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	return events, nil
+}
+
+// MarkPublished records that the event was handed to the publisher
+func (r *OutboxRepositoryImpl) MarkPublished(ctx context.Context, id int64, at time.Time) error {
+	err := r.queries.MarkOutboxPublished(ctx, db.MarkOutboxPublishedParams{
+		ID:          id,
+		PublishedAt: pgtype.Timestamptz{Time: at, Valid: true},
+	})
+	if err != nil {
+		r.logger.Error("failed to mark outbox event published",
+			slog.Int64("outbox_id", id),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to mark outbox event published: %w", err)
+	}
+	return nil
+}
+
+// ScheduleRetry records a failed publish attempt and when to try again
+func (r *OutboxRepositoryImpl) ScheduleRetry(ctx context.Context, id int64, attempts int, nextAttemptAt time.Time, lastError string) error {
+	err := r.queries.ScheduleOutboxRetry(ctx, db.ScheduleOutboxRetryParams{
+		ID:            id,
+		Attempts:      int32(attempts), // #nosec G115 -- attempt counter
+		NextAttemptAt: pgtype.Timestamptz{Time: nextAttemptAt, Valid: true},
+		LastError:     &lastError,
+	})
+	if err != nil {
+		r.logger.Error("failed to schedule outbox retry",
+			slog.Int64("outbox_id", id),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to schedule outbox retry: %w", err)
+	}
+	return nil
+}
+
+// DeletePublished removes events published before the given time
+func (r *OutboxRepositoryImpl) DeletePublished(ctx context.Context, before time.Time) (int, error) {
+	rows, err := r.queries.DeletePublishedOutboxEvents(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		r.logger.Error("failed to delete published outbox events", slog.String("error", err.Error()))
+		return 0, fmt.Errorf("failed to delete published outbox events: %w", err)
+	}
+	return int(rows), nil
+}
+
+// writeOutbox stores the event through q, which must be bound to the transaction of the change it describes
+func writeOutbox(ctx context.Context, q *db.Queries, event domain.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox event: %w", err)
+	}
+
+	err = q.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventID:   event.ID,
+		EventType: string(event.Type),
+		Payload:   payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write outbox event: %w", err)
+	}
+	return nil
+}
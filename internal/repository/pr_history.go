@@ -0,0 +1,92 @@
+// Запись и чтение истории PR (таблица pr_events)
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"test_avito/internal/database/db"
+	"test_avito/internal/domain"
+	"test_avito/pkg/logger"
+)
+
+// writeHistory appends entries to the PR history with the actor and request ID taken from ctx
+// All entries go in one query; q must be bound to the transaction of the change they describe
+func writeHistory(ctx context.Context, q *db.Queries, entries ...domain.PRHistoryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	arg := db.InsertPREventsParams{
+		Actor:          optionalString(domain.ActorFromContext(ctx)),
+		RequestID:      optionalString(logger.RequestIDFromContext(ctx)),
+		PullRequestIds: make([]string, len(entries)),
+		EventTypes:     make([]string, len(entries)),
+		ReviewerIds:    make([]string, len(entries)),
+		OldReviewerIds: make([]string, len(entries)),
+		Reasons:        make([]string, len(entries)),
+		FromStatuses:   make([]string, len(entries)),
+		ToStatuses:     make([]string, len(entries)),
+		ReviewStates:   make([]string, len(entries)),
+	}
+	for i, entry := range entries {
+		arg.PullRequestIds[i] = entry.PullRequestID
+		arg.EventTypes[i] = string(entry.Type)
+		arg.ReviewerIds[i] = entry.ReviewerID
+		arg.OldReviewerIds[i] = entry.OldReviewerID
+		arg.Reasons[i] = entry.Reason
+		arg.FromStatuses[i] = string(entry.FromStatus)
+		arg.ToStatuses[i] = string(entry.ToStatus)
+		arg.ReviewStates[i] = string(entry.ReviewState)
+	}
+
+	if err := q.InsertPREvents(ctx, arg); err != nil {
+		return fmt.Errorf("failed to write PR history: %w", err)
+	}
+	return nil
+}
+
+// assignedEntries returns a reviewer_assigned entry for each reviewer
+func assignedEntries(prID string, reviewerIDs []string, reason string) []domain.PRHistoryEntry {
+	entries := make([]domain.PRHistoryEntry, len(reviewerIDs))
+	for i, reviewerID := range reviewerIDs {
+		entries[i] = domain.PRHistoryEntry{
+			PullRequestID: prID,
+			Type:          domain.PRHistoryReviewerAssigned,
+			ReviewerID:    reviewerID,
+			Reason:        reason,
+		}
+	}
+	return entries
+}
+
+func historyEntryFromDB(row db.PrEvent) domain.PRHistoryEntry {
+	return domain.PRHistoryEntry{
+		ID:            row.ID,
+		PullRequestID: row.PullRequestID,
+		Type:          domain.PRHistoryType(row.EventType),
+		Actor:         stringValue(row.Actor),
+		RequestID:     stringValue(row.RequestID),
+		ReviewerID:    stringValue(row.ReviewerID),
+		OldReviewerID: stringValue(row.OldReviewerID),
+		Reason:        stringValue(row.Reason),
+		FromStatus:    domain.PRStatus(stringValue(row.FromStatus)),
+		ToStatus:      domain.PRStatus(stringValue(row.ToStatus)),
+		ReviewState:   domain.ReviewState(stringValue(row.ReviewState)),
+		CreatedAt:     row.CreatedAt.Time,
+	}
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
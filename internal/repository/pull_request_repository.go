@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"test_avito/internal/database/db"
@@ -31,8 +33,9 @@ func NewPullRequestRepository(pool *pgxpool.Pool, logger *slog.Logger) *PullRequ
 	}
 }
 
-// Create creates a new pull request with reviewers
-func (r *PullRequestRepositoryImpl) Create(ctx context.Context, pr *domain.PullRequest) error {
+// Create creates a new pull request with reviewers and its pr.created outbox event in a transaction
+// The creation and each assignment are recorded in the PR history with reason
+func (r *PullRequestRepositoryImpl) Create(ctx context.Context, pr *domain.PullRequest, reason string) error {
 	txCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
@@ -55,7 +58,8 @@ func (r *PullRequestRepositoryImpl) Create(ctx context.Context, pr *domain.PullR
 
 	qtx := r.queries.WithTx(tx)
 
-	createdAt := pgtype.Timestamptz{Valid: false}
+	// created_at обязателен: по нему постранично выдаются списки PR
+	createdAt := pgtype.Timestamptz{Time: time.Now(), Valid: true}
 	if pr.CreatedAt != nil {
 		createdAt = pgtype.Timestamptz{Time: *pr.CreatedAt, Valid: true}
 	}
@@ -100,6 +104,28 @@ func (r *PullRequestRepositoryImpl) Create(ctx context.Context, pr *domain.PullR
 		}
 	}
 
+	event := domain.NewEvent(domain.EventPRCreated, domain.PREventData{PR: pr})
+	if err := writeOutbox(txCtx, qtx, event); err != nil {
+		r.logger.Error("failed to write outbox event in transaction",
+			slog.String("pr_id", pr.ID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	history := append([]domain.PRHistoryEntry{{
+		PullRequestID: pr.ID,
+		Type:          domain.PRHistoryCreated,
+		ToStatus:      pr.Status,
+	}}, assignedEntries(pr.ID, reviewers, reason)...)
+	if err := writeHistory(txCtx, qtx, history...); err != nil {
+		r.logger.Error("failed to write PR history in transaction",
+			slog.String("pr_id", pr.ID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
 	if err := tx.Commit(txCtx); err != nil {
 		r.logger.Error("failed to commit transaction",
 			slog.String("pr_id", pr.ID),
@@ -117,39 +143,14 @@ func (r *PullRequestRepositoryImpl) Create(ctx context.Context, pr *domain.PullR
 
 // GetByID retrieves a pull request by ID with reviewers
 func (r *PullRequestRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.PullRequest, error) {
-	dbPR, err := r.queries.GetPullRequestByID(ctx, id)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, domain.ErrPRNotFound
-		}
+	pr, err := loadPullRequest(ctx, r.queries, id)
+	if err != nil && !errors.Is(err, domain.ErrPRNotFound) {
 		r.logger.Error("failed to get PR",
 			slog.String("pr_id", id),
 			slog.String("error", err.Error()),
 		)
-		return nil, fmt.Errorf("failed to get PR: %w", err)
 	}
-
-	reviewerIDs, err := r.queries.GetReviewersByPRID(ctx, id)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get reviewers: %w", err)
-	}
-
-	pr := &domain.PullRequest{
-		ID:                dbPR.ID,
-		Name:              dbPR.Name,
-		AuthorID:          dbPR.AuthorID,
-		Status:            domain.PRStatus(dbPR.Status),
-		AssignedReviewers: reviewerIDs,
-	}
-
-	if dbPR.CreatedAt.Valid {
-		pr.CreatedAt = &dbPR.CreatedAt.Time
-	}
-	if dbPR.MergedAt.Valid {
-		pr.MergedAt = &dbPR.MergedAt.Time
-	}
-
-	return pr, nil
+	return pr, err
 }
 
 // Update updates an existing pull request
@@ -178,42 +179,148 @@ func (r *PullRequestRepositoryImpl) Update(ctx context.Context, pr *domain.PullR
 	return nil
 }
 
-// Merge marks a PR as merged (idempotent - just one UPDATE)
-func (r *PullRequestRepositoryImpl) Merge(ctx context.Context, id string) (*domain.PullRequest, error) {
-	now := time.Now()
-	mergedPR, err := r.queries.MergePullRequest(ctx, db.MergePullRequestParams{
-		ID:       id,
-		MergedAt: pgtype.Timestamptz{Time: now, Valid: true},
+// Transition persists a lifecycle transition of the PR (status and closed_at) in a transaction
+// The update only applies if the stored status is still from; reviewerIDs are assigned in the same transaction
+// The status change and assignments are recorded in the PR history, assignments with reason
+func (r *PullRequestRepositoryImpl) Transition(ctx context.Context, pr *domain.PullRequest, from domain.PRStatus, reviewerIDs []string, reason string) error {
+	txCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(txCtx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.Background())
+			r.logger.Error("panic in Transition transaction",
+				slog.String("pr_id", pr.ID),
+				slog.Any("panic", p),
+			)
+			panic(p)
+		}
+		_ = tx.Rollback(context.Background())
+	}()
+
+	qtx := r.queries.WithTx(tx)
+
+	closedAt := pgtype.Timestamptz{Valid: false}
+	if pr.ClosedAt != nil {
+		closedAt = pgtype.Timestamptz{Time: *pr.ClosedAt, Valid: true}
+	}
+
+	rows, err := qtx.TransitionPullRequest(txCtx, db.TransitionPullRequestParams{
+		Status:     string(pr.Status),
+		ClosedAt:   closedAt,
+		ID:         pr.ID,
+		FromStatus: string(from),
 	})
+	if err != nil {
+		r.logger.Error("failed to update PR status",
+			slog.String("pr_id", pr.ID),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to update PR status: %w", err)
+	}
+	if rows == 0 {
+		// Status changed concurrently
+		return domain.ErrInvalidTransition
+	}
+
+	sortedReviewers := make([]string, len(reviewerIDs))
+	copy(sortedReviewers, reviewerIDs)
+	sort.Strings(sortedReviewers)
+
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	for _, reviewerID := range sortedReviewers {
+		err = qtx.AddReviewer(txCtx, db.AddReviewerParams{
+			PullRequestID: pr.ID,
+			ReviewerID:    reviewerID,
+			AssignedAt:    now,
+		})
+		if err != nil {
+			r.logger.Error("failed to add reviewer in transaction",
+				slog.String("pr_id", pr.ID),
+				slog.String("reviewer_id", reviewerID),
+				slog.String("error", err.Error()),
+			)
+			return fmt.Errorf("failed to add reviewer: %w", err)
+		}
+	}
 
+	history := append([]domain.PRHistoryEntry{{
+		PullRequestID: pr.ID,
+		Type:          domain.PRHistoryStatusChanged,
+		FromStatus:    from,
+		ToStatus:      pr.Status,
+	}}, assignedEntries(pr.ID, sortedReviewers, reason)...)
+	if err := writeHistory(txCtx, qtx, history...); err != nil {
+		r.logger.Error("failed to write PR history in transaction",
+			slog.String("pr_id", pr.ID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	if err := tx.Commit(txCtx); err != nil {
+		r.logger.Error("failed to commit transaction",
+			slog.String("pr_id", pr.ID),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.Info("PR status updated in transaction",
+		slog.String("pr_id", pr.ID),
+		slog.String("from", string(from)),
+		slog.String("to", string(pr.Status)),
+		slog.Int("reviewers_added", len(reviewerIDs)),
+	)
+	return nil
+}
+
+// Merge marks a PR as merged (idempotent - just one UPDATE) and writes pr.merged to the outbox
+// and the PR history in a transaction
+// force is recorded on the PR; an already merged PR keeps its original flag and no event is written
+func (r *PullRequestRepositoryImpl) Merge(ctx context.Context, id string, force bool) (*domain.PullRequest, error) {
+	txCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(txCtx)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			dbPR, getErr := r.queries.GetPullRequestByID(ctx, id)
-			if getErr != nil {
-				if errors.Is(getErr, pgx.ErrNoRows) {
-					return nil, domain.ErrPRNotFound
-				}
-				return nil, fmt.Errorf("failed to get PR: %w", getErr)
-			}
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
 
-			reviewerIDs, err := r.queries.GetReviewersByPRID(ctx, id)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get reviewers: %w", err)
-			}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.Background())
+			r.logger.Error("panic in Merge transaction",
+				slog.String("pr_id", id),
+				slog.Any("panic", p),
+			)
+			panic(p)
+		}
+		_ = tx.Rollback(context.Background())
+	}()
 
-			pr := &domain.PullRequest{
-				ID:                dbPR.ID,
-				Name:              dbPR.Name,
-				AuthorID:          dbPR.AuthorID,
-				Status:            domain.PRStatus(dbPR.Status),
-				AssignedReviewers: reviewerIDs,
-			}
+	qtx := r.queries.WithTx(tx)
 
-			if dbPR.CreatedAt.Valid {
-				pr.CreatedAt = &dbPR.CreatedAt.Time
+	_, err = qtx.MergePullRequest(txCtx, db.MergePullRequestParams{
+		ID:          id,
+		MergedAt:    pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		ForceMerged: force,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			pr, getErr := loadPullRequest(txCtx, qtx, id)
+			if getErr != nil {
+				return nil, getErr
 			}
-			if dbPR.MergedAt.Valid {
-				pr.MergedAt = &dbPR.MergedAt.Time
+
+			// Only OPEN PRs are merged by the UPDATE; DRAFT and CLOSED ones can't be merged
+			if !pr.IsMerged() {
+				return nil, domain.ErrInvalidTransition
 			}
 
 			r.logger.Info("PR already merged (idempotent)", slog.String("pr_id", id))
@@ -227,33 +334,85 @@ func (r *PullRequestRepositoryImpl) Merge(ctx context.Context, id string) (*doma
 		return nil, fmt.Errorf("failed to merge PR: %w", err)
 	}
 
-	reviewerIDs, err := r.queries.GetReviewersByPRID(ctx, id)
+	pr, err := loadPullRequest(txCtx, qtx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reviewers: %w", err)
+		return nil, err
 	}
 
-	pr := &domain.PullRequest{
-		ID:                mergedPR.ID,
-		Name:              mergedPR.Name,
-		AuthorID:          mergedPR.AuthorID,
-		Status:            domain.PRStatus(mergedPR.Status),
-		AssignedReviewers: reviewerIDs,
+	if err := writeOutbox(txCtx, qtx, domain.NewEvent(domain.EventPRMerged, domain.PREventData{PR: pr})); err != nil {
+		r.logger.Error("failed to write outbox event in transaction",
+			slog.String("pr_id", id),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
 	}
 
-	if mergedPR.CreatedAt.Valid {
-		pr.CreatedAt = &mergedPR.CreatedAt.Time
+	merged := domain.PRHistoryEntry{
+		PullRequestID: id,
+		Type:          domain.PRHistoryMerged,
+		FromStatus:    domain.PRStatusOpen,
+		ToStatus:      domain.PRStatusMerged,
 	}
-	if mergedPR.MergedAt.Valid {
-		pr.MergedAt = &mergedPR.MergedAt.Time
+	if force {
+		merged.Reason = domain.ReasonForceMerge
+	}
+	if err := writeHistory(txCtx, qtx, merged); err != nil {
+		r.logger.Error("failed to write PR history in transaction",
+			slog.String("pr_id", id),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	if err := tx.Commit(txCtx); err != nil {
+		r.logger.Error("failed to commit transaction",
+			slog.String("pr_id", id),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	r.logger.Info("PR merged", slog.String("pr_id", id))
 	return pr, nil
 }
 
-// AddReviewer adds a reviewer to a PR
-func (r *PullRequestRepositoryImpl) AddReviewer(ctx context.Context, prID, reviewerID string) error {
-	err := r.queries.AddReviewer(ctx, db.AddReviewerParams{
+// AddReviewer adds a reviewer to an OPEN PR and records the assignment with reason in the PR history in a transaction
+// The PR row is locked while its reviewers are counted, so concurrent additions can't exceed maxReviewers
+func (r *PullRequestRepositoryImpl) AddReviewer(ctx context.Context, prID, reviewerID, reason string, maxReviewers int) error {
+	txCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(txCtx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.Background())
+			r.logger.Error("panic in AddReviewer transaction",
+				slog.String("pr_id", prID),
+				slog.Any("panic", p),
+			)
+			panic(p)
+		}
+		_ = tx.Rollback(context.Background())
+	}()
+
+	qtx := r.queries.WithTx(tx)
+
+	reviewers, err := lockOpenReviewers(txCtx, qtx, prID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(reviewers, reviewerID) {
+		return domain.ErrAlreadyReviewer
+	}
+	if len(reviewers) >= maxReviewers {
+		return domain.ErrTooManyReviewers
+	}
+
+	err = qtx.AddReviewer(txCtx, db.AddReviewerParams{
 		PullRequestID: prID,
 		ReviewerID:    reviewerID,
 		AssignedAt:    pgtype.Timestamptz{Time: time.Now(), Valid: true},
@@ -267,6 +426,22 @@ func (r *PullRequestRepositoryImpl) AddReviewer(ctx context.Context, prID, revie
 		return fmt.Errorf("failed to add reviewer: %w", err)
 	}
 
+	if err := writeHistory(txCtx, qtx, assignedEntries(prID, []string{reviewerID}, reason)...); err != nil {
+		r.logger.Error("failed to write PR history in transaction",
+			slog.String("pr_id", prID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	if err := tx.Commit(txCtx); err != nil {
+		r.logger.Error("failed to commit transaction",
+			slog.String("pr_id", prID),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	r.logger.Info("reviewer added",
 		slog.String("pr_id", prID),
 		slog.String("reviewer_id", reviewerID),
@@ -274,9 +449,43 @@ func (r *PullRequestRepositoryImpl) AddReviewer(ctx context.Context, prID, revie
 	return nil
 }
 
-// RemoveReviewer removes a reviewer from a PR
-func (r *PullRequestRepositoryImpl) RemoveReviewer(ctx context.Context, prID, reviewerID string) error {
-	err := r.queries.RemoveReviewer(ctx, db.RemoveReviewerParams{
+// RemoveReviewer removes a reviewer from an OPEN PR and records the removal in the PR history in a transaction
+// The PR row is locked while its reviewers are counted, so concurrent removals can't go below minReviewers
+func (r *PullRequestRepositoryImpl) RemoveReviewer(ctx context.Context, prID, reviewerID string, minReviewers int) error {
+	txCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(txCtx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.Background())
+			r.logger.Error("panic in RemoveReviewer transaction",
+				slog.String("pr_id", prID),
+				slog.Any("panic", p),
+			)
+			panic(p)
+		}
+		_ = tx.Rollback(context.Background())
+	}()
+
+	qtx := r.queries.WithTx(tx)
+
+	reviewers, err := lockOpenReviewers(txCtx, qtx, prID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(reviewers, reviewerID) {
+		return domain.ErrReviewerNotFound
+	}
+	if len(reviewers)-1 < minReviewers {
+		return domain.ErrTooFewReviewers
+	}
+
+	err = qtx.RemoveReviewer(txCtx, db.RemoveReviewerParams{
 		PullRequestID: prID,
 		ReviewerID:    reviewerID,
 	})
@@ -289,6 +498,27 @@ func (r *PullRequestRepositoryImpl) RemoveReviewer(ctx context.Context, prID, re
 		return fmt.Errorf("failed to remove reviewer: %w", err)
 	}
 
+	removed := domain.PRHistoryEntry{
+		PullRequestID: prID,
+		Type:          domain.PRHistoryReviewerRemoved,
+		ReviewerID:    reviewerID,
+	}
+	if err := writeHistory(txCtx, qtx, removed); err != nil {
+		r.logger.Error("failed to write PR history in transaction",
+			slog.String("pr_id", prID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	if err := tx.Commit(txCtx); err != nil {
+		r.logger.Error("failed to commit transaction",
+			slog.String("pr_id", prID),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	r.logger.Info("reviewer removed",
 		slog.String("pr_id", prID),
 		slog.String("reviewer_id", reviewerID),
@@ -297,7 +527,9 @@ func (r *PullRequestRepositoryImpl) RemoveReviewer(ctx context.Context, prID, re
 }
 
 // ReassignReviewer replaces old reviewer with new one in a transaction
-func (r *PullRequestRepositoryImpl) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error {
+// The PR is locked and must be OPEN; old reviewer must be assigned (ErrReviewerNotFound), new one must not (ErrAlreadyReviewer)
+// The pr.reviewer_reassigned outbox event and the PR history entry with reason are written in the same transaction
+func (r *PullRequestRepositoryImpl) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, reason string) error {
 	txCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
@@ -324,6 +556,18 @@ func (r *PullRequestRepositoryImpl) ReassignReviewer(ctx context.Context, prID,
 
 	qtx := r.queries.WithTx(tx)
 
+	// The lock keeps a concurrent merge, close or reviewer change from interleaving with the swap
+	reviewers, err := lockOpenReviewers(txCtx, qtx, prID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(reviewers, oldReviewerID) {
+		return domain.ErrReviewerNotFound
+	}
+	if slices.Contains(reviewers, newReviewerID) {
+		return domain.ErrAlreadyReviewer
+	}
+
 	err = qtx.RemoveReviewer(txCtx, db.RemoveReviewerParams{
 		PullRequestID: prID,
 		ReviewerID:    oldReviewerID,
@@ -351,6 +595,39 @@ func (r *PullRequestRepositoryImpl) ReassignReviewer(ctx context.Context, prID,
 		return fmt.Errorf("failed to add reviewer: %w", err)
 	}
 
+	pr, err := loadPullRequest(txCtx, qtx, prID)
+	if err != nil {
+		return err
+	}
+
+	event := domain.NewEvent(domain.EventReviewerReassigned, domain.ReviewerReassignedData{
+		PR:            pr,
+		OldReviewerID: oldReviewerID,
+		NewReviewerID: newReviewerID,
+	})
+	if err := writeOutbox(txCtx, qtx, event); err != nil {
+		r.logger.Error("failed to write outbox event in transaction",
+			slog.String("pr_id", prID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	reassigned := domain.PRHistoryEntry{
+		PullRequestID: prID,
+		Type:          domain.PRHistoryReviewerReassigned,
+		ReviewerID:    newReviewerID,
+		OldReviewerID: oldReviewerID,
+		Reason:        reason,
+	}
+	if err := writeHistory(txCtx, qtx, reassigned); err != nil {
+		r.logger.Error("failed to write PR history in transaction",
+			slog.String("pr_id", prID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
 	if err := tx.Commit(txCtx); err != nil {
 		r.logger.Error("failed to commit transaction",
 			slog.String("pr_id", prID),
@@ -369,7 +646,8 @@ func (r *PullRequestRepositoryImpl) ReassignReviewer(ctx context.Context, prID,
 
 // AssignReviewers assigns reviewers to an existing PR in a transaction
 // Returns error if PR already has any reviewers assigned
-func (r *PullRequestRepositoryImpl) AssignReviewers(ctx context.Context, prID string, reviewerIDs []string) error {
+// The pr.reviewers_assigned outbox event and the PR history entries with reason are written in the same transaction
+func (r *PullRequestRepositoryImpl) AssignReviewers(ctx context.Context, prID string, reviewerIDs []string, reason string) error {
 	// Add timeout for transaction
 	txCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -397,13 +675,13 @@ func (r *PullRequestRepositoryImpl) AssignReviewers(ctx context.Context, prID st
 
 	qtx := r.queries.WithTx(tx)
 
-	existingReviewers, err := qtx.GetReviewersByPRID(txCtx, prID)
+	existingReviewers, err := lockOpenReviewers(txCtx, qtx, prID)
 	if err != nil {
 		r.logger.Error("failed to check existing reviewers",
 			slog.String("pr_id", prID),
 			slog.String("error", err.Error()),
 		)
-		return fmt.Errorf("failed to check existing reviewers: %w", err)
+		return err
 	}
 
 	if len(existingReviewers) > 0 {
@@ -435,6 +713,28 @@ func (r *PullRequestRepositoryImpl) AssignReviewers(ctx context.Context, prID st
 		}
 	}
 
+	pr, err := loadPullRequest(txCtx, qtx, prID)
+	if err != nil {
+		return err
+	}
+
+	event := domain.NewEvent(domain.EventReviewersAssigned, domain.PREventData{PR: pr, Added: sortedReviewers})
+	if err := writeOutbox(txCtx, qtx, event); err != nil {
+		r.logger.Error("failed to write outbox event in transaction",
+			slog.String("pr_id", prID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	if err := writeHistory(txCtx, qtx, assignedEntries(prID, sortedReviewers, reason)...); err != nil {
+		r.logger.Error("failed to write PR history in transaction",
+			slog.String("pr_id", prID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
 	if err := tx.Commit(txCtx); err != nil {
 		r.logger.Error("failed to commit transaction",
 			slog.String("pr_id", prID),
@@ -450,6 +750,29 @@ func (r *PullRequestRepositoryImpl) AssignReviewers(ctx context.Context, prID st
 	return nil
 }
 
+// lockOpenReviewers locks the PR row until the end of the transaction and returns its reviewers
+// Reviewers can only be changed on OPEN PRs (see domain.PullRequest.EnsureOpen)
+func lockOpenReviewers(ctx context.Context, qtx *db.Queries, prID string) ([]string, error) {
+	status, err := qtx.LockPullRequest(ctx, prID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPRNotFound
+		}
+		return nil, fmt.Errorf("failed to lock PR: %w", err)
+	}
+
+	pr := domain.PullRequest{ID: prID, Status: domain.PRStatus(status)}
+	if err := pr.EnsureOpen(); err != nil {
+		return nil, err
+	}
+
+	reviewers, err := qtx.GetReviewersByPRID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewers: %w", err)
+	}
+	return reviewers, nil
+}
+
 // GetReviewersByPRID gets all reviewers for a PR
 func (r *PullRequestRepositoryImpl) GetReviewersByPRID(ctx context.Context, prID string) ([]string, error) {
 	reviewers, err := r.queries.GetReviewersByPRID(ctx, prID)
@@ -488,6 +811,345 @@ func (r *PullRequestRepositoryImpl) GetPRsByReviewer(ctx context.Context, review
 	return prs, nil
 }
 
+// GetPendingPRsByReviewer gets OPEN PRs where the reviewer has not submitted a review yet
+func (r *PullRequestRepositoryImpl) GetPendingPRsByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
+	dbPRs, err := r.queries.GetPendingPRsByReviewer(ctx, reviewerID)
+	if err != nil {
+		r.logger.Error("failed to get pending PRs by reviewer",
+			slog.String("reviewer_id", reviewerID),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to get pending PRs by reviewer: %w", err)
+	}
+
+	prs := make([]domain.PullRequestShort, len(dbPRs))
+	for i, dbPR := range dbPRs {
+		prs[i] = domain.PullRequestShort{
+			ID:       dbPR.ID,
+			Name:     dbPR.Name,
+			AuthorID: dbPR.AuthorID,
+			Status:   domain.PRStatus(dbPR.Status),
+		}
+	}
+
+	return prs, nil
+}
+
+// ListPRsByReviewer gets one page of PRs assigned to a reviewer, ordered by (created_at, id)
+// One extra row is read to tell whether there is a next page
+func (r *PullRequestRepositoryImpl) ListPRsByReviewer(ctx context.Context, filter domain.ReviewerPRFilter) (*domain.PRPage, error) {
+	arg := db.ListPRsByReviewerParams{
+		ReviewerID:  filter.ReviewerID,
+		Status:      optionalString(string(filter.Status)),
+		PendingOnly: filter.PendingOnly,
+		TeamName:    optionalString(filter.TeamName),
+		MaxRows:     int32(filter.Limit + 1),
+	}
+	if filter.CreatedAfter != nil {
+		arg.CreatedAfter = pgtype.Timestamptz{Time: *filter.CreatedAfter, Valid: true}
+	}
+	if filter.CreatedBefore != nil {
+		arg.CreatedBefore = pgtype.Timestamptz{Time: *filter.CreatedBefore, Valid: true}
+	}
+	if filter.After != nil {
+		arg.AfterCreatedAt = pgtype.Timestamptz{Time: filter.After.CreatedAt, Valid: true}
+		arg.AfterID = &filter.After.ID
+	}
+
+	var rows []db.ListPRsByReviewerRow
+	var err error
+	if filter.Sort == domain.PRSortOldest {
+		var ascRows []db.ListPRsByReviewerAscRow
+		ascRows, err = r.queries.ListPRsByReviewerAsc(ctx, db.ListPRsByReviewerAscParams(arg))
+		for _, row := range ascRows {
+			rows = append(rows, db.ListPRsByReviewerRow(row))
+		}
+	} else {
+		rows, err = r.queries.ListPRsByReviewer(ctx, arg)
+	}
+	if err != nil {
+		r.logger.Error("failed to list PRs by reviewer",
+			slog.String("reviewer_id", filter.ReviewerID),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to list PRs by reviewer: %w", err)
+	}
+
+	page := &domain.PRPage{PullRequests: make([]domain.PullRequestShort, 0, len(rows))}
+	if len(rows) > filter.Limit {
+		rows = rows[:filter.Limit]
+		last := rows[len(rows)-1]
+		page.NextCursor = domain.PRCursor{
+			CreatedAt: last.CreatedAt.Time,
+			ID:        last.ID,
+			Sort:      filter.Sort,
+		}.Encode()
+	}
+	for _, row := range rows {
+		page.PullRequests = append(page.PullRequests, domain.PullRequestShort{
+			ID:       row.ID,
+			Name:     row.Name,
+			AuthorID: row.AuthorID,
+			Status:   domain.PRStatus(row.Status),
+		})
+	}
+
+	return page, nil
+}
+
+// List gets one page of PRs matching the filter, ordered by (created_at, id)
+// One extra row is read to tell whether there is a next page
+func (r *PullRequestRepositoryImpl) List(ctx context.Context, filter domain.PRFilter) (*domain.PRSummaryPage, error) {
+	arg := db.ListPullRequestsParams{
+		AuthorID:    optionalString(filter.AuthorID),
+		TeamName:    optionalString(filter.TeamName),
+		Status:      optionalString(string(filter.Status)),
+		ReviewerID:  optionalString(filter.ReviewerID),
+		NoReviewers: filter.NoReviewers,
+		Search:      optionalString(filter.Search),
+		MaxRows:     int32(filter.Limit + 1),
+	}
+	if filter.NameContains != "" {
+		pattern := "%" + likeEscaper.Replace(filter.NameContains) + "%"
+		arg.NamePattern = &pattern
+	}
+	if filter.CreatedAfter != nil {
+		arg.CreatedAfter = pgtype.Timestamptz{Time: *filter.CreatedAfter, Valid: true}
+	}
+	if filter.CreatedBefore != nil {
+		arg.CreatedBefore = pgtype.Timestamptz{Time: *filter.CreatedBefore, Valid: true}
+	}
+	if filter.After != nil {
+		arg.AfterCreatedAt = pgtype.Timestamptz{Time: filter.After.CreatedAt, Valid: true}
+		arg.AfterID = &filter.After.ID
+	}
+
+	var rows []db.ListPullRequestsRow
+	var err error
+	if filter.Sort == domain.PRSortOldest {
+		var ascRows []db.ListPullRequestsAscRow
+		ascRows, err = r.queries.ListPullRequestsAsc(ctx, db.ListPullRequestsAscParams(arg))
+		for _, row := range ascRows {
+			rows = append(rows, db.ListPullRequestsRow(row))
+		}
+	} else {
+		rows, err = r.queries.ListPullRequests(ctx, arg)
+	}
+	if err != nil {
+		r.logger.Error("failed to list PRs",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to list PRs: %w", err)
+	}
+
+	page := &domain.PRSummaryPage{PullRequests: make([]domain.PRSummary, 0, len(rows))}
+	if len(rows) > filter.Limit {
+		rows = rows[:filter.Limit]
+		last := rows[len(rows)-1]
+		page.NextCursor = domain.PRCursor{
+			CreatedAt: last.CreatedAt.Time,
+			ID:        last.ID,
+			Sort:      filter.Sort,
+		}.Encode()
+	}
+	for _, row := range rows {
+		pr := domain.PRSummary{
+			PullRequestShort: domain.PullRequestShort{
+				ID:       row.ID,
+				Name:     row.Name,
+				AuthorID: row.AuthorID,
+				Status:   domain.PRStatus(row.Status),
+			},
+			TeamName:          row.TeamName,
+			AssignedReviewers: row.ReviewerIds,
+			CreatedAt:         row.CreatedAt.Time,
+		}
+		if row.MergedAt.Valid {
+			pr.MergedAt = &row.MergedAt.Time
+		}
+		if row.ClosedAt.Valid {
+			pr.ClosedAt = &row.ClosedAt.Time
+		}
+		page.PullRequests = append(page.PullRequests, pr)
+	}
+
+	return page, nil
+}
+
+// likeEscaper makes LIKE wildcards in user input match literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// GetPendingReviewsWithSLA gets the reviewer's PENDING reviews on OPEN PRs with assignment time and team SLA, oldest first
+func (r *PullRequestRepositoryImpl) GetPendingReviewsWithSLA(ctx context.Context, reviewerID string) ([]domain.PendingReview, error) {
+	rows, err := r.queries.GetPendingReviewsWithSLA(ctx, reviewerID)
+	if err != nil {
+		r.logger.Error("failed to get pending reviews with SLA",
+			slog.String("reviewer_id", reviewerID),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to get pending reviews with SLA: %w", err)
+	}
+
+	reviews := make([]domain.PendingReview, len(rows))
+	for i, row := range rows {
+		reviews[i] = domain.PendingReview{
+			PullRequestShort: domain.PullRequestShort{
+				ID:       row.ID,
+				Name:     row.Name,
+				AuthorID: row.AuthorID,
+				Status:   domain.PRStatus(row.Status),
+			},
+			AssignedAt: row.AssignedAt.Time,
+			SLA:        time.Duration(row.ReviewSlaMinutes) * time.Minute,
+		}
+	}
+
+	return reviews, nil
+}
+
+// SetReviewState stores a reviewer's review state on a PR and records it in the PR history in a transaction
+func (r *PullRequestRepositoryImpl) SetReviewState(ctx context.Context, prID, reviewerID string, state domain.ReviewState) error {
+	txCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(txCtx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.Background())
+			r.logger.Error("panic in SetReviewState transaction",
+				slog.String("pr_id", prID),
+				slog.Any("panic", p),
+			)
+			panic(p)
+		}
+		_ = tx.Rollback(context.Background())
+	}()
+
+	qtx := r.queries.WithTx(tx)
+
+	rows, err := qtx.SetReviewState(txCtx, db.SetReviewStateParams{
+		PullRequestID:  prID,
+		ReviewerID:     reviewerID,
+		State:          string(state),
+		StateUpdatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		r.logger.Error("failed to set review state",
+			slog.String("pr_id", prID),
+			slog.String("reviewer_id", reviewerID),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to set review state: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrReviewerNotFound
+	}
+
+	submitted := domain.PRHistoryEntry{
+		PullRequestID: prID,
+		Type:          domain.PRHistoryReviewSubmitted,
+		ReviewerID:    reviewerID,
+		ReviewState:   state,
+	}
+	if err := writeHistory(txCtx, qtx, submitted); err != nil {
+		r.logger.Error("failed to write PR history in transaction",
+			slog.String("pr_id", prID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	if err := tx.Commit(txCtx); err != nil {
+		r.logger.Error("failed to commit transaction",
+			slog.String("pr_id", prID),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.Info("review state updated",
+		slog.String("pr_id", prID),
+		slog.String("reviewer_id", reviewerID),
+		slog.String("state", string(state)),
+	)
+	return nil
+}
+
+// GetHistory gets the PR history, oldest entry first
+func (r *PullRequestRepositoryImpl) GetHistory(ctx context.Context, prID string) ([]domain.PRHistoryEntry, error) {
+	rows, err := r.queries.GetPREvents(ctx, prID)
+	if err != nil {
+		r.logger.Error("failed to get PR history",
+			slog.String("pr_id", prID),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to get PR history: %w", err)
+	}
+
+	entries := make([]domain.PRHistoryEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = historyEntryFromDB(row)
+	}
+	return entries, nil
+}
+
+// GetOverdueReviews gets up to limit PENDING reviews on OPEN PRs that are past their team's review SLA at now
+// Oldest assignments come first; reviews already marked escalated are skipped
+func (r *PullRequestRepositoryImpl) GetOverdueReviews(ctx context.Context, now time.Time, limit int) ([]domain.OverdueReview, error) {
+	rows, err := r.queries.GetOverdueReviews(ctx, db.GetOverdueReviewsParams{
+		Now:     pgtype.Timestamptz{Time: now, Valid: true},
+		MaxRows: int32(limit), // #nosec G115 -- batch size comes from config
+	})
+	if err != nil {
+		r.logger.Error("failed to get overdue reviews", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get overdue reviews: %w", err)
+	}
+
+	reviews := make([]domain.OverdueReview, len(rows))
+	for i, row := range rows {
+		reviews[i] = domain.OverdueReview{
+			PullRequestID: row.PullRequestID,
+			ReviewerID:    row.ReviewerID,
+			AssignedAt:    row.AssignedAt.Time,
+			TeamName:      row.TeamName,
+			Action:        domain.SLAAction(row.SlaAction),
+			LeadUserID:    row.LeadUserID,
+		}
+	}
+
+	return reviews, nil
+}
+
+// MarkEscalated flags an overdue review as escalated so the SLA job skips it from now on
+func (r *PullRequestRepositoryImpl) MarkEscalated(ctx context.Context, prID, reviewerID string, at time.Time) error {
+	rows, err := r.queries.MarkReviewEscalated(ctx, db.MarkReviewEscalatedParams{
+		PullRequestID: prID,
+		ReviewerID:    reviewerID,
+		EscalatedAt:   pgtype.Timestamptz{Time: at, Valid: true},
+	})
+	if err != nil {
+		r.logger.Error("failed to mark review escalated",
+			slog.String("pr_id", prID),
+			slog.String("reviewer_id", reviewerID),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to mark review escalated: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrReviewerNotFound
+	}
+
+	r.logger.Info("review marked escalated",
+		slog.String("pr_id", prID),
+		slog.String("reviewer_id", reviewerID),
+	)
+	return nil
+}
+
 // Exists checks if a PR exists
 func (r *PullRequestRepositoryImpl) Exists(ctx context.Context, id string) (bool, error) {
 	exists, err := r.queries.PullRequestExists(ctx, id)
@@ -526,3 +1188,62 @@ func (r *PullRequestRepositoryImpl) CountByStatus(ctx context.Context, status do
 
 	return int(count), nil
 }
+
+// loadPullRequest reads a PR with its reviews through q, which may be bound to a transaction
+func loadPullRequest(ctx context.Context, q *db.Queries, id string) (*domain.PullRequest, error) {
+	dbPR, err := q.GetPullRequestByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPRNotFound
+		}
+		return nil, fmt.Errorf("failed to get PR: %w", err)
+	}
+
+	reviews, err := q.GetReviewsByPRID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewers: %w", err)
+	}
+	reviewerIDs, prReviews := reviewsFromDB(reviews)
+
+	pr := &domain.PullRequest{
+		ID:                dbPR.ID,
+		Name:              dbPR.Name,
+		AuthorID:          dbPR.AuthorID,
+		Status:            domain.PRStatus(dbPR.Status),
+		AssignedReviewers: reviewerIDs,
+		Reviews:           prReviews,
+		ForceMerged:       dbPR.ForceMerged,
+	}
+
+	if dbPR.CreatedAt.Valid {
+		pr.CreatedAt = &dbPR.CreatedAt.Time
+	}
+	if dbPR.MergedAt.Valid {
+		pr.MergedAt = &dbPR.MergedAt.Time
+	}
+	if dbPR.ClosedAt.Valid {
+		pr.ClosedAt = &dbPR.ClosedAt.Time
+	}
+
+	return pr, nil
+}
+
+// reviewsFromDB splits reviewer rows into the assigned reviewer IDs and their review states
+func reviewsFromDB(rows []db.GetReviewsByPRIDRow) ([]string, []domain.Review) {
+	reviewerIDs := make([]string, len(rows))
+	reviews := make([]domain.Review, len(rows))
+	for i, row := range rows {
+		reviewerIDs[i] = row.ReviewerID
+		reviews[i] = domain.Review{
+			ReviewerID: row.ReviewerID,
+			State:      domain.ReviewState(row.State),
+		}
+		if row.StateUpdatedAt.Valid {
+			reviews[i].UpdatedAt = &row.StateUpdatedAt.Time
+		}
+		if row.EscalatedAt.Valid {
+			reviews[i].EscalatedAt = &row.EscalatedAt.Time
+		}
+	}
+	return reviewerIDs, reviews
+}
@@ -0,0 +1,154 @@
+// Массовое переназначение открытых ревью (используется при деактивации и переводе пользователей)
+package repository
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	"test_avito/internal/database/db"
+	"test_avito/internal/domain"
+)
+
+// reassignOpenReviews moves every OPEN review of the given users to active members of the PR author's team
+// It runs a fixed number of queries regardless of the number of PRs: the reviews and the candidates
+// are loaded in two queries, replacements are picked in memory (least loaded first, spread by PR hash),
+// then old assignments are removed and new ones inserted in bulk, and the PR history is written in one insert
+// Every pick counts against the candidate's load, so nobody is pushed past max_open_reviews
+// The given users are never picked, so when a whole team is deactivated its own PRs are left short
+// reason is recorded in the PR history (deactivation or transfer)
+// Must be called with queries bound to a transaction
+func reassignOpenReviews(ctx context.Context, qtx *db.Queries, userIDs []string, reason string) (*domain.ReassignmentReport, error) {
+	report := domain.NewReassignmentReport()
+	if len(userIDs) == 0 {
+		return report, nil
+	}
+
+	reviews, err := qtx.GetOpenReviewsByReviewers(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open reviews: %w", err)
+	}
+	if len(reviews) == 0 {
+		return report, nil
+	}
+
+	teamNames := make([]string, 0, len(reviews))
+	for _, review := range reviews {
+		teamNames = append(teamNames, review.TeamName)
+	}
+	candidates, err := qtx.GetReplacementCandidates(ctx, db.GetReplacementCandidatesParams{
+		TeamNames:  teamNames,
+		ExcludeIds: userIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replacement candidates: %w", err)
+	}
+
+	prIDs := make([]string, 0, len(reviews))
+	newReviewerIDs := make([]string, 0, len(reviews))
+	for _, pick := range pickReplacements(reviews, candidates) {
+		if pick.NewReviewerID == "" {
+			report.LeftShort = append(report.LeftShort, pick)
+			continue
+		}
+		report.Reassigned = append(report.Reassigned, pick)
+		prIDs = append(prIDs, pick.PullRequestID)
+		newReviewerIDs = append(newReviewerIDs, pick.NewReviewerID)
+	}
+
+	if _, err := qtx.RemoveOpenReviewsByReviewers(ctx, userIDs); err != nil {
+		return nil, fmt.Errorf("failed to remove open reviews: %w", err)
+	}
+
+	if len(prIDs) > 0 {
+		err = qtx.AddReviewersBulk(ctx, db.AddReviewersBulkParams{
+			PullRequestIds: prIDs,
+			ReviewerIds:    newReviewerIDs,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add replacement reviewers: %w", err)
+		}
+	}
+
+	history := make([]domain.PRHistoryEntry, 0, len(reviews))
+	for _, item := range report.Reassigned {
+		history = append(history, domain.PRHistoryEntry{
+			PullRequestID: item.PullRequestID,
+			Type:          domain.PRHistoryReviewerReassigned,
+			ReviewerID:    item.NewReviewerID,
+			OldReviewerID: item.OldReviewerID,
+			Reason:        reason,
+		})
+	}
+	for _, item := range report.LeftShort {
+		history = append(history, domain.PRHistoryEntry{
+			PullRequestID: item.PullRequestID,
+			Type:          domain.PRHistoryReviewerRemoved,
+			ReviewerID:    item.OldReviewerID,
+			Reason:        reason,
+		})
+	}
+	if err := writeHistory(ctx, qtx, history...); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// pickReplacements picks a replacement for each review, in order, among the candidates of the PR author's team
+// A candidate is never the author or someone already on the PR; the least loaded one wins, counting earlier picks,
+// ties are spread by a hash of the PR and broken by ID. Reviews without a candidate get an empty NewReviewerID
+func pickReplacements(reviews []db.GetOpenReviewsByReviewersRow, candidates []db.GetReplacementCandidatesRow) []domain.ReviewerReassignment {
+	byTeam := make(map[string][]*db.GetReplacementCandidatesRow)
+	for i := range candidates {
+		byTeam[candidates[i].TeamName] = append(byTeam[candidates[i].TeamName], &candidates[i])
+	}
+
+	onPR := make(map[string]map[string]bool)
+	picks := make([]domain.ReviewerReassignment, 0, len(reviews))
+	for _, review := range reviews {
+		reviewers, ok := onPR[review.PullRequestID]
+		if !ok {
+			reviewers = make(map[string]bool, len(review.ReviewerIds))
+			for _, id := range review.ReviewerIds {
+				reviewers[id] = true
+			}
+			onPR[review.PullRequestID] = reviewers
+		}
+
+		var best *db.GetReplacementCandidatesRow
+		for _, c := range byTeam[review.TeamName] {
+			if c.ID == review.AuthorID || reviewers[c.ID] {
+				continue
+			}
+			if c.MaxOpenReviews != nil && c.OpenReviews >= *c.MaxOpenReviews {
+				continue
+			}
+			if best == nil || c.OpenReviews < best.OpenReviews ||
+				(c.OpenReviews == best.OpenReviews && spreadHash(review.PullRequestID, c.ID) < spreadHash(review.PullRequestID, best.ID)) {
+				best = c
+			}
+		}
+
+		pick := domain.ReviewerReassignment{
+			PullRequestID: review.PullRequestID,
+			OldReviewerID: review.ReviewerID,
+		}
+		if best != nil {
+			best.OpenReviews++
+			reviewers[best.ID] = true
+			pick.NewReviewerID = best.ID
+		}
+		picks = append(picks, pick)
+	}
+
+	return picks
+}
+
+// spreadHash orders equally loaded candidates differently for different PRs
+func spreadHash(prID, userID string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(prID))
+	_, _ = h.Write([]byte(userID))
+	return h.Sum32()
+}
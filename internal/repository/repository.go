@@ -3,6 +3,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"test_avito/internal/domain"
 )
@@ -10,15 +11,25 @@ import (
 type TeamRepository interface {
 	Create(ctx context.Context, team *domain.Team) error
 	// CreateWithMembers creates a team with members in a transaction
-	CreateWithMembers(ctx context.Context, team *domain.Team) error
-	// UpdateMembers updates team members in a transaction
-	UpdateMembers(ctx context.Context, teamName string, members []domain.User) error
+	CreateWithMembers(ctx context.Context, team *domain.Team) (*domain.MemberMoves, error)
+	// UpdateMembers updates team members and, when provided, the policy in a transaction
+	UpdateMembers(ctx context.Context, team *domain.Team) (*domain.MemberMoves, error)
+	// ReplaceMembers makes the given members the active roster of the team in a transaction
+	ReplaceMembers(ctx context.Context, team *domain.Team) (*domain.MembershipChanges, error)
 	// GetByName retrieves a team by name
 	GetByName(ctx context.Context, name string) (*domain.Team, error)
 	// Exists checks if a team exists
 	Exists(ctx context.Context, name string) (bool, error)
+	// List retrieves all teams with member and active member counts, ordered by name
+	// isActive selects teams with (true) or without (false) active members; nil returns all teams
+	List(ctx context.Context, isActive *bool) ([]domain.TeamSummary, error)
 	// Count returns the total number of teams
 	Count(ctx context.Context) (int, error)
+	// GetPolicy retrieves the reviewer policy of a team
+	GetPolicy(ctx context.Context, teamName string) (*domain.TeamPolicy, error)
+	// AdvanceReviewerCursor atomically moves the round-robin cursor of the team past the next picks
+	// of the sorted candidateIDs and returns the new cursor (the last picked reviewer)
+	AdvanceReviewerCursor(ctx context.Context, teamName string, candidateIDs []string, picks int) (string, error)
 }
 
 type UserRepository interface {
@@ -28,10 +39,16 @@ type UserRepository interface {
 	Update(ctx context.Context, user *domain.User) error
 	// GetByID retrieves a user by ID
 	GetByID(ctx context.Context, id string) (*domain.User, error)
+	// GetByIDs retrieves the users with the given IDs; unknown IDs are skipped
+	GetByIDs(ctx context.Context, ids []string) ([]domain.User, error)
 	// GetByTeam retrieves all users in a team
 	GetByTeam(ctx context.Context, teamName string) ([]domain.User, error)
+	// Search retrieves users whose ID or username contains the query, best matches first
+	Search(ctx context.Context, filter domain.UserSearch) ([]domain.User, error)
 	// SetIsActive updates the user's active status
 	SetIsActive(ctx context.Context, userID string, isActive bool) error
+	// SetMaxOpenReviews updates the user's open reviews limit (nil removes the limit)
+	SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) error
 	// GetActiveByTeam retrieves all active users in a team excluding specific user
 	GetActiveByTeam(ctx context.Context, teamName string, excludeUserID string) ([]domain.User, error)
 	// Exists checks if a user exists
@@ -44,29 +61,74 @@ type UserRepository interface {
 	Upsert(ctx context.Context, user *domain.User) error
 	// DeactivateTeamUsers deactivates all users in a team
 	DeactivateTeamUsers(ctx context.Context, teamName string) (int, error)
+	// DeactivateWithReassignment deactivates a user and reassigns their OPEN reviews in a transaction
+	DeactivateWithReassignment(ctx context.Context, userID string) (*domain.ReassignmentReport, error)
+	// DeactivateTeamWithReassignment deactivates all users in a team and reassigns their OPEN reviews in a transaction
+	DeactivateTeamWithReassignment(ctx context.Context, teamName string) (int, *domain.ReassignmentReport, error)
+	// Transfer moves a user to another team applying the review policy and records the move in a transaction
+	Transfer(ctx context.Context, userID, teamName string, policy domain.TransferReviewPolicy) (string, *domain.ReassignmentReport, error)
+	// GetMemberships retrieves the team membership history of a user
+	GetMemberships(ctx context.Context, userID string) ([]domain.MembershipChange, error)
+	// GetReviewCandidates retrieves active team members with their open review load, excluding given users
+	GetReviewCandidates(ctx context.Context, teamName string, excludeUserIDs []string) ([]domain.ReviewCandidate, error)
+	// GetOpenReviewCounts returns the number of OPEN PRs each user is reviewing
+	GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error)
+	// SetEmailSettings replaces the user's email notification preferences
+	SetEmailSettings(ctx context.Context, userID string, settings domain.EmailSettings) error
+	// GetDigestRecipients retrieves active users who accept email and have PENDING reviews on OPEN PRs
+	GetDigestRecipients(ctx context.Context) ([]domain.DigestRecipient, error)
+	// SetLastDigestAt records when the user was sent the daily digest
+	SetLastDigestAt(ctx context.Context, userID string, at time.Time) error
 }
 
 type PullRequestRepository interface {
-	// Create creates a new pull request
-	Create(ctx context.Context, pr *domain.PullRequest) error
+	// Create creates a new pull request and writes pr.created to the outbox in a transaction
+	// The creation and assignments are recorded in the PR history, assignments with reason
+	Create(ctx context.Context, pr *domain.PullRequest, reason string) error
 	// GetByID retrieves a pull request by ID
 	GetByID(ctx context.Context, id string) (*domain.PullRequest, error)
 	// Update updates an existing pull request
 	Update(ctx context.Context, pr *domain.PullRequest) error
-	// Merge marks a PR as merged (idempotent)
-	Merge(ctx context.Context, id string) (*domain.PullRequest, error)
-	// AddReviewer adds a reviewer to a PR
-	AddReviewer(ctx context.Context, prID, reviewerID string) error
-	// RemoveReviewer removes a reviewer from a PR
-	RemoveReviewer(ctx context.Context, prID, reviewerID string) error
-	// ReassignReviewer replaces old reviewer with new one in a transaction
-	ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error
-	// AssignReviewers assigns reviewers to an existing PR in a transaction
-	AssignReviewers(ctx context.Context, prID string, reviewerIDs []string) error
+	// Merge marks a PR as merged (idempotent), recording whether the team merge rule was bypassed
+	// An actual merge writes pr.merged to the outbox in the same transaction
+	Merge(ctx context.Context, id string, force bool) (*domain.PullRequest, error)
+	// Transition persists a lifecycle transition of the PR from the given status
+	// and assigns reviewerIDs in the same transaction
+	Transition(ctx context.Context, pr *domain.PullRequest, from domain.PRStatus, reviewerIDs []string, reason string) error
+	// AddReviewer adds a reviewer to an OPEN PR that has fewer than maxReviewers; reason is recorded in the PR history
+	// The count check and the insert are atomic
+	AddReviewer(ctx context.Context, prID, reviewerID, reason string, maxReviewers int) error
+	// RemoveReviewer removes a reviewer from an OPEN PR unless that leaves it with fewer than minReviewers
+	// The count check and the delete are atomic
+	RemoveReviewer(ctx context.Context, prID, reviewerID string, minReviewers int) error
+	// ReassignReviewer replaces old reviewer with new one and writes pr.reviewer_reassigned to the outbox in a transaction
+	// reason is recorded in the PR history
+	ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, reason string) error
+	// AssignReviewers assigns reviewers to an existing PR and writes pr.reviewers_assigned to the outbox in a transaction
+	// reason is recorded in the PR history
+	AssignReviewers(ctx context.Context, prID string, reviewerIDs []string, reason string) error
 	// GetReviewersByPRID gets all reviewers for a PR
 	GetReviewersByPRID(ctx context.Context, prID string) ([]string, error)
 	// GetPRsByReviewer gets all PRs assigned to a reviewer
 	GetPRsByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error)
+	// GetPendingPRsByReviewer gets OPEN PRs where the reviewer has not submitted a review yet
+	GetPendingPRsByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error)
+	// ListPRsByReviewer gets one page of PRs assigned to a reviewer matching the filter
+	// filter.Limit must be positive and filter.Sort valid
+	ListPRsByReviewer(ctx context.Context, filter domain.ReviewerPRFilter) (*domain.PRPage, error)
+	// List gets one page of PRs matching the filter
+	// filter.Limit must be positive and filter.Sort valid
+	List(ctx context.Context, filter domain.PRFilter) (*domain.PRSummaryPage, error)
+	// GetPendingReviewsWithSLA gets the reviewer's PENDING reviews on OPEN PRs with assignment time and team SLA, oldest first
+	GetPendingReviewsWithSLA(ctx context.Context, reviewerID string) ([]domain.PendingReview, error)
+	// SetReviewState stores a reviewer's review state on a PR
+	SetReviewState(ctx context.Context, prID, reviewerID string, state domain.ReviewState) error
+	// GetHistory gets the PR history (changes of the PR, its reviewers and reviews), oldest first
+	GetHistory(ctx context.Context, prID string) ([]domain.PRHistoryEntry, error)
+	// GetOverdueReviews gets up to limit PENDING reviews on OPEN PRs that are past their team's review SLA at now
+	GetOverdueReviews(ctx context.Context, now time.Time, limit int) ([]domain.OverdueReview, error)
+	// MarkEscalated flags an overdue review as escalated so the SLA job skips it from now on
+	MarkEscalated(ctx context.Context, prID, reviewerID string, at time.Time) error
 	// Exists checks if a PR exists
 	Exists(ctx context.Context, id string) (bool, error)
 	// Count returns total number of PRs
@@ -75,6 +137,53 @@ type PullRequestRepository interface {
 	CountByStatus(ctx context.Context, status domain.PRStatus) (int, error)
 }
 
+type WebhookRepository interface {
+	// CreateSubscription stores a new webhook subscription
+	CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	// ListSubscriptions retrieves all webhook subscriptions
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	// DeleteSubscription removes a subscription together with its queued and failed deliveries
+	DeleteSubscription(ctx context.Context, id int64) error
+	// EnqueueDeliveries queues one delivery of the payload per subscription
+	EnqueueDeliveries(ctx context.Context, subscriptionIDs []int64, eventType domain.EventType, payload []byte) error
+	// ClaimDueDeliveries takes up to limit deliveries due at now and hides them from other workers until leaseUntil
+	ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error)
+	// MarkDelivered records a successful delivery
+	MarkDelivered(ctx context.Context, id int64, at time.Time) error
+	// ScheduleRetry records a failed attempt and when to try again
+	ScheduleRetry(ctx context.Context, id int64, attempts int, nextAttemptAt time.Time, lastError string) error
+	// DeleteDelivered removes deliveries delivered before the given time
+	DeleteDelivered(ctx context.Context, before time.Time) (int, error)
+	// MoveToDeadLetter replaces a delivery that ran out of attempts with a dead letter in a transaction
+	MoveToDeadLetter(ctx context.Context, delivery *domain.WebhookDelivery, lastError string) error
+	// ListDeadLetters retrieves failed deliveries; nil subscriptionID lists all of them
+	ListDeadLetters(ctx context.Context, subscriptionID *int64) ([]domain.WebhookDeadLetter, error)
+	// Redeliver moves a dead letter back to the delivery queue in a transaction
+	Redeliver(ctx context.Context, deadLetterID int64) (*domain.WebhookDeadLetter, error)
+}
+
+type OutboxRepository interface {
+	// ClaimEvents takes up to limit unpublished events due at now and hides them from other relays until leaseUntil
+	ClaimEvents(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.OutboxEvent, error)
+	// MarkPublished records that the event was handed to the publisher
+	MarkPublished(ctx context.Context, id int64, at time.Time) error
+	// ScheduleRetry records a failed publish attempt and when to try again
+	ScheduleRetry(ctx context.Context, id int64, attempts int, nextAttemptAt time.Time, lastError string) error
+	// DeletePublished removes events published before the given time
+	DeletePublished(ctx context.Context, before time.Time) (int, error)
+}
+
+type StreamRepository interface {
+	// Append stores the event with the team and users it concerns and returns its sequence number
+	Append(ctx context.Context, event domain.Event, teamName *string, userIDs []string) (int64, error)
+	// ListAfter retrieves up to limit events with a sequence number above afterSeq, in order
+	ListAfter(ctx context.Context, afterSeq int64, limit int) ([]domain.StreamEvent, error)
+	// LatestSeq returns the sequence number of the newest event, 0 if there are none
+	LatestSeq(ctx context.Context) (int64, error)
+	// DeleteBefore removes events stored before the given time
+	DeleteBefore(ctx context.Context, before time.Time) (int, error)
+}
+
 type StatsRepository interface {
 	// GetStats retrieves overall statistics
 	GetStats(ctx context.Context) (*Stats, error)
@@ -85,6 +194,8 @@ type Stats struct {
 	TotalPRs    int `json:"total_prs"`
 	OpenPRs     int `json:"open_prs"`
 	MergedPRs   int `json:"merged_prs"`
+	DraftPRs    int `json:"draft_prs"`
+	ClosedPRs   int `json:"closed_prs"`
 	TotalTeams  int `json:"total_teams"`
 	TotalUsers  int `json:"total_users"`
 	ActiveUsers int `json:"active_users"`
@@ -34,6 +34,8 @@ func (r *StatsRepositoryImpl) GetStats(ctx context.Context) (*Stats, error) {
 		TotalPRs:    int(dbStats.TotalPrs),
 		OpenPRs:     int(dbStats.OpenPrs),
 		MergedPRs:   int(dbStats.MergedPrs),
+		DraftPRs:    int(dbStats.DraftPrs),
+		ClosedPRs:   int(dbStats.ClosedPrs),
 		TotalTeams:  int(dbStats.TotalTeams),
 		TotalUsers:  int(dbStats.TotalUsers),
 		ActiveUsers: int(dbStats.ActiveUsers),
@@ -0,0 +1,106 @@
+// Имплементация репозитория для работы с лентой событий SSE в базе данных postgresql
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"test_avito/internal/database/db"
+	"test_avito/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StreamRepositoryImpl struct {
+	queries *db.Queries
+	pool    *pgxpool.Pool
+	logger  *slog.Logger
+}
+
+func NewStreamRepository(pool *pgxpool.Pool, logger *slog.Logger) *StreamRepositoryImpl {
+	return &StreamRepositoryImpl{
+		queries: db.New(pool),
+		pool:    pool,
+		logger:  logger,
+	}
+}
+
+// Append stores the event with the team and users it concerns and returns its sequence number
+func (r *StreamRepositoryImpl) Append(ctx context.Context, event domain.Event, teamName *string, userIDs []string) (int64, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode stream event: %w", err)
+	}
+	if userIDs == nil {
+		userIDs = []string{}
+	}
+
+	seq, err := r.queries.InsertStreamEvent(ctx, db.InsertStreamEventParams{
+		EventID:   event.ID,
+		EventType: string(event.Type),
+		TeamName:  teamName,
+		UserIds:   userIDs,
+		Payload:   payload,
+	})
+	if err != nil {
+		r.logger.Error("failed to append stream event",
+			slog.String("event_id", event.ID),
+			slog.String("error", err.Error()),
+		)
+		return 0, fmt.Errorf("failed to append stream event: %w", err)
+	}
+	return seq, nil
+}
+
+// ListAfter retrieves up to limit events with a sequence number above afterSeq, in order
+func (r *StreamRepositoryImpl) ListAfter(ctx context.Context, afterSeq int64, limit int) ([]domain.StreamEvent, error) {
+	rows, err := r.queries.ListStreamEventsAfter(ctx, db.ListStreamEventsAfterParams{
+		AfterSeq: afterSeq,
+		MaxRows:  int32(limit), // #nosec G115 -- batch size comes from config
+	})
+	if err != nil {
+		r.logger.Error("failed to list stream events",
+			slog.Int64("after_seq", afterSeq),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to list stream events: %w", err)
+	}
+
+	events := make([]domain.StreamEvent, len(rows))
+	for i, row := range rows {
+		events[i] = domain.StreamEvent{
+			Seq:       row.Seq,
+			EventID:   row.EventID,
+			Type:      domain.EventType(row.EventType),
+			TeamName:  row.TeamName,
+			UserIDs:   row.UserIds,
+			Payload:   row.Payload,
+			CreatedAt: row.CreatedAt.Time,
+		}
+	}
+	return events, nil
+}
+
+// LatestSeq returns the sequence number of the newest event, 0 if there are none
+func (r *StreamRepositoryImpl) LatestSeq(ctx context.Context) (int64, error) {
+	seq, err := r.queries.GetLatestStreamSeq(ctx)
+	if err != nil {
+		r.logger.Error("failed to get latest stream sequence", slog.String("error", err.Error()))
+		return 0, fmt.Errorf("failed to get latest stream sequence: %w", err)
+	}
+	return seq, nil
+}
+
+// DeleteBefore removes events stored before the given time
+func (r *StreamRepositoryImpl) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	rows, err := r.queries.DeleteStreamEventsBefore(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		r.logger.Error("failed to delete stream events", slog.String("error", err.Error()))
+		return 0, fmt.Errorf("failed to delete stream events: %w", err)
+	}
+	return int(rows), nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sort"
 	"time"
 
@@ -45,7 +46,8 @@ func (r *TeamRepositoryImpl) Create(ctx context.Context, team *domain.Team) erro
 }
 
 // CreateWithMembers creates a team with members in a transaction
-func (r *TeamRepositoryImpl) CreateWithMembers(ctx context.Context, team *domain.Team) error {
+// Members are taken over from other teams as described by recordMoves
+func (r *TeamRepositoryImpl) CreateWithMembers(ctx context.Context, team *domain.Team) (*domain.MemberMoves, error) {
 	txCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -55,7 +57,7 @@ func (r *TeamRepositoryImpl) CreateWithMembers(ctx context.Context, team *domain
 			slog.String("team_name", team.Name),
 			slog.String("error", err.Error()),
 		)
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
 	defer func() {
@@ -78,7 +80,7 @@ func (r *TeamRepositoryImpl) CreateWithMembers(ctx context.Context, team *domain
 			slog.String("team_name", team.Name),
 			slog.String("error", err.Error()),
 		)
-		return fmt.Errorf("failed to create team: %w", err)
+		return nil, fmt.Errorf("failed to create team: %w", err)
 	}
 
 	members := make([]domain.User, len(team.Members))
@@ -87,12 +89,18 @@ func (r *TeamRepositoryImpl) CreateWithMembers(ctx context.Context, team *domain
 		return members[i].ID < members[j].ID
 	})
 
+	_, moves, err := r.recordMoves(txCtx, qtx, team, members)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, member := range members {
 		err = qtx.UpsertUser(txCtx, db.UpsertUserParams{
-			ID:       member.ID,
-			Username: member.Username,
-			TeamName: team.Name,
-			IsActive: member.IsActive,
+			ID:             member.ID,
+			Username:       member.Username,
+			TeamName:       team.Name,
+			IsActive:       member.IsActive,
+			MaxOpenReviews: toInt32Ptr(member.MaxOpenReviews),
 		})
 		if err != nil {
 			r.logger.Error("failed to upsert member in transaction",
@@ -100,7 +108,19 @@ func (r *TeamRepositoryImpl) CreateWithMembers(ctx context.Context, team *domain
 				slog.String("user_id", member.ID),
 				slog.String("error", err.Error()),
 			)
-			return fmt.Errorf("failed to upsert member %s: %w", member.ID, err)
+			return nil, fmt.Errorf("failed to upsert member %s: %w", member.ID, err)
+		}
+	}
+
+	// Policy goes after members: lead_user_id references one of them
+	if team.Policy != nil {
+		err = qtx.UpdateTeamPolicy(txCtx, teamPolicyParams(team.Name, team.Policy))
+		if err != nil {
+			r.logger.Error("failed to set team policy in transaction",
+				slog.String("team_name", team.Name),
+				slog.String("error", err.Error()),
+			)
+			return nil, fmt.Errorf("failed to set team policy: %w", err)
 		}
 	}
 
@@ -109,35 +129,37 @@ func (r *TeamRepositoryImpl) CreateWithMembers(ctx context.Context, team *domain
 			slog.String("team_name", team.Name),
 			slog.String("error", err.Error()),
 		)
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	r.logger.Info("team created with members in transaction",
 		slog.String("team_name", team.Name),
 		slog.Int("members_count", len(team.Members)),
 	)
-	return nil
+	return moves, nil
 }
 
 // UpdateMembers updates team members in a transaction
-func (r *TeamRepositoryImpl) UpdateMembers(ctx context.Context, teamName string, members []domain.User) error {
+// Members are taken over from other teams as described by recordMoves
+// The policy is changed in the same transaction, only when provided
+func (r *TeamRepositoryImpl) UpdateMembers(ctx context.Context, team *domain.Team) (*domain.MemberMoves, error) {
 	txCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	tx, err := r.pool.Begin(txCtx)
 	if err != nil {
 		r.logger.Error("failed to begin transaction",
-			slog.String("team_name", teamName),
+			slog.String("team_name", team.Name),
 			slog.String("error", err.Error()),
 		)
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
 	defer func() {
 		if p := recover(); p != nil {
 			_ = tx.Rollback(context.Background())
 			r.logger.Error("panic in UpdateMembers transaction",
-				slog.String("team_name", teamName),
+				slog.String("team_name", team.Name),
 				slog.Any("panic", p),
 			)
 			panic(p)
@@ -147,42 +169,209 @@ func (r *TeamRepositoryImpl) UpdateMembers(ctx context.Context, teamName string,
 
 	qtx := r.queries.WithTx(tx)
 
-	sortedMembers := make([]domain.User, len(members))
-	copy(sortedMembers, members)
+	sortedMembers := make([]domain.User, len(team.Members))
+	copy(sortedMembers, team.Members)
 	sort.Slice(sortedMembers, func(i, j int) bool {
 		return sortedMembers[i].ID < sortedMembers[j].ID
 	})
 
+	_, moves, err := r.recordMoves(txCtx, qtx, team, sortedMembers)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, member := range sortedMembers {
 		err = qtx.UpsertUser(txCtx, db.UpsertUserParams{
-			ID:       member.ID,
-			Username: member.Username,
-			TeamName: teamName,
-			IsActive: member.IsActive,
+			ID:             member.ID,
+			Username:       member.Username,
+			TeamName:       team.Name,
+			IsActive:       member.IsActive,
+			MaxOpenReviews: toInt32Ptr(member.MaxOpenReviews),
 		})
 		if err != nil {
 			r.logger.Error("failed to upsert member in transaction",
-				slog.String("team_name", teamName),
+				slog.String("team_name", team.Name),
 				slog.String("user_id", member.ID),
 				slog.String("error", err.Error()),
 			)
-			return fmt.Errorf("failed to upsert member %s: %w", member.ID, err)
+			return nil, fmt.Errorf("failed to upsert member %s: %w", member.ID, err)
+		}
+	}
+
+	// Policy goes after members: lead_user_id references one of them
+	if team.Policy != nil {
+		err = qtx.UpdateTeamPolicy(txCtx, teamPolicyParams(team.Name, team.Policy))
+		if err != nil {
+			r.logger.Error("failed to set team policy in transaction",
+				slog.String("team_name", team.Name),
+				slog.String("error", err.Error()),
+			)
+			return nil, fmt.Errorf("failed to set team policy: %w", err)
 		}
 	}
 
 	if err := tx.Commit(txCtx); err != nil {
 		r.logger.Error("failed to commit transaction",
-			slog.String("team_name", teamName),
+			slog.String("team_name", team.Name),
 			slog.String("error", err.Error()),
 		)
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	r.logger.Info("team members updated in transaction",
-		slog.String("team_name", teamName),
-		slog.Int("members_count", len(members)),
+		slog.String("team_name", team.Name),
+		slog.Int("members_count", len(team.Members)),
 	)
-	return nil
+	return moves, nil
+}
+
+// ReplaceMembers makes the given members the active roster of the team in a transaction:
+// the team is created if missing, listed members are upserted (moving them from other teams),
+// active members missing from the list are deactivated and their OPEN reviews reassigned,
+// as are those of listed members switched to is_active=false
+// The policy is changed only when provided
+func (r *TeamRepositoryImpl) ReplaceMembers(ctx context.Context, team *domain.Team) (*domain.MembershipChanges, error) {
+	txCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(txCtx)
+	if err != nil {
+		r.logger.Error("failed to begin transaction",
+			slog.String("team_name", team.Name),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.Background())
+			r.logger.Error("panic in ReplaceMembers transaction",
+				slog.String("team_name", team.Name),
+				slog.Any("panic", p),
+			)
+			panic(p)
+		}
+		_ = tx.Rollback(context.Background())
+	}()
+
+	qtx := r.queries.WithTx(tx)
+
+	if err := qtx.EnsureTeam(txCtx, team.Name); err != nil {
+		r.logger.Error("failed to ensure team in transaction",
+			slog.String("team_name", team.Name),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to create team: %w", err)
+	}
+
+	members := make([]domain.User, len(team.Members))
+	copy(members, team.Members)
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].ID < members[j].ID
+	})
+	memberIDs := make([]string, len(members))
+	for i, member := range members {
+		memberIDs[i] = member.ID
+	}
+
+	// The stored state is locked, so a concurrent sync can't classify the members differently
+	rows, moves, err := r.recordMoves(txCtx, qtx, team, members)
+	if err != nil {
+		return nil, err
+	}
+	stored := make(map[string]db.User, len(rows))
+	for _, row := range rows {
+		stored[row.ID] = row
+	}
+
+	changes := domain.NewMembershipChanges()
+	changes.FromTeams = moves.FromTeams
+	for _, member := range members {
+		prev, ok := stored[member.ID]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, member.ID)
+		case prev.TeamName != team.Name:
+			changes.Moved = append(changes.Moved, member.ID)
+		case memberChanged(prev, member):
+			changes.Updated = append(changes.Updated, member.ID)
+		}
+		if ok && prev.IsActive && !member.IsActive {
+			changes.Deactivated = append(changes.Deactivated, member.ID)
+		}
+
+		err = qtx.UpsertUser(txCtx, db.UpsertUserParams{
+			ID:             member.ID,
+			Username:       member.Username,
+			TeamName:       team.Name,
+			IsActive:       member.IsActive,
+			MaxOpenReviews: toInt32Ptr(member.MaxOpenReviews),
+		})
+		if err != nil {
+			r.logger.Error("failed to upsert member in transaction",
+				slog.String("team_name", team.Name),
+				slog.String("user_id", member.ID),
+				slog.String("error", err.Error()),
+			)
+			return nil, fmt.Errorf("failed to upsert member %s: %w", member.ID, err)
+		}
+	}
+
+	// Removed members stay in the team as inactive: their PRs and history reference them
+	changes.Removed, err = qtx.DeactivateTeamUsersExcept(txCtx, db.DeactivateTeamUsersExceptParams{
+		TeamName: team.Name,
+		KeepIds:  memberIDs,
+	})
+	if err != nil {
+		r.logger.Error("failed to deactivate removed members in transaction",
+			slog.String("team_name", team.Name),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to deactivate removed members: %w", err)
+	}
+
+	// Listed members are already upserted, so new and moved ones can take the reviews over
+	// and members deactivated in the list are no longer candidates
+	deactivated := append(slices.Clone(changes.Removed), changes.Deactivated...)
+	removed, err := reassignOpenReviews(txCtx, qtx, deactivated, domain.ReasonDeactivation)
+	if err != nil {
+		r.logger.Error("failed to reassign open reviews in transaction",
+			slog.String("team_name", team.Name),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	changes.Reassignment = moves.Reassignment
+	changes.Reassignment.Merge(removed)
+
+	if team.Policy != nil {
+		err = qtx.UpdateTeamPolicy(txCtx, teamPolicyParams(team.Name, team.Policy))
+		if err != nil {
+			r.logger.Error("failed to set team policy in transaction",
+				slog.String("team_name", team.Name),
+				slog.String("error", err.Error()),
+			)
+			return nil, fmt.Errorf("failed to set team policy: %w", err)
+		}
+	}
+
+	if err := tx.Commit(txCtx); err != nil {
+		r.logger.Error("failed to commit transaction",
+			slog.String("team_name", team.Name),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.Info("team members replaced in transaction",
+		slog.String("team_name", team.Name),
+		slog.Int("added", len(changes.Added)),
+		slog.Int("updated", len(changes.Updated)),
+		slog.Int("moved", len(changes.Moved)),
+		slog.Int("removed", len(changes.Removed)),
+	)
+	return changes, nil
 }
 
 // GetByName retrieves a team by name with its members
@@ -210,22 +399,50 @@ func (r *TeamRepositoryImpl) GetByName(ctx context.Context, name string) (*domai
 
 	members := make([]domain.User, len(dbUsers))
 	for i, u := range dbUsers {
-		members[i] = domain.User{
-			ID:       u.ID,
-			Username: u.Username,
-			TeamName: u.TeamName,
-			IsActive: u.IsActive,
-		}
+		members[i] = userFromDB(u)
+	}
+
+	policy, err := r.GetPolicy(ctx, name)
+	if err != nil {
+		return nil, err
 	}
 
 	team := &domain.Team{
 		Name:    teamName,
 		Members: members,
+		Policy:  policy,
 	}
 
 	return team, nil
 }
 
+// GetPolicy retrieves the reviewer policy of a team
+func (r *TeamRepositoryImpl) GetPolicy(ctx context.Context, teamName string) (*domain.TeamPolicy, error) {
+	row, err := r.queries.GetTeamPolicy(ctx, teamName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTeamNotFound
+		}
+		r.logger.Error("failed to get team policy",
+			slog.String("team_name", teamName),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to get team policy: %w", err)
+	}
+
+	return &domain.TeamPolicy{
+		MinReviewers:            int(row.MinReviewers),
+		MaxReviewers:            int(row.MaxReviewers),
+		RequiredApprovals:       int(row.RequiredApprovals),
+		BlockOnChangesRequested: row.BlockOnChangesRequested,
+		ReviewSLAMinutes:        int(row.ReviewSlaMinutes),
+		SLAAction:               domain.SLAAction(row.SlaAction),
+		LeadUserID:              row.LeadUserID,
+		ChatChannel:             row.ChatChannel,
+		ChatTemplate:            row.ChatTemplate,
+	}, nil
+}
+
 // Exists checks if a team exists
 func (r *TeamRepositoryImpl) Exists(ctx context.Context, name string) (bool, error) {
 	exists, err := r.queries.TeamExists(ctx, name)
@@ -250,3 +467,129 @@ func (r *TeamRepositoryImpl) Count(ctx context.Context) (int, error) {
 
 	return int(count), nil
 }
+
+// List retrieves all teams with member counts, ordered by name
+// isActive selects teams with (true) or without (false) active members; nil returns all teams
+func (r *TeamRepositoryImpl) List(ctx context.Context, isActive *bool) ([]domain.TeamSummary, error) {
+	rows, err := r.queries.ListTeams(ctx, isActive)
+	if err != nil {
+		r.logger.Error("failed to list teams", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	teams := make([]domain.TeamSummary, len(rows))
+	for i, row := range rows {
+		teams[i] = domain.TeamSummary{
+			Name:        row.Name,
+			MemberCount: int(row.MemberCount),
+			ActiveCount: int(row.ActiveCount),
+		}
+	}
+
+	return teams, nil
+}
+
+// AdvanceReviewerCursor moves the round-robin cursor of the team past the next picks of the sorted
+// candidateIDs and returns the new cursor
+// The read and the write are one statement, so concurrent selections get consecutive turns;
+// a team without a cursor starts from the first candidate
+func (r *TeamRepositoryImpl) AdvanceReviewerCursor(ctx context.Context, teamName string, candidateIDs []string, picks int) (string, error) {
+	cursor, err := r.queries.AdvanceReviewerCursor(ctx, db.AdvanceReviewerCursorParams{
+		TeamName:     teamName,
+		CandidateIds: candidateIDs,
+		Picks:        int32(picks), // #nosec G115 -- picks is bounded by the number of candidates
+	})
+	if err != nil {
+		r.logger.Error("failed to advance reviewer cursor",
+			slog.String("team_name", teamName),
+			slog.Int("picks", picks),
+			slog.String("error", err.Error()),
+		)
+		return "", fmt.Errorf("failed to advance reviewer cursor: %w", err)
+	}
+
+	return cursor, nil
+}
+
+// recordMoves locks the stored members and takes over the ones that are in other teams:
+// without team.AllowMoves they are rejected with ErrUserInOtherTeam, otherwise their OPEN reviews
+// are handled by team.MoveReviewPolicy and the moves are recorded in the membership history
+// Must be called before the members are upserted, with queries bound to the transaction
+// Returns the stored members and the moves
+func (r *TeamRepositoryImpl) recordMoves(ctx context.Context, qtx *db.Queries, team *domain.Team, members []domain.User) ([]db.User, *domain.MemberMoves, error) {
+	memberIDs := make([]string, len(members))
+	for i, member := range members {
+		memberIDs[i] = member.ID
+	}
+
+	// The lock keeps a concurrent sync or transfer from moving the members in between
+	stored, err := qtx.GetUsersByIDsForUpdate(ctx, memberIDs)
+	if err != nil {
+		r.logger.Error("failed to get members in transaction",
+			slog.String("team_name", team.Name),
+			slog.String("error", err.Error()),
+		)
+		return nil, nil, fmt.Errorf("failed to get members: %w", err)
+	}
+
+	moves := &domain.MemberMoves{FromTeams: map[string]string{}}
+	var userIDs, fromTeams []string
+	for _, user := range stored {
+		if user.TeamName == team.Name {
+			continue
+		}
+		if !team.AllowMoves {
+			r.logger.Warn("member belongs to another team",
+				slog.String("user_id", user.ID),
+				slog.String("team_name", user.TeamName),
+			)
+			return nil, nil, fmt.Errorf("member %s of team %s: %w", user.ID, user.TeamName, domain.ErrUserInOtherTeam)
+		}
+		userIDs = append(userIDs, user.ID)
+		fromTeams = append(fromTeams, user.TeamName)
+		moves.FromTeams[user.ID] = user.TeamName
+	}
+
+	moves.Reassignment, err = prepareMoves(ctx, qtx, userIDs, team.MoveReviewPolicy)
+	if err == nil {
+		err = writeTeamAddMoves(ctx, qtx, team.Name, team.MoveReviewPolicy, userIDs, fromTeams)
+	}
+	if err != nil {
+		if !moveRejected(err) {
+			r.logger.Error("failed to move members in transaction",
+				slog.String("team_name", team.Name),
+				slog.String("error", err.Error()),
+			)
+		}
+		return nil, nil, err
+	}
+
+	return stored, moves, nil
+}
+
+// memberChanged reports whether upserting member changes the stored user
+// A nil MaxOpenReviews keeps the stored limit (see UpsertUser)
+func memberChanged(stored db.User, member domain.User) bool {
+	if stored.Username != member.Username || stored.IsActive != member.IsActive {
+		return true
+	}
+	if member.MaxOpenReviews == nil {
+		return false
+	}
+	return stored.MaxOpenReviews == nil || int(*stored.MaxOpenReviews) != *member.MaxOpenReviews
+}
+
+func teamPolicyParams(teamName string, policy *domain.TeamPolicy) db.UpdateTeamPolicyParams {
+	return db.UpdateTeamPolicyParams{
+		Name:                    teamName,
+		MinReviewers:            int32(policy.MinReviewers),      // #nosec G115 -- bounded by TeamPolicy.Validate
+		MaxReviewers:            int32(policy.MaxReviewers),      // #nosec G115 -- bounded by TeamPolicy.Validate
+		RequiredApprovals:       int32(policy.RequiredApprovals), // #nosec G115 -- bounded by TeamPolicy.Validate
+		BlockOnChangesRequested: policy.BlockOnChangesRequested,
+		ReviewSlaMinutes:        int32(policy.ReviewSLAMinutes), // #nosec G115 -- validated by TeamPolicy.Validate
+		SlaAction:               string(policy.SLAAction.OrDefault()),
+		LeadUserID:              policy.LeadUserID,
+		ChatChannel:             policy.ChatChannel,
+		ChatTemplate:            policy.ChatTemplate,
+	}
+}
@@ -6,11 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"test_avito/internal/database/db"
 	"test_avito/internal/domain"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -31,10 +33,11 @@ func NewUserRepository(pool *pgxpool.Pool, logger *slog.Logger) *UserRepositoryI
 // Create creates a new user
 func (r *UserRepositoryImpl) Create(ctx context.Context, user *domain.User) error {
 	err := r.queries.CreateUser(ctx, db.CreateUserParams{
-		ID:       user.ID,
-		Username: user.Username,
-		TeamName: user.TeamName,
-		IsActive: user.IsActive,
+		ID:             user.ID,
+		Username:       user.Username,
+		TeamName:       user.TeamName,
+		IsActive:       user.IsActive,
+		MaxOpenReviews: toInt32Ptr(user.MaxOpenReviews),
 	})
 	if err != nil {
 		r.logger.Error("failed to create user",
@@ -51,10 +54,11 @@ func (r *UserRepositoryImpl) Create(ctx context.Context, user *domain.User) erro
 // Update updates an existing user
 func (r *UserRepositoryImpl) Update(ctx context.Context, user *domain.User) error {
 	err := r.queries.UpdateUser(ctx, db.UpdateUserParams{
-		ID:       user.ID,
-		Username: user.Username,
-		TeamName: user.TeamName,
-		IsActive: user.IsActive,
+		ID:             user.ID,
+		Username:       user.Username,
+		TeamName:       user.TeamName,
+		IsActive:       user.IsActive,
+		MaxOpenReviews: toInt32Ptr(user.MaxOpenReviews),
 	})
 	if err != nil {
 		r.logger.Error("failed to update user",
@@ -71,10 +75,11 @@ func (r *UserRepositoryImpl) Update(ctx context.Context, user *domain.User) erro
 // Upsert creates or updates a user
 func (r *UserRepositoryImpl) Upsert(ctx context.Context, user *domain.User) error {
 	err := r.queries.UpsertUser(ctx, db.UpsertUserParams{
-		ID:       user.ID,
-		Username: user.Username,
-		TeamName: user.TeamName,
-		IsActive: user.IsActive,
+		ID:             user.ID,
+		Username:       user.Username,
+		TeamName:       user.TeamName,
+		IsActive:       user.IsActive,
+		MaxOpenReviews: toInt32Ptr(user.MaxOpenReviews),
 	})
 	if err != nil {
 		r.logger.Error("failed to upsert user",
@@ -102,12 +107,27 @@ func (r *UserRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.Us
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	return &domain.User{
-		ID:       dbUser.ID,
-		Username: dbUser.Username,
-		TeamName: dbUser.TeamName,
-		IsActive: dbUser.IsActive,
-	}, nil
+	user := userFromDB(dbUser)
+	return &user, nil
+}
+
+// GetByIDs retrieves the users with the given IDs ordered by ID; unknown IDs are skipped
+func (r *UserRepositoryImpl) GetByIDs(ctx context.Context, ids []string) ([]domain.User, error) {
+	dbUsers, err := r.queries.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		r.logger.Error("failed to get users by IDs",
+			slog.Int("count", len(ids)),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to get users by IDs: %w", err)
+	}
+
+	users := make([]domain.User, len(dbUsers))
+	for i, u := range dbUsers {
+		users[i] = userFromDB(u)
+	}
+
+	return users, nil
 }
 
 // GetByTeam retrieves all users in a team
@@ -123,12 +143,34 @@ func (r *UserRepositoryImpl) GetByTeam(ctx context.Context, teamName string) ([]
 
 	users := make([]domain.User, len(dbUsers))
 	for i, u := range dbUsers {
-		users[i] = domain.User{
-			ID:       u.ID,
-			Username: u.Username,
-			TeamName: u.TeamName,
-			IsActive: u.IsActive,
-		}
+		users[i] = userFromDB(u)
+	}
+
+	return users, nil
+}
+
+// Search retrieves up to filter.Limit users whose ID or username contains filter.Query
+// An exact ID match comes first, then ID or username prefixes, then the rest by username
+func (r *UserRepositoryImpl) Search(ctx context.Context, filter domain.UserSearch) ([]domain.User, error) {
+	escaped := likeEscaper.Replace(filter.Query)
+	dbUsers, err := r.queries.SearchUsers(ctx, db.SearchUsersParams{
+		Pattern:  "%" + escaped + "%",
+		IsActive: filter.IsActive,
+		Query:    filter.Query,
+		Prefix:   escaped + "%",
+		MaxRows:  int32(filter.Limit),
+	})
+	if err != nil {
+		r.logger.Error("failed to search users",
+			slog.String("query", filter.Query),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	users := make([]domain.User, len(dbUsers))
+	for i, u := range dbUsers {
+		users[i] = userFromDB(u)
 	}
 
 	return users, nil
@@ -156,6 +198,27 @@ func (r *UserRepositoryImpl) SetIsActive(ctx context.Context, userID string, isA
 	return nil
 }
 
+// SetMaxOpenReviews updates the user's open reviews limit (nil removes the limit)
+func (r *UserRepositoryImpl) SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) error {
+	err := r.queries.SetUserMaxOpenReviews(ctx, db.SetUserMaxOpenReviewsParams{
+		ID:             userID,
+		MaxOpenReviews: toInt32Ptr(maxOpenReviews),
+	})
+	if err != nil {
+		r.logger.Error("failed to set user max open reviews",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to set user max open reviews: %w", err)
+	}
+
+	r.logger.Info("user max open reviews updated",
+		slog.String("user_id", userID),
+		slog.Any("max_open_reviews", maxOpenReviews),
+	)
+	return nil
+}
+
 // GetActiveByTeam retrieves all active users in a team excluding specific user
 func (r *UserRepositoryImpl) GetActiveByTeam(ctx context.Context, teamName string, excludeUserID string) ([]domain.User, error) {
 	dbUsers, err := r.queries.GetActiveUsersByTeam(ctx, db.GetActiveUsersByTeamParams{
@@ -172,12 +235,7 @@ func (r *UserRepositoryImpl) GetActiveByTeam(ctx context.Context, teamName strin
 
 	users := make([]domain.User, len(dbUsers))
 	for i, u := range dbUsers {
-		users[i] = domain.User{
-			ID:       u.ID,
-			Username: u.Username,
-			TeamName: u.TeamName,
-			IsActive: u.IsActive,
-		}
+		users[i] = userFromDB(u)
 	}
 
 	return users, nil
@@ -236,3 +294,447 @@ func (r *UserRepositoryImpl) DeactivateTeamUsers(ctx context.Context, teamName s
 	)
 	return int(rowsAffected), nil
 }
+
+// DeactivateWithReassignment deactivates a user and reassigns their OPEN reviews
+// to active members of the PR authors' teams in a single transaction
+func (r *UserRepositoryImpl) DeactivateWithReassignment(ctx context.Context, userID string) (*domain.ReassignmentReport, error) {
+	txCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(txCtx)
+	if err != nil {
+		r.logger.Error("failed to begin transaction",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.Background())
+			r.logger.Error("panic in DeactivateWithReassignment transaction",
+				slog.String("user_id", userID),
+				slog.Any("panic", p),
+			)
+			panic(p)
+		}
+		_ = tx.Rollback(context.Background())
+	}()
+
+	qtx := r.queries.WithTx(tx)
+
+	err = qtx.SetUserIsActive(txCtx, db.SetUserIsActiveParams{
+		ID:       userID,
+		IsActive: false,
+	})
+	if err != nil {
+		r.logger.Error("failed to deactivate user in transaction",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to deactivate user: %w", err)
+	}
+
+	report, err := reassignOpenReviews(txCtx, qtx, []string{userID}, domain.ReasonDeactivation)
+	if err != nil {
+		r.logger.Error("failed to reassign open reviews in transaction",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	if err := tx.Commit(txCtx); err != nil {
+		r.logger.Error("failed to commit transaction",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.Info("user deactivated with reassignment",
+		slog.String("user_id", userID),
+		slog.Int("reassigned", len(report.Reassigned)),
+		slog.Int("left_short", len(report.LeftShort)),
+	)
+	return report, nil
+}
+
+// DeactivateTeamWithReassignment deactivates all users in a team and reassigns
+// their OPEN reviews to active members of the PR authors' teams in a single transaction
+// Nobody in the team stays active, so reviews on the team's own PRs are reported as left short
+// Returns the number of deactivated users
+func (r *UserRepositoryImpl) DeactivateTeamWithReassignment(ctx context.Context, teamName string) (int, *domain.ReassignmentReport, error) {
+	txCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(txCtx)
+	if err != nil {
+		r.logger.Error("failed to begin transaction",
+			slog.String("team_name", teamName),
+			slog.String("error", err.Error()),
+		)
+		return 0, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.Background())
+			r.logger.Error("panic in DeactivateTeamWithReassignment transaction",
+				slog.String("team_name", teamName),
+				slog.Any("panic", p),
+			)
+			panic(p)
+		}
+		_ = tx.Rollback(context.Background())
+	}()
+
+	qtx := r.queries.WithTx(tx)
+
+	rowsAffected, err := qtx.DeactivateTeamUsers(txCtx, teamName)
+	if err != nil {
+		r.logger.Error("failed to deactivate team users in transaction",
+			slog.String("team_name", teamName),
+			slog.String("error", err.Error()),
+		)
+		return 0, nil, fmt.Errorf("failed to deactivate team users: %w", err)
+	}
+
+	members, err := qtx.GetUsersByTeam(txCtx, teamName)
+	if err != nil {
+		r.logger.Error("failed to get team members in transaction",
+			slog.String("team_name", teamName),
+			slog.String("error", err.Error()),
+		)
+		return 0, nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	memberIDs := make([]string, len(members))
+	for i, m := range members {
+		memberIDs[i] = m.ID
+	}
+
+	report, err := reassignOpenReviews(txCtx, qtx, memberIDs, domain.ReasonDeactivation)
+	if err != nil {
+		r.logger.Error("failed to reassign open reviews in transaction",
+			slog.String("team_name", teamName),
+			slog.String("error", err.Error()),
+		)
+		return 0, nil, err
+	}
+
+	if err := tx.Commit(txCtx); err != nil {
+		r.logger.Error("failed to commit transaction",
+			slog.String("team_name", teamName),
+			slog.String("error", err.Error()),
+		)
+		return 0, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.Info("team users deactivated with reassignment",
+		slog.String("team_name", teamName),
+		slog.Int64("count", rowsAffected),
+		slog.Int("reassigned", len(report.Reassigned)),
+		slog.Int("left_short", len(report.LeftShort)),
+	)
+	return int(rowsAffected), report, nil
+}
+
+// Transfer moves a user to another team in a single transaction and records the move
+// in the membership history; policy decides what happens to the user's reviews on OPEN PRs:
+// they are kept, reassigned to active members of the PR authors' teams, or the transfer fails with ErrHasOpenReviews
+// Returns the old team and the reassignment report (empty unless reviews were reassigned)
+func (r *UserRepositoryImpl) Transfer(ctx context.Context, userID, teamName string, policy domain.TransferReviewPolicy) (string, *domain.ReassignmentReport, error) {
+	txCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(txCtx)
+	if err != nil {
+		r.logger.Error("failed to begin transaction",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return "", nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.Background())
+			r.logger.Error("panic in Transfer transaction",
+				slog.String("user_id", userID),
+				slog.Any("panic", p),
+			)
+			panic(p)
+		}
+		_ = tx.Rollback(context.Background())
+	}()
+
+	qtx := r.queries.WithTx(tx)
+
+	// The lock keeps a concurrent transfer or /team/add from moving the user in between
+	rows, err := qtx.GetUsersByIDsForUpdate(txCtx, []string{userID})
+	if err != nil {
+		r.logger.Error("failed to lock user in transaction",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return "", nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if len(rows) == 0 {
+		return "", nil, domain.ErrUserNotFound
+	}
+	fromTeam := rows[0].TeamName
+	if fromTeam == teamName {
+		return "", nil, fmt.Errorf("user is already in team %s: %w", teamName, domain.ErrInvalidInput)
+	}
+
+	report, err := prepareMoves(txCtx, qtx, []string{userID}, policy)
+	if err != nil {
+		if !moveRejected(err) {
+			r.logger.Error("failed to handle open reviews in transaction",
+				slog.String("user_id", userID),
+				slog.String("error", err.Error()),
+			)
+		}
+		return "", nil, err
+	}
+
+	err = qtx.SetUserTeam(txCtx, db.SetUserTeamParams{
+		ID:       userID,
+		TeamName: teamName,
+	})
+	if err != nil {
+		r.logger.Error("failed to move user in transaction",
+			slog.String("user_id", userID),
+			slog.String("team_name", teamName),
+			slog.String("error", err.Error()),
+		)
+		return "", nil, fmt.Errorf("failed to move user: %w", err)
+	}
+
+	policyName := string(policy)
+	err = writeMemberships(txCtx, qtx, db.InsertTeamMembershipsParams{
+		ToTeam:       teamName,
+		Source:       domain.MembershipSourceTransfer,
+		ReviewPolicy: &policyName,
+		UserIds:      []string{userID},
+		FromTeams:    []string{fromTeam},
+	})
+	if err != nil {
+		r.logger.Error("failed to record transfer in transaction",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return "", nil, err
+	}
+
+	if err := tx.Commit(txCtx); err != nil {
+		r.logger.Error("failed to commit transaction",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return "", nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.Info("user transferred in transaction",
+		slog.String("user_id", userID),
+		slog.String("from_team", fromTeam),
+		slog.String("to_team", teamName),
+		slog.String("review_policy", policyName),
+		slog.Int("reassigned", len(report.Reassigned)),
+		slog.Int("left_short", len(report.LeftShort)),
+	)
+	return fromTeam, report, nil
+}
+
+// GetMemberships retrieves the team membership history of a user, oldest first
+func (r *UserRepositoryImpl) GetMemberships(ctx context.Context, userID string) ([]domain.MembershipChange, error) {
+	rows, err := r.queries.GetTeamMemberships(ctx, userID)
+	if err != nil {
+		r.logger.Error("failed to get membership history",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to get membership history: %w", err)
+	}
+
+	history := make([]domain.MembershipChange, len(rows))
+	for i, row := range rows {
+		history[i] = membershipFromDB(row)
+	}
+
+	return history, nil
+}
+
+// GetOpenReviewCounts returns the number of OPEN PRs each user is reviewing
+// Users without open reviews are present in the result with zero count
+func (r *UserRepositoryImpl) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	rows, err := r.queries.CountOpenReviewsByUsers(ctx, userIDs)
+	if err != nil {
+		r.logger.Error("failed to count open reviews",
+			slog.Int("users_count", len(userIDs)),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to count open reviews: %w", err)
+	}
+
+	counts := make(map[string]int, len(userIDs))
+	for _, id := range userIDs {
+		counts[id] = 0
+	}
+	for _, row := range rows {
+		counts[row.ReviewerID] = int(row.OpenReviews)
+	}
+
+	return counts, nil
+}
+
+// GetReviewCandidates retrieves active team members with their open review load
+// in a single query, excluding given users (author, current reviewers, etc.)
+// Candidates are ordered by load, least loaded first
+func (r *UserRepositoryImpl) GetReviewCandidates(ctx context.Context, teamName string, excludeUserIDs []string) ([]domain.ReviewCandidate, error) {
+	if excludeUserIDs == nil {
+		excludeUserIDs = []string{}
+	}
+
+	rows, err := r.queries.GetReviewCandidates(ctx, db.GetReviewCandidatesParams{
+		TeamName:   teamName,
+		ExcludeIds: excludeUserIDs,
+	})
+	if err != nil {
+		r.logger.Error("failed to get review candidates",
+			slog.String("team_name", teamName),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to get review candidates: %w", err)
+	}
+
+	candidates := make([]domain.ReviewCandidate, len(rows))
+	for i, row := range rows {
+		candidates[i] = domain.ReviewCandidate{
+			User: domain.User{
+				ID:             row.ID,
+				Username:       row.Username,
+				TeamName:       row.TeamName,
+				IsActive:       row.IsActive,
+				MaxOpenReviews: fromInt32Ptr(row.MaxOpenReviews),
+			},
+			OpenReviews: int(row.OpenReviews),
+		}
+	}
+
+	return candidates, nil
+}
+
+// SetEmailSettings replaces the user's email notification preferences
+func (r *UserRepositoryImpl) SetEmailSettings(ctx context.Context, userID string, settings domain.EmailSettings) error {
+	timezone := settings.Timezone
+	if timezone == "" {
+		timezone = domain.DefaultTimezone
+	}
+	quietStart, quietEnd := quietHoursToDB(settings.QuietHours)
+
+	err := r.queries.SetUserEmailSettings(ctx, db.SetUserEmailSettingsParams{
+		ID:              userID,
+		Email:           settings.Email,
+		EmailOptOut:     settings.EmailOptOut,
+		QuietHoursStart: quietStart,
+		QuietHoursEnd:   quietEnd,
+		Timezone:        timezone,
+	})
+	if err != nil {
+		r.logger.Error("failed to set user email settings",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to set user email settings: %w", err)
+	}
+
+	r.logger.Info("user email settings updated", slog.String("user_id", userID))
+	return nil
+}
+
+// GetDigestRecipients retrieves active users who accept email and have PENDING reviews on OPEN PRs
+func (r *UserRepositoryImpl) GetDigestRecipients(ctx context.Context) ([]domain.DigestRecipient, error) {
+	dbUsers, err := r.queries.GetDigestRecipients(ctx)
+	if err != nil {
+		r.logger.Error("failed to get digest recipients", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get digest recipients: %w", err)
+	}
+
+	recipients := make([]domain.DigestRecipient, len(dbUsers))
+	for i, u := range dbUsers {
+		recipients[i] = domain.DigestRecipient{User: userFromDB(u)}
+		if u.LastDigestAt.Valid {
+			lastDigestAt := u.LastDigestAt.Time
+			recipients[i].LastDigestAt = &lastDigestAt
+		}
+	}
+
+	return recipients, nil
+}
+
+// SetLastDigestAt records when the user was sent the daily digest
+func (r *UserRepositoryImpl) SetLastDigestAt(ctx context.Context, userID string, at time.Time) error {
+	err := r.queries.SetUserLastDigestAt(ctx, db.SetUserLastDigestAtParams{
+		ID:           userID,
+		LastDigestAt: pgtype.Timestamptz{Time: at, Valid: true},
+	})
+	if err != nil {
+		r.logger.Error("failed to set user last digest time",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to set user last digest time: %w", err)
+	}
+	return nil
+}
+
+// userFromDB converts a sqlc user row into the domain model
+func userFromDB(u db.User) domain.User {
+	return domain.User{
+		ID:             u.ID,
+		Username:       u.Username,
+		TeamName:       u.TeamName,
+		IsActive:       u.IsActive,
+		MaxOpenReviews: fromInt32Ptr(u.MaxOpenReviews),
+		EmailSettings: domain.EmailSettings{
+			Email:       u.Email,
+			EmailOptOut: u.EmailOptOut,
+			QuietHours:  quietHoursFromDB(u.QuietHoursStart, u.QuietHoursEnd),
+			Timezone:    u.Timezone,
+		},
+	}
+}
+
+func quietHoursFromDB(start, end *int16) *domain.QuietHours {
+	if start == nil || end == nil {
+		return nil
+	}
+	return &domain.QuietHours{Start: int(*start), End: int(*end)}
+}
+
+func quietHoursToDB(q *domain.QuietHours) (*int16, *int16) {
+	if q == nil {
+		return nil, nil
+	}
+	start, end := int16(q.Start), int16(q.End) // #nosec G115 -- hours are validated to be 0-23
+	return &start, &end
+}
+
+func toInt32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	i := int32(*v) // #nosec G115 -- bounded by domain.MaxOpenReviewsLimit (User.Validate, UserUpdate.Validate)
+	return &i
+}
+
+func fromInt32Ptr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
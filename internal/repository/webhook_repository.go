@@ -0,0 +1,356 @@
+// Имплементация репозитория для работы с webhook-подписками и очередью доставок в базе данных postgresql
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"test_avito/internal/database/db"
+	"test_avito/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhookRepositoryImpl struct {
+	queries *db.Queries
+	pool    *pgxpool.Pool
+	logger  *slog.Logger
+}
+
+func NewWebhookRepository(pool *pgxpool.Pool, logger *slog.Logger) *WebhookRepositoryImpl {
+	return &WebhookRepositoryImpl{
+		queries: db.New(pool),
+		pool:    pool,
+		logger:  logger,
+	}
+}
+
+// CreateSubscription stores a new webhook subscription
+func (r *WebhookRepositoryImpl) CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	events := make([]string, len(sub.Events))
+	for i, eventType := range sub.Events {
+		events[i] = string(eventType)
+	}
+
+	row, err := r.queries.CreateWebhookSubscription(ctx, db.CreateWebhookSubscriptionParams{
+		Url:    sub.URL,
+		Secret: sub.Secret,
+		Events: events,
+	})
+	if err != nil {
+		r.logger.Error("failed to create webhook subscription",
+			slog.String("url", sub.URL),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	r.logger.Info("webhook subscription created", slog.Int64("subscription_id", row.ID))
+	return subscriptionFromDB(row), nil
+}
+
+// ListSubscriptions retrieves all webhook subscriptions
+func (r *WebhookRepositoryImpl) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	rows, err := r.queries.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		r.logger.Error("failed to list webhook subscriptions", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	subs := make([]domain.WebhookSubscription, len(rows))
+	for i, row := range rows {
+		subs[i] = *subscriptionFromDB(row)
+	}
+	return subs, nil
+}
+
+// DeleteSubscription removes a subscription together with its queued and failed deliveries
+func (r *WebhookRepositoryImpl) DeleteSubscription(ctx context.Context, id int64) error {
+	rows, err := r.queries.DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		r.logger.Error("failed to delete webhook subscription",
+			slog.Int64("subscription_id", id),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	r.logger.Info("webhook subscription deleted", slog.Int64("subscription_id", id))
+	return nil
+}
+
+// EnqueueDeliveries queues one delivery of the payload per subscription
+func (r *WebhookRepositoryImpl) EnqueueDeliveries(ctx context.Context, subscriptionIDs []int64, eventType domain.EventType, payload []byte) error {
+	if len(subscriptionIDs) == 0 {
+		return nil
+	}
+
+	err := r.queries.EnqueueWebhookDeliveries(ctx, db.EnqueueWebhookDeliveriesParams{
+		SubscriptionIds: subscriptionIDs,
+		EventType:       string(eventType),
+		Payload:         payload,
+	})
+	if err != nil {
+		r.logger.Error("failed to enqueue webhook deliveries",
+			slog.String("event_type", string(eventType)),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries takes up to limit deliveries due at now and hides them from
+// other workers until leaseUntil, so concurrent instances don't send the same delivery
+func (r *WebhookRepositoryImpl) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	rows, err := r.queries.ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{
+		Now:        pgtype.Timestamptz{Time: now, Valid: true},
+		MaxRows:    int32(limit), // #nosec G115 -- batch size comes from config
+		LeaseUntil: pgtype.Timestamptz{Time: leaseUntil, Valid: true},
+	})
+	if err != nil {
+		r.logger.Error("failed to claim webhook deliveries", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]domain.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = domain.WebhookDelivery{
+			ID:             row.ID,
+			SubscriptionID: row.SubscriptionID,
+			EventType:      domain.EventType(row.EventType),
+			Payload:        row.Payload,
+			Attempts:       int(row.Attempts),
+			URL:            row.Url,
+			Secret:         row.Secret,
+		}
+	}
+	return deliveries, nil
+}
+
+// MarkDelivered records a successful delivery
+func (r *WebhookRepositoryImpl) MarkDelivered(ctx context.Context, id int64, at time.Time) error {
+	err := r.queries.MarkWebhookDelivered(ctx, db.MarkWebhookDeliveredParams{
+		ID:          id,
+		DeliveredAt: pgtype.Timestamptz{Time: at, Valid: true},
+	})
+	if err != nil {
+		r.logger.Error("failed to mark webhook delivered",
+			slog.Int64("delivery_id", id),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to mark webhook delivered: %w", err)
+	}
+	return nil
+}
+
+// DeleteDelivered removes deliveries delivered before the given time
+func (r *WebhookRepositoryImpl) DeleteDelivered(ctx context.Context, before time.Time) (int, error) {
+	rows, err := r.queries.DeleteDeliveredWebhooksBefore(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		r.logger.Error("failed to delete delivered webhooks", slog.String("error", err.Error()))
+		return 0, fmt.Errorf("failed to delete delivered webhooks: %w", err)
+	}
+	return int(rows), nil
+}
+
+// ScheduleRetry records a failed attempt and when to try again
+func (r *WebhookRepositoryImpl) ScheduleRetry(ctx context.Context, id int64, attempts int, nextAttemptAt time.Time, lastError string) error {
+	err := r.queries.ScheduleWebhookRetry(ctx, db.ScheduleWebhookRetryParams{
+		ID:            id,
+		Attempts:      int32(attempts), // #nosec G115 -- bounded by max attempts
+		NextAttemptAt: pgtype.Timestamptz{Time: nextAttemptAt, Valid: true},
+		LastError:     &lastError,
+	})
+	if err != nil {
+		r.logger.Error("failed to schedule webhook retry",
+			slog.Int64("delivery_id", id),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to schedule webhook retry: %w", err)
+	}
+	return nil
+}
+
+// MoveToDeadLetter replaces a delivery that ran out of attempts with a dead letter in a transaction
+func (r *WebhookRepositoryImpl) MoveToDeadLetter(ctx context.Context, delivery *domain.WebhookDelivery, lastError string) error {
+	txCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(txCtx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.Background())
+			r.logger.Error("panic in MoveToDeadLetter transaction",
+				slog.Int64("delivery_id", delivery.ID),
+				slog.Any("panic", p),
+			)
+			panic(p)
+		}
+		_ = tx.Rollback(context.Background())
+	}()
+
+	qtx := r.queries.WithTx(tx)
+
+	if err := qtx.DeleteWebhookDelivery(txCtx, delivery.ID); err != nil {
+		r.logger.Error("failed to delete webhook delivery in transaction",
+			slog.Int64("delivery_id", delivery.ID),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to delete webhook delivery: %w", err)
+	}
+
+	err = qtx.CreateWebhookDeadLetter(txCtx, db.CreateWebhookDeadLetterParams{
+		SubscriptionID: delivery.SubscriptionID,
+		EventType:      string(delivery.EventType),
+		Payload:        delivery.Payload,
+		Attempts:       int32(delivery.Attempts), // #nosec G115 -- bounded by max attempts
+		LastError:      lastError,
+	})
+	if err != nil {
+		r.logger.Error("failed to create dead letter in transaction",
+			slog.Int64("delivery_id", delivery.ID),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to create dead letter: %w", err)
+	}
+
+	if err := tx.Commit(txCtx); err != nil {
+		r.logger.Error("failed to commit transaction",
+			slog.Int64("delivery_id", delivery.ID),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.Warn("webhook delivery moved to dead letters",
+		slog.Int64("delivery_id", delivery.ID),
+		slog.Int64("subscription_id", delivery.SubscriptionID),
+		slog.Int("attempts", delivery.Attempts),
+	)
+	return nil
+}
+
+// ListDeadLetters retrieves failed deliveries, newest first; nil subscriptionID lists all of them
+func (r *WebhookRepositoryImpl) ListDeadLetters(ctx context.Context, subscriptionID *int64) ([]domain.WebhookDeadLetter, error) {
+	rows, err := r.queries.ListWebhookDeadLetters(ctx, subscriptionID)
+	if err != nil {
+		r.logger.Error("failed to list dead letters", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	letters := make([]domain.WebhookDeadLetter, len(rows))
+	for i, row := range rows {
+		letters[i] = deadLetterFromDB(row)
+	}
+	return letters, nil
+}
+
+// Redeliver moves a dead letter back to the delivery queue with a fresh attempt budget in a transaction
+func (r *WebhookRepositoryImpl) Redeliver(ctx context.Context, deadLetterID int64) (*domain.WebhookDeadLetter, error) {
+	txCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(txCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.Background())
+			r.logger.Error("panic in Redeliver transaction",
+				slog.Int64("dead_letter_id", deadLetterID),
+				slog.Any("panic", p),
+			)
+			panic(p)
+		}
+		_ = tx.Rollback(context.Background())
+	}()
+
+	qtx := r.queries.WithTx(tx)
+
+	row, err := qtx.DeleteWebhookDeadLetter(txCtx, deadLetterID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrDeadLetterNotFound
+		}
+		r.logger.Error("failed to delete dead letter in transaction",
+			slog.Int64("dead_letter_id", deadLetterID),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to delete dead letter: %w", err)
+	}
+
+	err = qtx.EnqueueWebhookDeliveries(txCtx, db.EnqueueWebhookDeliveriesParams{
+		SubscriptionIds: []int64{row.SubscriptionID},
+		EventType:       row.EventType,
+		Payload:         row.Payload,
+	})
+	if err != nil {
+		r.logger.Error("failed to enqueue redelivery in transaction",
+			slog.Int64("dead_letter_id", deadLetterID),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to enqueue redelivery: %w", err)
+	}
+
+	if err := tx.Commit(txCtx); err != nil {
+		r.logger.Error("failed to commit transaction",
+			slog.Int64("dead_letter_id", deadLetterID),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.Info("dead letter queued for redelivery",
+		slog.Int64("dead_letter_id", deadLetterID),
+		slog.Int64("subscription_id", row.SubscriptionID),
+	)
+
+	letter := deadLetterFromDB(row)
+	return &letter, nil
+}
+
+func subscriptionFromDB(row db.WebhookSubscription) *domain.WebhookSubscription {
+	events := make([]domain.EventType, len(row.Events))
+	for i, eventType := range row.Events {
+		events[i] = domain.EventType(eventType)
+	}
+
+	sub := &domain.WebhookSubscription{
+		ID:     row.ID,
+		URL:    row.Url,
+		Secret: row.Secret,
+		Events: events,
+	}
+	if row.CreatedAt.Valid {
+		sub.CreatedAt = &row.CreatedAt.Time
+	}
+	return sub
+}
+
+func deadLetterFromDB(row db.WebhookDeadLetter) domain.WebhookDeadLetter {
+	return domain.WebhookDeadLetter{
+		ID:             row.ID,
+		SubscriptionID: row.SubscriptionID,
+		EventType:      domain.EventType(row.EventType),
+		Payload:        row.Payload,
+		Attempts:       int(row.Attempts),
+		LastError:      row.LastError,
+		FailedAt:       row.FailedAt.Time,
+	}
+}
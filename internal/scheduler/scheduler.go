@@ -0,0 +1,115 @@
+// Package scheduler runs periodic background jobs next to the HTTP server
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Job is a unit of periodic work
+// A returned error is logged; the job runs again on the next tick
+type Job func(ctx context.Context) error
+
+type task struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+type Scheduler struct {
+	tasks  []task
+	logger *slog.Logger
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(logger *slog.Logger) *Scheduler {
+	return &Scheduler{logger: logger}
+}
+
+// Every registers a job to run every interval; must be called before Start
+func (s *Scheduler) Every(name string, interval time.Duration, job Job) {
+	s.tasks = append(s.tasks, task{name: name, interval: interval, job: job})
+}
+
+// Start launches each registered job in its own goroutine
+// Jobs run until Stop is called or ctx is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, t := range s.tasks {
+		s.wg.Add(1)
+		go s.loop(ctx, t)
+	}
+
+	s.logger.Info("scheduler started", slog.Int("jobs", len(s.tasks)))
+}
+
+// Stop cancels running jobs and waits for them to return or for ctx to expire
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, t task) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.run(ctx, t)
+		}
+	}
+}
+
+// run executes one tick of a job; a panic is logged instead of taking the process down
+func (s *Scheduler) run(ctx context.Context, t task) {
+	defer func() {
+		if p := recover(); p != nil {
+			s.logger.Error("panic in scheduled job",
+				slog.String("job", t.name),
+				slog.Any("panic", p),
+			)
+		}
+	}()
+
+	start := time.Now()
+	if err := t.job(ctx); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		s.logger.Error("scheduled job failed",
+			slog.String("job", t.name),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	s.logger.Debug("scheduled job finished",
+		slog.String("job", t.name),
+		slog.Duration("duration", time.Since(start)),
+	)
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"test_avito/internal/domain"
+)
+
+// CodeHostClient mirrors reviewer changes to the code host the PR lives on (e.g. GitHub "request reviewers")
+// Calls must not fail the operation that changed reviewers: implementations log their own errors
+// and skip PRs that don't belong to their code host
+type CodeHostClient interface {
+	RequestReviewers(ctx context.Context, prID string, reviewerIDs []string)
+	RemoveRequestedReviewers(ctx context.Context, prID string, reviewerIDs []string)
+}
+
+// NopCodeHost keeps reviewer assignments in the database only
+type NopCodeHost struct{}
+
+func (NopCodeHost) RequestReviewers(context.Context, string, []string) {}
+
+func (NopCodeHost) RemoveRequestedReviewers(context.Context, string, []string) {}
+
+// syncReviewers mirrors a committed reviewer change of one PR to the code host
+// Removal goes first so a reassignment never asks the code host for more reviewers than the PR has
+func syncReviewers(ctx context.Context, codeHost CodeHostClient, prID string, added, removed []string) {
+	if len(removed) > 0 {
+		codeHost.RemoveRequestedReviewers(ctx, prID, removed)
+	}
+	if len(added) > 0 {
+		codeHost.RequestReviewers(ctx, prID, added)
+	}
+}
+
+// syncReassignment mirrors a committed bulk reassignment to the code host:
+// every reviewer taken off a PR is removed and every replacement is requested
+func syncReassignment(ctx context.Context, codeHost CodeHostClient, report *domain.ReassignmentReport) {
+	if report == nil {
+		return
+	}
+	for _, item := range report.Reassigned {
+		syncReviewers(ctx, codeHost, item.PullRequestID, []string{item.NewReviewerID}, []string{item.OldReviewerID})
+	}
+	for _, item := range report.LeftShort {
+		syncReviewers(ctx, codeHost, item.PullRequestID, nil, []string{item.OldReviewerID})
+	}
+}
+
+// AsyncCodeHost makes code host calls in the background, so requests and code host webhooks
+// don't wait for the code host API
+// Calls are made one at a time in the order they were queued, so a removal is never overtaken
+// by the request that follows it; when the queue is full the call is logged and dropped
+type AsyncCodeHost struct {
+	client CodeHostClient
+	calls  chan codeHostCall
+	done   chan struct{}
+	logger *slog.Logger
+}
+
+type codeHostCall struct {
+	ctx         context.Context
+	prID        string
+	reviewerIDs []string
+	remove      bool
+}
+
+func NewAsyncCodeHost(client CodeHostClient, queueSize int, logger *slog.Logger) *AsyncCodeHost {
+	c := &AsyncCodeHost{
+		client: client,
+		calls:  make(chan codeHostCall, queueSize),
+		done:   make(chan struct{}),
+		logger: logger,
+	}
+	go c.run()
+	return c
+}
+
+func (c *AsyncCodeHost) RequestReviewers(ctx context.Context, prID string, reviewerIDs []string) {
+	c.enqueue(codeHostCall{ctx: ctx, prID: prID, reviewerIDs: reviewerIDs})
+}
+
+func (c *AsyncCodeHost) RemoveRequestedReviewers(ctx context.Context, prID string, reviewerIDs []string) {
+	c.enqueue(codeHostCall{ctx: ctx, prID: prID, reviewerIDs: reviewerIDs, remove: true})
+}
+
+// Close stops accepting calls and waits until the queued ones are made
+func (c *AsyncCodeHost) Close(ctx context.Context) error {
+	close(c.calls)
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *AsyncCodeHost) enqueue(call codeHostCall) {
+	// The request context is canceled once the response is written
+	call.ctx = context.WithoutCancel(call.ctx)
+	call.reviewerIDs = slices.Clone(call.reviewerIDs)
+
+	select {
+	case c.calls <- call:
+	default:
+		c.logger.Warn("code host queue is full, reviewer sync dropped",
+			slog.String("pr_id", call.prID),
+			slog.Any("reviewer_ids", call.reviewerIDs),
+			slog.Bool("remove", call.remove),
+		)
+	}
+}
+
+func (c *AsyncCodeHost) run() {
+	defer close(c.done)
+	for call := range c.calls {
+		if call.remove {
+			c.client.RemoveRequestedReviewers(call.ctx, call.prID, call.reviewerIDs)
+		} else {
+			c.client.RequestReviewers(call.ctx, call.prID, call.reviewerIDs)
+		}
+	}
+}
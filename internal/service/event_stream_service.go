@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/repository"
+)
+
+// EventStreamOptions controls the SSE event stream
+type EventStreamOptions struct {
+	// PollInterval is how often open streams look for events appended by other instances
+	PollInterval time.Duration
+	// HeartbeatInterval is how often an idle stream sends a keep-alive so proxies don't drop it
+	HeartbeatInterval time.Duration
+	// GapWait is how long a missing sequence number is waited for before it is skipped:
+	// numbers are taken before commit, so a lower one can become visible after a higher one
+	GapWait time.Duration
+	// BatchSize limits how many events one read returns
+	BatchSize int
+	// Retention is how long events are kept for clients to resume from (0 = forever)
+	Retention time.Duration
+}
+
+// DefaultEventStreamOptions are used for zero fields of EventStreamOptions
+var DefaultEventStreamOptions = EventStreamOptions{
+	PollInterval:      time.Second,
+	HeartbeatInterval: 15 * time.Second,
+	GapWait:           2 * time.Second,
+	BatchSize:         100,
+	Retention:         24 * time.Hour,
+}
+
+// EventStreamService persists events to a sequenced stream and feeds them to SSE clients
+// It implements EventEmitter; appending wakes up streams of this instance at once,
+// events appended by other instances are picked up within PollInterval
+type EventStreamService struct {
+	repo     repository.StreamRepository
+	userRepo repository.UserRepository
+	teamRepo repository.TeamRepository
+	opts     EventStreamOptions
+	logger   *slog.Logger
+
+	mu        sync.Mutex
+	changed   chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func NewEventStreamService(
+	repo repository.StreamRepository,
+	userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository,
+	opts EventStreamOptions,
+	logger *slog.Logger,
+) *EventStreamService {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultEventStreamOptions.PollInterval
+	}
+	if opts.HeartbeatInterval <= 0 {
+		opts.HeartbeatInterval = DefaultEventStreamOptions.HeartbeatInterval
+	}
+	if opts.GapWait <= 0 {
+		opts.GapWait = DefaultEventStreamOptions.GapWait
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultEventStreamOptions.BatchSize
+	}
+	return &EventStreamService{
+		repo:     repo,
+		userRepo: userRepo,
+		teamRepo: teamRepo,
+		opts:     opts,
+		logger:   logger,
+		changed:  make(chan struct{}),
+		closed:   make(chan struct{}),
+	}
+}
+
+// Emit appends the event to the stream with the team and users it concerns
+func (s *EventStreamService) Emit(ctx context.Context, event domain.Event) {
+	seq, err := s.repo.Append(ctx, event, s.teamOf(ctx, event), event.InvolvedUsers())
+	if err != nil {
+		s.logger.Error("failed to append event to stream",
+			slog.String("event_type", string(event.Type)),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	s.logger.Debug("event appended to stream",
+		slog.String("event_id", event.ID),
+		slog.Int64("seq", seq),
+	)
+	s.wake()
+}
+
+// Open checks the filter and returns the sequence number the stream starts after:
+// lastEventID when the client resumes, otherwise the newest event, so only new events are sent
+func (s *EventStreamService) Open(ctx context.Context, filter domain.StreamFilter, lastEventID *int64) (int64, error) {
+	if filter.TeamName != "" {
+		exists, err := s.teamRepo.Exists(ctx, filter.TeamName)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check team existence: %w", err)
+		}
+		if !exists {
+			return 0, domain.ErrTeamNotFound
+		}
+	}
+	if filter.UserID != "" {
+		exists, err := s.userRepo.Exists(ctx, filter.UserID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check user existence: %w", err)
+		}
+		if !exists {
+			return 0, domain.ErrUserNotFound
+		}
+	}
+
+	if lastEventID != nil {
+		if *lastEventID < 0 {
+			return 0, domain.ErrInvalidInput
+		}
+		return *lastEventID, nil
+	}
+	return s.repo.LatestSeq(ctx)
+}
+
+// Stream passes events after afterSeq that match the filter to send, in order,
+// until ctx is canceled or the service is closed; heartbeat is called when the stream is idle
+// An error from send or heartbeat (the client went away) ends the stream
+func (s *EventStreamService) Stream(
+	ctx context.Context,
+	filter domain.StreamFilter,
+	afterSeq int64,
+	send func(domain.StreamEvent) error,
+	heartbeat func() error,
+) error {
+	poll := time.NewTicker(s.opts.PollInterval)
+	defer poll.Stop()
+	idle := time.NewTicker(s.opts.HeartbeatInterval)
+	defer idle.Stop()
+
+	cursor := afterSeq
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		// Taken before reading, so an event appended right after the read still wakes us up
+		changed := s.wakeup()
+
+		events, next, err := s.next(ctx, filter, cursor, time.Now())
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+		if len(events) > 0 {
+			idle.Reset(s.opts.HeartbeatInterval)
+		}
+		if next != cursor {
+			cursor = next
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.closed:
+			return nil
+		case <-changed:
+		case <-poll.C:
+		case <-idle.C:
+			if err := heartbeat(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Close ends all open streams; it is meant for http.Server.RegisterOnShutdown,
+// since Shutdown waits for connections to go idle and a stream never does
+func (s *EventStreamService) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+}
+
+// DeleteExpired removes events older than the retention and returns how many were removed
+func (s *EventStreamService) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	if s.opts.Retention <= 0 {
+		return 0, nil
+	}
+	deleted, err := s.repo.DeleteBefore(ctx, now.Add(-s.opts.Retention))
+	if err != nil {
+		return 0, err
+	}
+	if deleted > 0 {
+		s.logger.Info("expired stream events deleted", slog.Int("count", deleted))
+	}
+	return deleted, nil
+}
+
+// next reads one batch after afterSeq and returns the matching events and the sequence number to continue from
+func (s *EventStreamService) next(ctx context.Context, filter domain.StreamFilter, afterSeq int64, now time.Time) ([]domain.StreamEvent, int64, error) {
+	batch, err := s.repo.ListAfter(ctx, afterSeq, s.opts.BatchSize)
+	if err != nil {
+		return nil, afterSeq, err
+	}
+
+	cursor := afterSeq
+	var events []domain.StreamEvent
+	for _, event := range batch {
+		// A gap is either an append still being committed or a rolled back one:
+		// wait for it a little, then move past it
+		if event.Seq != cursor+1 && now.Sub(event.CreatedAt) < s.opts.GapWait {
+			break
+		}
+		cursor = event.Seq
+		if filter.Matches(event) {
+			events = append(events, event)
+		}
+	}
+	return events, cursor, nil
+}
+
+// teamOf returns the team an event belongs to: the PR author's team, the deactivated user or team,
+// or the team a user was transferred to
+func (s *EventStreamService) teamOf(ctx context.Context, event domain.Event) *string {
+	var authorID string
+	switch data := event.Data.(type) {
+	case domain.PREventData:
+		if data.PR != nil {
+			authorID = data.PR.AuthorID
+		}
+	case domain.ReviewerReassignedData:
+		if data.PR != nil {
+			authorID = data.PR.AuthorID
+		}
+	case domain.UserDeactivatedData:
+		if data.User != nil {
+			return &data.User.TeamName
+		}
+	case domain.UserTransferredData:
+		if data.User != nil {
+			return &data.User.TeamName
+		}
+	case domain.TeamDeactivatedData:
+		return &data.TeamName
+	}
+	if authorID == "" {
+		return nil
+	}
+
+	author, err := s.userRepo.GetByID(ctx, authorID)
+	if err != nil {
+		s.logger.Warn("failed to get PR author team for stream event",
+			slog.String("event_id", event.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil
+	}
+	return &author.TeamName
+}
+
+func (s *EventStreamService) wakeup() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.changed
+}
+
+func (s *EventStreamService) wake() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"test_avito/internal/domain"
+)
+
+// EventEmitter publishes domain events after a change is committed
+// Emit must not fail the operation that produced the event: implementations log their own errors
+type EventEmitter interface {
+	Emit(ctx context.Context, event domain.Event)
+}
+
+// NopEmitter drops every event
+type NopEmitter struct{}
+
+func (NopEmitter) Emit(context.Context, domain.Event) {}
+
+// MultiEmitter hands every event to each of its emitters in order
+type MultiEmitter []EventEmitter
+
+func (m MultiEmitter) Emit(ctx context.Context, event domain.Event) {
+	for _, e := range m {
+		e.Emit(ctx, event)
+	}
+}
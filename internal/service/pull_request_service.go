@@ -2,9 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"math/rand"
+	"time"
 
 	"test_avito/internal/domain"
 	"test_avito/internal/repository"
@@ -13,23 +14,46 @@ import (
 type PullRequestService struct {
 	prRepo   repository.PullRequestRepository
 	userRepo repository.UserRepository
+	teamRepo repository.TeamRepository
+	selector ReviewerSelector
+	events   EventEmitter
+	codeHost CodeHostClient
 	logger   *slog.Logger
 }
 
 func NewPullRequestService(
 	prRepo repository.PullRequestRepository,
 	userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository,
+	selector ReviewerSelector,
+	events EventEmitter,
+	codeHost CodeHostClient,
 	logger *slog.Logger,
 ) *PullRequestService {
 	return &PullRequestService{
 		prRepo:   prRepo,
 		userRepo: userRepo,
+		teamRepo: teamRepo,
+		selector: selector,
+		events:   events,
+		codeHost: codeHost,
 		logger:   logger,
 	}
 }
 
-// CreatePR creates a new PR and automatically assigns up to 2 reviewers from author's team
+// CreatePR creates a new PR and automatically assigns up to max_reviewers (team policy) from author's team
+// Reviewers are picked by the configured ReviewerSelector; members at their review limit are skipped
 func (s *PullRequestService) CreatePR(ctx context.Context, prID, prName, authorID string) (*domain.PullRequest, error) {
+	return s.createPR(ctx, prID, prName, authorID, false)
+}
+
+// CreateDraftPR creates a new PR in DRAFT status without reviewers
+// Reviewers are assigned when the draft is marked ready (see MarkReady)
+func (s *PullRequestService) CreateDraftPR(ctx context.Context, prID, prName, authorID string) (*domain.PullRequest, error) {
+	return s.createPR(ctx, prID, prName, authorID, true)
+}
+
+func (s *PullRequestService) createPR(ctx context.Context, prID, prName, authorID string, draft bool) (*domain.PullRequest, error) {
 	if prID == "" || prName == "" || authorID == "" {
 		return nil, domain.ErrInvalidInput
 	}
@@ -37,6 +61,7 @@ func (s *PullRequestService) CreatePR(ctx context.Context, prID, prName, authorI
 	s.logger.Info("creating PR",
 		slog.String("pr_id", prID),
 		slog.String("author_id", authorID),
+		slog.Bool("draft", draft),
 	)
 
 	exists, err := s.prRepo.Exists(ctx, prID)
@@ -52,46 +77,265 @@ func (s *PullRequestService) CreatePR(ctx context.Context, prID, prName, authorI
 		return nil, fmt.Errorf("author not found: %w", err)
 	}
 
-	activeMembers, err := s.userRepo.GetActiveByTeam(ctx, author.TeamName, authorID)
+	if draft {
+		pr := domain.NewPullRequest(prID, prName, authorID)
+		pr.Status = domain.PRStatusDraft
+
+		if err := s.prRepo.Create(ctx, pr, ""); err != nil {
+			return nil, fmt.Errorf("failed to create PR: %w", err)
+		}
+
+		s.logger.Info("draft PR created", slog.String("pr_id", prID))
+		s.events.Emit(ctx, domain.NewEvent(domain.EventPRCreated, domain.PREventData{PR: pr}))
+		return pr, nil
+	}
+
+	policy, err := s.teamRepo.GetPolicy(ctx, author.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team policy: %w", err)
+	}
+
+	candidates, err := s.userRepo.GetReviewCandidates(ctx, author.TeamName, []string{authorID})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team members: %w", err)
 	}
 
 	pr := domain.NewPullRequest(prID, prName, authorID)
 
-	reviewers := s.selectRandomReviewers(activeMembers, 2)
+	reviewers, err := s.selector.Select(ctx, author.TeamName, candidates, policy.MaxReviewers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviewers: %w", err)
+	}
 	pr.AssignedReviewers = reviewers
+	pr.Reviews = domain.PendingReviews(reviewers)
+
+	// Auto-assignment is best effort: the PR is created even if the team can't satisfy min_reviewers
+	if len(reviewers) < policy.MinReviewers {
+		s.logger.Warn("PR is short of reviewers",
+			slog.String("pr_id", prID),
+			slog.Int("assigned", len(reviewers)),
+			slog.Int("min_reviewers", policy.MinReviewers),
+		)
+	}
 
-	if err := s.prRepo.Create(ctx, pr); err != nil {
+	if err := s.prRepo.Create(ctx, pr, s.selector.Name()); err != nil {
 		return nil, fmt.Errorf("failed to create PR: %w", err)
 	}
 
 	s.logger.Info("PR created",
 		slog.String("pr_id", prID),
 		slog.Int("reviewers_assigned", len(reviewers)),
+		slog.String("strategy", s.selector.Name()),
 	)
 
+	s.events.Emit(ctx, domain.NewEvent(domain.EventPRCreated, domain.PREventData{PR: pr}))
+	s.emitReviewersAssigned(ctx, pr, reviewers)
+	syncReviewers(ctx, s.codeHost, prID, reviewers, nil)
+
 	return pr, nil
 }
 
-func (s *PullRequestService) MergePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+// MergePR moves an OPEN PR to MERGED through the lifecycle state machine if it satisfies the author's team merge rule
+// (required_approvals and block_on_changes_requested); force bypasses the rule and is recorded on the PR
+// Merging an already merged PR is a no-op that returns it unchanged
+func (s *PullRequestService) MergePR(ctx context.Context, prID string, force bool) (*domain.PullRequest, error) {
 	if prID == "" {
 		return nil, domain.ErrInvalidInput
 	}
 
-	s.logger.Info("merging PR", slog.String("pr_id", prID))
+	s.logger.Info("merging PR",
+		slog.String("pr_id", prID),
+		slog.Bool("force", force),
+	)
+
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.IsMerged() {
+		s.logger.Info("PR already merged", slog.String("pr_id", prID))
+		return pr, nil
+	}
+
+	from := pr.Status
+	if err := pr.Apply(domain.PRActionMerge); err != nil {
+		s.logger.Warn("PR status transition rejected",
+			slog.String("pr_id", prID),
+			slog.String("status", string(from)),
+			slog.String("action", string(domain.PRActionMerge)),
+		)
+		return nil, err
+	}
+
+	if !force {
+		author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return nil, err
+		}
+
+		policy, err := s.teamRepo.GetPolicy(ctx, author.TeamName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team policy: %w", err)
+		}
+
+		if err := policy.CheckMergeable(pr); err != nil {
+			s.logger.Warn("PR does not satisfy merge rule",
+				slog.String("pr_id", prID),
+				slog.Int("approvals", pr.Approvals()),
+				slog.Int("required_approvals", policy.RequiredApprovals),
+				slog.Bool("changes_requested", pr.HasChangesRequested()),
+			)
+			return nil, err
+		}
+	} else {
+		s.logger.Warn("PR force merged, team merge rule bypassed",
+			slog.String("pr_id", prID),
+			slog.Int("approvals", pr.Approvals()),
+		)
+	}
 
-	pr, err := s.prRepo.Merge(ctx, prID)
+	pr, err = s.prRepo.Merge(ctx, prID, force)
 	if err != nil {
 		return nil, fmt.Errorf("failed to merge PR: %w", err)
 	}
 
-	s.logger.Info("PR merged", slog.String("pr_id", prID))
+	s.logger.Info("PR merged",
+		slog.String("pr_id", prID),
+		slog.Bool("force_merged", pr.ForceMerged),
+	)
+
+	s.events.Emit(ctx, domain.NewEvent(domain.EventPRMerged, domain.PREventData{PR: pr}))
+
+	return pr, nil
+}
+
+// MarkReady moves a DRAFT PR to OPEN and auto-assigns reviewers like CreatePR does
+func (s *PullRequestService) MarkReady(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	if prID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	s.logger.Info("marking PR ready", slog.String("pr_id", prID))
+
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := pr.Status
+	if err := pr.Apply(domain.PRActionReady); err != nil {
+		return nil, err
+	}
+
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.teamRepo.GetPolicy(ctx, author.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team policy: %w", err)
+	}
+
+	exclude := append([]string{pr.AuthorID}, pr.AssignedReviewers...)
+	candidates, err := s.userRepo.GetReviewCandidates(ctx, author.TeamName, exclude)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	reviewers, err := s.selector.Select(ctx, author.TeamName, candidates, policy.MaxReviewers-len(pr.AssignedReviewers))
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviewers: %w", err)
+	}
+
+	if len(pr.AssignedReviewers)+len(reviewers) < policy.MinReviewers {
+		s.logger.Warn("PR is short of reviewers",
+			slog.String("pr_id", prID),
+			slog.Int("assigned", len(pr.AssignedReviewers)+len(reviewers)),
+			slog.Int("min_reviewers", policy.MinReviewers),
+		)
+	}
+
+	if err := s.prRepo.Transition(ctx, pr, from, reviewers, s.selector.Name()); err != nil {
+		return nil, fmt.Errorf("failed to mark PR ready: %w", err)
+	}
+
+	pr, err = s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated PR: %w", err)
+	}
+
+	s.logger.Info("PR marked ready",
+		slog.String("pr_id", prID),
+		slog.Int("reviewers_assigned", len(reviewers)),
+		slog.String("strategy", s.selector.Name()),
+	)
+
+	s.emitReviewersAssigned(ctx, pr, reviewers)
+	syncReviewers(ctx, s.codeHost, prID, reviewers, nil)
+
+	return pr, nil
+}
+
+// ClosePR closes a DRAFT or OPEN PR without merging; its reviewers are frozen
+func (s *PullRequestService) ClosePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	return s.transition(ctx, prID, domain.PRActionClose)
+}
+
+// ReopenPR moves a CLOSED PR back to OPEN with the reviewers it had
+func (s *PullRequestService) ReopenPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	return s.transition(ctx, prID, domain.PRActionReopen)
+}
+
+// transition applies a lifecycle action that doesn't change reviewers
+func (s *PullRequestService) transition(ctx context.Context, prID string, action domain.PRAction) (*domain.PullRequest, error) {
+	if prID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	s.logger.Info("changing PR status",
+		slog.String("pr_id", prID),
+		slog.String("action", string(action)),
+	)
+
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := pr.Status
+	if err := pr.Apply(action); err != nil {
+		s.logger.Warn("PR status transition rejected",
+			slog.String("pr_id", prID),
+			slog.String("status", string(from)),
+			slog.String("action", string(action)),
+		)
+		return nil, err
+	}
+
+	if err := s.prRepo.Transition(ctx, pr, from, nil, ""); err != nil {
+		return nil, fmt.Errorf("failed to change PR status: %w", err)
+	}
+
+	pr, err = s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated PR: %w", err)
+	}
+
+	s.logger.Info("PR status changed",
+		slog.String("pr_id", prID),
+		slog.String("from", string(from)),
+		slog.String("to", string(pr.Status)),
+	)
 
 	return pr, nil
 }
 
-func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (string, *domain.PullRequest, error) {
+// ReassignReviewer replaces oldReviewerID on the PR
+// If newReviewerID is empty, the replacement is picked by the selector among active members
+// of the replaced reviewer's team; otherwise newReviewerID is validated and used as is
+func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) (string, *domain.PullRequest, error) {
 	if prID == "" || oldReviewerID == "" {
 		return "", nil, domain.ErrInvalidInput
 	}
@@ -99,6 +343,7 @@ func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldRevi
 	s.logger.Info("reassigning reviewer",
 		slog.String("pr_id", prID),
 		slog.String("old_reviewer_id", oldReviewerID),
+		slog.String("requested_reviewer_id", newReviewerID),
 	)
 
 	pr, err := s.prRepo.GetByID(ctx, prID)
@@ -106,8 +351,8 @@ func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldRevi
 		return "", nil, err
 	}
 
-	if pr.IsMerged() {
-		return "", nil, domain.ErrPRMerged
+	if err := pr.EnsureOpen(); err != nil {
+		return "", nil, err
 	}
 
 	if !pr.HasReviewer(oldReviewerID) {
@@ -119,43 +364,41 @@ func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldRevi
 		return "", nil, fmt.Errorf("old reviewer not found: %w", err)
 	}
 
-	// Get active members from the reviewer's team, excluding:
-	// - the old reviewer
-	// - the PR author
-	// - current reviewers
-	activeMembers, err := s.userRepo.GetActiveByTeam(ctx, oldReviewer.TeamName, "")
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to get team members: %w", err)
-	}
-
-	candidates := make([]domain.User, 0)
-	for _, member := range activeMembers {
-		if member.ID == pr.AuthorID {
-			continue
-		}
-		if member.ID == oldReviewerID {
-			continue
-		}
-		isCurrentReviewer := false
-		for _, reviewerID := range pr.AssignedReviewers {
-			if member.ID == reviewerID {
-				isCurrentReviewer = true
-				break
+	strategy := s.selector.Name()
+	if newReviewerID != "" {
+		strategy = domain.ReasonExplicit
+		if _, err := s.validateExplicitReviewer(ctx, pr, newReviewerID, oldReviewer.TeamName); err != nil {
+			if errors.Is(err, domain.ErrReviewerNotInTeam) {
+				return "", nil, domain.ErrReplacementNotInTeam
 			}
+			return "", nil, err
+		}
+	} else {
+		// Get active members from the reviewer's team with their review load, excluding:
+		// - the PR author
+		// - current reviewers (including the old one)
+		exclude := append([]string{pr.AuthorID}, pr.AssignedReviewers...)
+		candidates, err := s.userRepo.GetReviewCandidates(ctx, oldReviewer.TeamName, exclude)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get team members: %w", err)
 		}
-		if !isCurrentReviewer {
-			candidates = append(candidates, member)
+
+		if len(candidates) == 0 {
+			return "", nil, domain.ErrNoAvailableReviewer
 		}
-	}
 
-	if len(candidates) == 0 {
-		return "", nil, domain.ErrNoAvailableReviewer
+		selected, err := s.selector.Select(ctx, oldReviewer.TeamName, candidates, 1)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to select reviewer: %w", err)
+		}
+		if len(selected) == 0 {
+			return "", nil, domain.ErrNoAvailableReviewer
+		}
+		newReviewerID = selected[0]
 	}
 
-	newReviewerID := s.selectRandomReviewers(candidates, 1)[0]
-
 	// Reassign reviewer in transaction (remove old + add new atomically)
-	if err := s.prRepo.ReassignReviewer(ctx, prID, oldReviewerID, newReviewerID); err != nil {
+	if err := s.prRepo.ReassignReviewer(ctx, prID, oldReviewerID, newReviewerID, strategy); err != nil {
 		return "", nil, fmt.Errorf("failed to reassign reviewer: %w", err)
 	}
 
@@ -168,8 +411,16 @@ func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldRevi
 		slog.String("pr_id", prID),
 		slog.String("old_reviewer_id", oldReviewerID),
 		slog.String("new_reviewer_id", newReviewerID),
+		slog.String("strategy", strategy),
 	)
 
+	s.events.Emit(ctx, domain.NewEvent(domain.EventReviewerReassigned, domain.ReviewerReassignedData{
+		PR:            pr,
+		OldReviewerID: oldReviewerID,
+		NewReviewerID: newReviewerID,
+	}))
+	syncReviewers(ctx, s.codeHost, prID, []string{newReviewerID}, []string{oldReviewerID})
+
 	return newReviewerID, pr, nil
 }
 
@@ -194,38 +445,182 @@ func (s *PullRequestService) GetPRsByReviewer(ctx context.Context, reviewerID st
 	return prs, nil
 }
 
-// selectRandomReviewers selects up to maxCount random reviewers from candidates
-func (s *PullRequestService) selectRandomReviewers(candidates []domain.User, maxCount int) []string {
-	if len(candidates) == 0 {
-		return []string{}
+// GetPendingReviews retrieves OPEN PRs where the reviewer has not submitted a review yet
+func (s *PullRequestService) GetPendingReviews(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
+	if reviewerID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	_, err := s.userRepo.GetByID(ctx, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("getting pending reviews for reviewer", slog.String("reviewer_id", reviewerID))
+
+	prs, err := s.prRepo.GetPendingPRsByReviewer(ctx, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending PRs by reviewer: %w", err)
+	}
+
+	return prs, nil
+}
+
+// ListReviewerPRs retrieves one page of PRs assigned to a reviewer
+// Zero Sort and Limit take the defaults; a cursor only continues a list with the same sort
+func (s *PullRequestService) ListReviewerPRs(ctx context.Context, filter domain.ReviewerPRFilter) (*domain.PRPage, error) {
+	if filter.ReviewerID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, domain.ErrInvalidInput
+	}
+	if err := normalizePRPage(&filter.Sort, &filter.Limit, filter.After, filter.CreatedAfter, filter.CreatedBefore); err != nil {
+		return nil, err
 	}
 
-	count := maxCount
-	if len(candidates) < count {
-		count = len(candidates)
+	_, err := s.userRepo.GetByID(ctx, filter.ReviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTeamExists(ctx, filter.TeamName); err != nil {
+		return nil, err
 	}
 
-	shuffled := make([]domain.User, len(candidates))
-	copy(shuffled, candidates)
+	s.logger.Info("listing PRs for reviewer",
+		slog.String("reviewer_id", filter.ReviewerID),
+		slog.String("sort", string(filter.Sort)),
+		slog.Bool("continued", filter.After != nil),
+	)
 
-	// Fisher-Yates shuffle
-	// #nosec G404 -- math/rand is sufficient for reviewer selection (not security-sensitive)
-	for i := len(shuffled) - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
-		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	page, err := s.prRepo.ListPRsByReviewer(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PRs by reviewer: %w", err)
 	}
 
-	reviewers := make([]string, count)
-	for i := 0; i < count; i++ {
-		reviewers[i] = shuffled[i].ID
+	return page, nil
+}
+
+// GetPR retrieves a PR with its reviewers and reviews
+func (s *PullRequestService) GetPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	if prID == "" {
+		return nil, domain.ErrInvalidInput
 	}
 
-	return reviewers
+	return s.prRepo.GetByID(ctx, prID)
+}
+
+// ListPRs retrieves one page of PRs matching the filter
+// Zero Sort and Limit take the defaults; a cursor only continues a list with the same sort
+// Named author, reviewer and team must exist; NoReviewers cannot be combined with ReviewerID
+func (s *PullRequestService) ListPRs(ctx context.Context, filter domain.PRFilter) (*domain.PRSummaryPage, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, domain.ErrInvalidInput
+	}
+	if filter.NoReviewers && filter.ReviewerID != "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if err := normalizePRPage(&filter.Sort, &filter.Limit, filter.After, filter.CreatedAfter, filter.CreatedBefore); err != nil {
+		return nil, err
+	}
+
+	for _, userID := range []string{filter.AuthorID, filter.ReviewerID} {
+		if userID == "" {
+			continue
+		}
+		exists, err := s.userRepo.Exists(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check user existence: %w", err)
+		}
+		if !exists {
+			return nil, domain.ErrUserNotFound
+		}
+	}
+	if err := s.checkTeamExists(ctx, filter.TeamName); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("listing PRs",
+		slog.String("sort", string(filter.Sort)),
+		slog.Bool("continued", filter.After != nil),
+	)
+
+	page, err := s.prRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PRs: %w", err)
+	}
+
+	return page, nil
+}
+
+// GetHistory retrieves the PR history: creation, reviewer changes with their reasons,
+// review submissions, status changes and merge, oldest first
+func (s *PullRequestService) GetHistory(ctx context.Context, prID string) ([]domain.PRHistoryEntry, error) {
+	if prID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	exists, err := s.prRepo.Exists(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check PR existence: %w", err)
+	}
+	if !exists {
+		return nil, domain.ErrPRNotFound
+	}
+
+	s.logger.Info("getting PR history", slog.String("pr_id", prID))
+
+	history, err := s.prRepo.GetHistory(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR history: %w", err)
+	}
+
+	return history, nil
+}
+
+// SubmitReview records a reviewer's verdict (APPROVED, CHANGES_REQUESTED or COMMENTED) on an OPEN PR
+// A reviewer may change their verdict while the PR stays open
+func (s *PullRequestService) SubmitReview(ctx context.Context, prID, reviewerID string, state domain.ReviewState) (*domain.PullRequest, error) {
+	if prID == "" || reviewerID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	s.logger.Info("submitting review",
+		slog.String("pr_id", prID),
+		slog.String("reviewer_id", reviewerID),
+		slog.String("state", string(state)),
+	)
+
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pr.SubmitReview(reviewerID, state); err != nil {
+		return nil, err
+	}
+
+	if err := s.prRepo.SetReviewState(ctx, prID, reviewerID, state); err != nil {
+		return nil, fmt.Errorf("failed to submit review: %w", err)
+	}
+
+	pr, err = s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated PR: %w", err)
+	}
+
+	s.logger.Info("review submitted",
+		slog.String("pr_id", prID),
+		slog.String("reviewer_id", reviewerID),
+		slog.String("state", string(state)),
+	)
+
+	return pr, nil
 }
 
 // AssignReviewersToPR assigns reviewers to an existing PR (must have no reviewers yet)
-// If reviewerIDs is empty or nil, assigns random reviewers from author's team (up to 2)
-// If reviewerIDs is provided, assigns those specific reviewers
+// If reviewerIDs is empty or nil, assigns reviewers from author's team picked by the selector (up to max_reviewers)
+// If reviewerIDs is provided, assigns those specific reviewers (their count must satisfy the team policy)
 func (s *PullRequestService) AssignReviewersToPR(ctx context.Context, prID string, reviewerIDs []string) (*domain.PullRequest, error) {
 	if prID == "" {
 		return nil, domain.ErrInvalidInput
@@ -241,70 +636,60 @@ func (s *PullRequestService) AssignReviewersToPR(ctx context.Context, prID strin
 		return nil, err
 	}
 
-	if pr.IsMerged() {
-		return nil, domain.ErrPRMerged
+	if err := pr.EnsureOpen(); err != nil {
+		return nil, err
+	}
+
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.teamRepo.GetPolicy(ctx, author.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team policy: %w", err)
 	}
 
 	var reviewersToAssign []string
+	strategy := s.selector.Name()
 
 	if len(reviewerIDs) == 0 {
-		author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
-		if err != nil {
-			return nil, err
-		}
-
-		activeMembers, err := s.userRepo.GetActiveByTeam(ctx, author.TeamName, pr.AuthorID)
+		candidates, err := s.userRepo.GetReviewCandidates(ctx, author.TeamName, []string{pr.AuthorID})
 		if err != nil {
 			return nil, err
 		}
 
-		if len(activeMembers) == 0 {
+		if len(candidates) == 0 {
 			return nil, domain.ErrNoAvailableReviewer
 		}
 
-		reviewersToAssign = s.selectRandomReviewers(activeMembers, 2)
-	} else {
-		if len(reviewerIDs) > 2 {
-			return nil, domain.ErrInvalidInput
-		}
-
-		author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+		reviewersToAssign, err = s.selector.Select(ctx, author.TeamName, candidates, policy.MaxReviewers)
 		if err != nil {
+			return nil, fmt.Errorf("failed to select reviewers: %w", err)
+		}
+	} else {
+		if err := policy.CheckReviewersCount(len(reviewerIDs)); err != nil {
+			s.logger.Warn("reviewers count violates team policy",
+				slog.String("pr_id", prID),
+				slog.Int("count", len(reviewerIDs)),
+				slog.Int("min_reviewers", policy.MinReviewers),
+				slog.Int("max_reviewers", policy.MaxReviewers),
+			)
 			return nil, err
 		}
 
 		for _, reviewerID := range reviewerIDs {
-			user, err := s.userRepo.GetByID(ctx, reviewerID)
-			if err != nil {
+			if _, err := s.validateExplicitReviewer(ctx, pr, reviewerID, author.TeamName); err != nil {
 				return nil, err
 			}
-			if !user.IsActive {
-				s.logger.Warn("reviewer is not active",
-					slog.String("reviewer_id", reviewerID),
-				)
-				return nil, domain.ErrUserNotActive
-			}
-			if user.TeamName != author.TeamName {
-				s.logger.Warn("reviewer not in author's team",
-					slog.String("reviewer_id", reviewerID),
-					slog.String("reviewer_team", user.TeamName),
-					slog.String("author_team", author.TeamName),
-				)
-				return nil, domain.ErrReviewerNotInTeam
-			}
-			if reviewerID == pr.AuthorID {
-				s.logger.Warn("attempt to assign author as reviewer",
-					slog.String("author_id", pr.AuthorID),
-				)
-				return nil, domain.ErrAuthorAsReviewer
-			}
 		}
 
 		reviewersToAssign = reviewerIDs
+		strategy = domain.ReasonExplicit
 	}
 
 	// Assign reviewers (repository will check if PR already has reviewers)
-	if err := s.prRepo.AssignReviewers(ctx, prID, reviewersToAssign); err != nil {
+	if err := s.prRepo.AssignReviewers(ctx, prID, reviewersToAssign, strategy); err != nil {
 		return nil, fmt.Errorf("failed to assign reviewers: %w", err)
 	}
 
@@ -319,5 +704,249 @@ func (s *PullRequestService) AssignReviewersToPR(ctx context.Context, prID strin
 		slog.Int("total_reviewers", len(pr.AssignedReviewers)),
 	)
 
+	s.emitReviewersAssigned(ctx, pr, reviewersToAssign)
+	syncReviewers(ctx, s.codeHost, prID, reviewersToAssign, nil)
+
 	return pr, nil
 }
+
+// AddReviewer adds a single reviewer to an OPEN PR that may already have reviewers
+// If reviewerID is empty, the reviewer is picked by the selector from the author's team
+// The PR may not exceed max_reviewers of the author's team policy
+func (s *PullRequestService) AddReviewer(ctx context.Context, prID, reviewerID string) (string, *domain.PullRequest, error) {
+	if prID == "" {
+		return "", nil, domain.ErrInvalidInput
+	}
+
+	s.logger.Info("adding reviewer to PR",
+		slog.String("pr_id", prID),
+		slog.String("requested_reviewer_id", reviewerID),
+	)
+
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := pr.EnsureOpen(); err != nil {
+		return "", nil, err
+	}
+
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	policy, err := s.teamRepo.GetPolicy(ctx, author.TeamName)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get team policy: %w", err)
+	}
+
+	if len(pr.AssignedReviewers) >= policy.MaxReviewers {
+		return "", nil, domain.ErrTooManyReviewers
+	}
+
+	strategy := s.selector.Name()
+	if reviewerID != "" {
+		strategy = domain.ReasonExplicit
+		if _, err := s.validateExplicitReviewer(ctx, pr, reviewerID, author.TeamName); err != nil {
+			return "", nil, err
+		}
+	} else {
+		exclude := append([]string{pr.AuthorID}, pr.AssignedReviewers...)
+		candidates, err := s.userRepo.GetReviewCandidates(ctx, author.TeamName, exclude)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get team members: %w", err)
+		}
+
+		if len(candidates) == 0 {
+			return "", nil, domain.ErrNoAvailableReviewer
+		}
+
+		selected, err := s.selector.Select(ctx, author.TeamName, candidates, 1)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to select reviewer: %w", err)
+		}
+		if len(selected) == 0 {
+			return "", nil, domain.ErrNoAvailableReviewer
+		}
+		reviewerID = selected[0]
+	}
+
+	if err := pr.AddReviewer(reviewerID, policy.MaxReviewers); err != nil {
+		return "", nil, err
+	}
+
+	// The checks above ran on a snapshot; the repository repeats the count check under a lock of the PR
+	if err := s.prRepo.AddReviewer(ctx, prID, reviewerID, strategy, policy.MaxReviewers); err != nil {
+		return "", nil, fmt.Errorf("failed to add reviewer: %w", err)
+	}
+
+	pr, err = s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get updated PR: %w", err)
+	}
+
+	s.logger.Info("reviewer added to PR",
+		slog.String("pr_id", prID),
+		slog.String("reviewer_id", reviewerID),
+		slog.String("strategy", strategy),
+		slog.Int("total_reviewers", len(pr.AssignedReviewers)),
+	)
+
+	s.emitReviewersAssigned(ctx, pr, []string{reviewerID})
+	syncReviewers(ctx, s.codeHost, prID, []string{reviewerID}, nil)
+
+	return reviewerID, pr, nil
+}
+
+// emitReviewersAssigned publishes pr.reviewers_assigned when the operation assigned anyone
+func (s *PullRequestService) emitReviewersAssigned(ctx context.Context, pr *domain.PullRequest, added []string) {
+	if len(added) == 0 {
+		return
+	}
+	s.events.Emit(ctx, domain.NewEvent(domain.EventReviewersAssigned, domain.PREventData{PR: pr, Added: added}))
+}
+
+// RemoveReviewer drops a single reviewer from an OPEN PR
+// The PR may not fall below min_reviewers of the author's team policy
+func (s *PullRequestService) RemoveReviewer(ctx context.Context, prID, reviewerID string) (*domain.PullRequest, error) {
+	if prID == "" || reviewerID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	s.logger.Info("removing reviewer from PR",
+		slog.String("pr_id", prID),
+		slog.String("reviewer_id", reviewerID),
+	)
+
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.teamRepo.GetPolicy(ctx, author.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team policy: %w", err)
+	}
+
+	if err := pr.RemoveReviewer(reviewerID); err != nil {
+		return nil, err
+	}
+
+	if len(pr.AssignedReviewers) < policy.MinReviewers {
+		s.logger.Warn("removal would leave PR short of reviewers",
+			slog.String("pr_id", prID),
+			slog.Int("remaining", len(pr.AssignedReviewers)),
+			slog.Int("min_reviewers", policy.MinReviewers),
+		)
+		return nil, domain.ErrTooFewReviewers
+	}
+
+	// The checks above ran on a snapshot; the repository repeats the count check under a lock of the PR
+	if err := s.prRepo.RemoveReviewer(ctx, prID, reviewerID, policy.MinReviewers); err != nil {
+		return nil, fmt.Errorf("failed to remove reviewer: %w", err)
+	}
+
+	pr, err = s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated PR: %w", err)
+	}
+
+	s.logger.Info("reviewer removed from PR",
+		slog.String("pr_id", prID),
+		slog.String("reviewer_id", reviewerID),
+		slog.Int("total_reviewers", len(pr.AssignedReviewers)),
+	)
+
+	syncReviewers(ctx, s.codeHost, prID, nil, []string{reviewerID})
+
+	return pr, nil
+}
+
+// validateExplicitReviewer checks a reviewer chosen by hand for the PR:
+// the user must be active, belong to teamName, not be the author or an already
+// assigned reviewer, and have capacity for one more open review
+func (s *PullRequestService) validateExplicitReviewer(ctx context.Context, pr *domain.PullRequest, reviewerID, teamName string) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		s.logger.Warn("reviewer is not active",
+			slog.String("reviewer_id", reviewerID),
+		)
+		return nil, domain.ErrUserNotActive
+	}
+	if user.TeamName != teamName {
+		s.logger.Warn("reviewer not in expected team",
+			slog.String("reviewer_id", reviewerID),
+			slog.String("reviewer_team", user.TeamName),
+			slog.String("expected_team", teamName),
+		)
+		return nil, domain.ErrReviewerNotInTeam
+	}
+	if reviewerID == pr.AuthorID {
+		s.logger.Warn("attempt to assign author as reviewer",
+			slog.String("author_id", pr.AuthorID),
+		)
+		return nil, domain.ErrAuthorAsReviewer
+	}
+	if pr.HasReviewer(reviewerID) {
+		return nil, domain.ErrAlreadyReviewer
+	}
+
+	openReviews, err := s.userRepo.GetOpenReviewCounts(ctx, []string{reviewerID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count open reviews: %w", err)
+	}
+	if !user.HasCapacity(openReviews[reviewerID]) {
+		s.logger.Warn("reviewer is at capacity",
+			slog.String("reviewer_id", reviewerID),
+			slog.Int("open_reviews", openReviews[reviewerID]),
+		)
+		return nil, domain.ErrReviewerAtCapacity
+	}
+
+	return user, nil
+}
+
+// normalizePRPage fills in the default sort and page size and checks the page parameters of a PR list
+func normalizePRPage(sort *domain.PRSort, limit *int, after *domain.PRCursor, createdAfter, createdBefore *time.Time) error {
+	if *sort == "" {
+		*sort = domain.PRSortNewest
+	}
+	if *limit == 0 {
+		*limit = domain.DefaultPRPageLimit
+	}
+	if !sort.IsValid() || *limit < 0 || *limit > domain.MaxPRPageLimit {
+		return domain.ErrInvalidInput
+	}
+	if createdAfter != nil && createdBefore != nil && !createdAfter.Before(*createdBefore) {
+		return domain.ErrInvalidInput
+	}
+	if after != nil && after.Sort != *sort {
+		return domain.ErrInvalidInput
+	}
+	return nil
+}
+
+// checkTeamExists returns ErrTeamNotFound for an unknown team; an empty name is not checked
+func (s *PullRequestService) checkTeamExists(ctx context.Context, teamName string) error {
+	if teamName == "" {
+		return nil
+	}
+	exists, err := s.teamRepo.Exists(ctx, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return domain.ErrTeamNotFound
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/repository"
+)
+
+// DefaultSLABatchSize limits how many overdue reviews one SLA pass handles
+const DefaultSLABatchSize = 100
+
+// SLAActor is the actor of PR changes made by the SLA job in the PR history
+const SLAActor = domain.ActorReviewSLA
+
+type ReviewSLAService struct {
+	prRepo    repository.PullRequestRepository
+	prService *PullRequestService
+	batchSize int
+	logger    *slog.Logger
+}
+
+func NewReviewSLAService(
+	prRepo repository.PullRequestRepository,
+	prService *PullRequestService,
+	batchSize int,
+	logger *slog.Logger,
+) *ReviewSLAService {
+	if batchSize <= 0 {
+		batchSize = DefaultSLABatchSize
+	}
+	return &ReviewSLAService{
+		prRepo:    prRepo,
+		prService: prService,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
+
+// CheckOverdueReviews handles reviews left PENDING longer than review_sla_minutes of the author's team at now
+// With sla_action=reassign the reviewer is replaced by the same rules as ReassignReviewer;
+// if nobody can replace them, the review is escalated instead
+// With sla_action=escalate the team lead takes the review over; if the lead can't
+// (author, already a reviewer, inactive or at capacity) the review is only marked escalated
+// Failures on single reviews are logged and don't stop the pass
+func (s *ReviewSLAService) CheckOverdueReviews(ctx context.Context, now time.Time) (*domain.SLAReport, error) {
+	ctx = domain.WithActor(ctx, SLAActor)
+
+	overdue, err := s.prRepo.GetOverdueReviews(ctx, now, s.batchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overdue reviews: %w", err)
+	}
+
+	report := domain.NewSLAReport()
+	if len(overdue) == 0 {
+		return report, nil
+	}
+
+	s.logger.Info("handling overdue reviews", slog.Int("count", len(overdue)))
+
+	for _, review := range overdue {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		if review.Action.OrDefault() == domain.SLAActionReassign {
+			newReviewerID, _, err := s.prService.ReassignReviewer(ctx, review.PullRequestID, review.ReviewerID, "")
+			if err == nil {
+				report.Reassigned = append(report.Reassigned, domain.ReviewerReassignment{
+					PullRequestID: review.PullRequestID,
+					OldReviewerID: review.ReviewerID,
+					NewReviewerID: newReviewerID,
+				})
+				continue
+			}
+
+			s.logger.Warn("failed to reassign overdue review, escalating",
+				slog.String("pr_id", review.PullRequestID),
+				slog.String("reviewer_id", review.ReviewerID),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		escalation, err := s.escalate(ctx, review, now)
+		if errors.Is(err, domain.ErrReviewerNotFound) {
+			// The reviewer was changed concurrently, nothing is overdue anymore
+			continue
+		}
+		if err != nil {
+			s.logger.Error("failed to escalate overdue review",
+				slog.String("pr_id", review.PullRequestID),
+				slog.String("reviewer_id", review.ReviewerID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		report.Escalated = append(report.Escalated, escalation)
+	}
+
+	s.logger.Info("overdue reviews handled",
+		slog.Int("reassigned", len(report.Reassigned)),
+		slog.Int("escalated", len(report.Escalated)),
+	)
+
+	return report, nil
+}
+
+// escalate hands an overdue review over to the team lead,
+// or marks it escalated when there is no lead able to take it
+func (s *ReviewSLAService) escalate(ctx context.Context, review domain.OverdueReview, now time.Time) (domain.ReviewerReassignment, error) {
+	escalation := domain.ReviewerReassignment{
+		PullRequestID: review.PullRequestID,
+		OldReviewerID: review.ReviewerID,
+	}
+
+	if review.LeadUserID != nil {
+		leadID, _, err := s.prService.ReassignReviewer(ctx, review.PullRequestID, review.ReviewerID, *review.LeadUserID)
+		if err == nil {
+			s.logger.Info("overdue review escalated to team lead",
+				slog.String("pr_id", review.PullRequestID),
+				slog.String("reviewer_id", review.ReviewerID),
+				slog.String("lead_user_id", leadID),
+			)
+			escalation.NewReviewerID = leadID
+			return escalation, nil
+		}
+
+		s.logger.Warn("team lead can't take overdue review",
+			slog.String("pr_id", review.PullRequestID),
+			slog.String("lead_user_id", *review.LeadUserID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	if err := s.prRepo.MarkEscalated(ctx, review.PullRequestID, review.ReviewerID, now); err != nil {
+		return escalation, err
+	}
+
+	s.logger.Warn("overdue review left without takeover",
+		slog.String("pr_id", review.PullRequestID),
+		slog.String("reviewer_id", review.ReviewerID),
+		slog.String("team_name", review.TeamName),
+	)
+
+	return escalation, nil
+}
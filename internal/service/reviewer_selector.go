@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/repository"
+)
+
+// Reviewer selection strategies (configured via REVIEWER_STRATEGY)
+const (
+	StrategyRandom      = "random"
+	StrategyRoundRobin  = "round_robin"
+	StrategyLeastLoaded = "least_loaded"
+)
+
+// ReviewerSelector chooses reviewers for a PR among eligible candidates
+type ReviewerSelector interface {
+	// Name returns the strategy name
+	Name() string
+	// Select returns up to count reviewer IDs from candidates of the given team
+	Select(ctx context.Context, teamName string, candidates []domain.ReviewCandidate, count int) ([]string, error)
+}
+
+// NewReviewerSelector creates a selector for the given strategy
+func NewReviewerSelector(strategy string, teamRepo repository.TeamRepository) (ReviewerSelector, error) {
+	switch strategy {
+	case StrategyRandom:
+		return &RandomSelector{}, nil
+	case StrategyRoundRobin:
+		return &RoundRobinSelector{teamRepo: teamRepo}, nil
+	case StrategyLeastLoaded:
+		return &LeastLoadedSelector{}, nil
+	default:
+		return nil, fmt.Errorf("unknown reviewer selection strategy: %s", strategy)
+	}
+}
+
+// RandomSelector picks reviewers uniformly at random
+type RandomSelector struct{}
+
+func (s *RandomSelector) Name() string {
+	return StrategyRandom
+}
+
+func (s *RandomSelector) Select(_ context.Context, _ string, candidates []domain.ReviewCandidate, count int) ([]string, error) {
+	return firstIDs(shuffleCandidates(candidates), count), nil
+}
+
+// RoundRobinSelector walks team members in a stable order, continuing
+// from the cursor persisted for the team by the previous selection
+// The cursor is advanced in one statement, so concurrent selections don't pick the same reviewers
+// by starting from the same cursor
+type RoundRobinSelector struct {
+	teamRepo repository.TeamRepository
+}
+
+func (s *RoundRobinSelector) Name() string {
+	return StrategyRoundRobin
+}
+
+func (s *RoundRobinSelector) Select(ctx context.Context, teamName string, candidates []domain.ReviewCandidate, count int) ([]string, error) {
+	if len(candidates) == 0 || count <= 0 {
+		return []string{}, nil
+	}
+
+	ordered := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		ordered[i] = candidate.ID
+	}
+	sort.Strings(ordered)
+
+	if count > len(ordered) {
+		count = len(ordered)
+	}
+
+	cursor, err := s.teamRepo.AdvanceReviewerCursor(ctx, teamName, ordered, count)
+	if err != nil {
+		return nil, err
+	}
+
+	// The picks are the count candidates ending at the new cursor, wrapping around
+	last := sort.SearchStrings(ordered, cursor)
+	reviewers := make([]string, count)
+	for i := 0; i < count; i++ {
+		reviewers[i] = ordered[(last-count+1+i+len(ordered))%len(ordered)]
+	}
+
+	return reviewers, nil
+}
+
+// LeastLoadedSelector prefers candidates with the fewest OPEN reviews,
+// breaking ties randomly
+type LeastLoadedSelector struct{}
+
+func (s *LeastLoadedSelector) Name() string {
+	return StrategyLeastLoaded
+}
+
+func (s *LeastLoadedSelector) Select(_ context.Context, _ string, candidates []domain.ReviewCandidate, count int) ([]string, error) {
+	// Shuffle first so that the stable sort keeps a random order among equals
+	ranked := shuffleCandidates(candidates)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].OpenReviews < ranked[j].OpenReviews
+	})
+
+	return firstIDs(ranked, count), nil
+}
+
+// shuffleCandidates returns a shuffled copy of candidates (Fisher-Yates shuffle)
+func shuffleCandidates(candidates []domain.ReviewCandidate) []domain.ReviewCandidate {
+	shuffled := make([]domain.ReviewCandidate, len(candidates))
+	copy(shuffled, candidates)
+
+	// #nosec G404 -- math/rand is sufficient for reviewer selection (not security-sensitive)
+	for i := len(shuffled) - 1; i > 0; i-- {
+		j := rand.Intn(i + 1)
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+
+	return shuffled
+}
+
+// firstIDs returns IDs of up to maxCount first candidates
+func firstIDs(candidates []domain.ReviewCandidate, maxCount int) []string {
+	count := maxCount
+	if len(candidates) < count {
+		count = len(candidates)
+	}
+	if count < 0 {
+		count = 0
+	}
+
+	ids := make([]string, count)
+	for i := 0; i < count; i++ {
+		ids[i] = candidates[i].ID
+	}
+
+	return ids
+}
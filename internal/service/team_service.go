@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 
 	"test_avito/internal/domain"
 	"test_avito/internal/repository"
@@ -12,25 +15,32 @@ import (
 type TeamService struct {
 	teamRepo repository.TeamRepository
 	userRepo repository.UserRepository
+	events   EventEmitter
+	codeHost CodeHostClient
 	logger   *slog.Logger
 }
 
 func NewTeamService(
 	teamRepo repository.TeamRepository,
 	userRepo repository.UserRepository,
+	events EventEmitter,
+	codeHost CodeHostClient,
 	logger *slog.Logger,
 ) *TeamService {
 	return &TeamService{
 		teamRepo: teamRepo,
 		userRepo: userRepo,
+		events:   events,
+		codeHost: codeHost,
 		logger:   logger,
 	}
 }
 
 // AddTeam creates or updates a team with members
-// If team exists, updates members (upsert)
+// If team exists, updates members (upsert); the reviewer policy is changed only when provided
+// Members of other teams are taken over only with AllowMoves, their OPEN reviews handled by MoveReviewPolicy
 func (s *TeamService) AddTeam(ctx context.Context, team *domain.Team) error {
-	if err := team.Validate(); err != nil {
+	if err := s.validateTeam(ctx, team, domain.TeamSyncUpsert); err != nil {
 		return err
 	}
 
@@ -39,24 +49,15 @@ func (s *TeamService) AddTeam(ctx context.Context, team *domain.Team) error {
 		slog.Int("members_count", len(team.Members)),
 	)
 
-	for i := range team.Members {
-		team.Members[i].TeamName = team.Name
-		if err := team.Members[i].Validate(); err != nil {
-			s.logger.Warn("invalid member data",
-				slog.String("user_id", team.Members[i].ID),
-				slog.String("error", err.Error()),
-			)
-			return fmt.Errorf("invalid member %s: %w", team.Members[i].ID, err)
-		}
-	}
-
 	exists, err := s.teamRepo.Exists(ctx, team.Name)
 	if err != nil {
 		return fmt.Errorf("failed to check team existence: %w", err)
 	}
 
+	var moves *domain.MemberMoves
 	if !exists {
-		if err := s.teamRepo.CreateWithMembers(ctx, team); err != nil {
+		moves, err = s.teamRepo.CreateWithMembers(ctx, team)
+		if err != nil {
 			return fmt.Errorf("failed to create team with members: %w", err)
 		}
 		s.logger.Info("team created with members",
@@ -64,7 +65,8 @@ func (s *TeamService) AddTeam(ctx context.Context, team *domain.Team) error {
 			slog.Int("members_count", len(team.Members)),
 		)
 	} else {
-		if err := s.teamRepo.UpdateMembers(ctx, team.Name, team.Members); err != nil {
+		moves, err = s.teamRepo.UpdateMembers(ctx, team)
+		if err != nil {
 			return fmt.Errorf("failed to update team members: %w", err)
 		}
 		s.logger.Info("team members updated",
@@ -73,9 +75,65 @@ func (s *TeamService) AddTeam(ctx context.Context, team *domain.Team) error {
 		)
 	}
 
+	syncReassignment(ctx, s.codeHost, moves.Reassignment)
+	s.emitMoves(ctx, moves.FromTeams, moves.Reassignment)
+
 	return nil
 }
 
+// ReplaceTeam creates or updates a team so that its active members are exactly the listed ones
+// Active members missing from the list are deactivated (they stay in the team, PRs reference them)
+// and their OPEN reviews are reassigned to the remaining active members in the same transaction,
+// as are the reviews of listed members switched to is_active=false
+// Members of other teams are taken over as with AddTeam
+// The reviewer policy is changed only when provided; its lead must be listed
+func (s *TeamService) ReplaceTeam(ctx context.Context, team *domain.Team) (*domain.MembershipChanges, error) {
+	if err := s.validateTeam(ctx, team, domain.TeamSyncReplace); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("replacing team members",
+		slog.String("team_name", team.Name),
+		slog.Int("members_count", len(team.Members)),
+	)
+
+	changes, err := s.teamRepo.ReplaceMembers(ctx, team)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replace team members: %w", err)
+	}
+
+	s.logger.Info("team members replaced",
+		slog.String("team_name", team.Name),
+		slog.Int("added", len(changes.Added)),
+		slog.Int("updated", len(changes.Updated)),
+		slog.Int("moved", len(changes.Moved)),
+		slog.Int("removed", len(changes.Removed)),
+		slog.Int("reassigned", len(changes.Reassignment.Reassigned)),
+		slog.Int("left_short", len(changes.Reassignment.LeftShort)),
+	)
+
+	syncReassignment(ctx, s.codeHost, changes.Reassignment)
+
+	// Removed and deactivated members are deactivated like with /users/deactivate, so subscribers get the same event
+	for _, userID := range append(slices.Clone(changes.Removed), changes.Deactivated...) {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			s.logger.Warn("failed to get deactivated member",
+				slog.String("user_id", userID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		s.events.Emit(ctx, domain.NewEvent(domain.EventUserDeactivated, domain.UserDeactivatedData{
+			User:         user,
+			Reassignment: changes.Reassignment.ForReviewer(userID),
+		}))
+	}
+	s.emitMoves(ctx, changes.FromTeams, changes.Reassignment)
+
+	return changes, nil
+}
+
 // GetTeam retrieves a team by name
 func (s *TeamService) GetTeam(ctx context.Context, name string) (*domain.Team, error) {
 	if name == "" {
@@ -95,34 +153,191 @@ func (s *TeamService) GetTeam(ctx context.Context, name string) (*domain.Team, e
 	return team, nil
 }
 
-// DeactivateTeam deactivates all users in a team
-func (s *TeamService) DeactivateTeam(ctx context.Context, teamName string) (*domain.Team, int, error) {
+// ListTeams retrieves all teams with member and active member counts
+// isActive keeps teams with (true) or without (false) active members; nil returns all teams
+func (s *TeamService) ListTeams(ctx context.Context, isActive *bool) ([]domain.TeamSummary, error) {
+	teams, err := s.teamRepo.List(ctx, isActive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	s.logger.Info("teams listed", slog.Int("teams_count", len(teams)))
+
+	return teams, nil
+}
+
+// DeactivateTeam deactivates all users in a team and reassigns their OPEN reviews
+// in the same transaction
+// Returns the team, the number of deactivated users and the reassignment report
+func (s *TeamService) DeactivateTeam(ctx context.Context, teamName string) (*domain.Team, int, *domain.ReassignmentReport, error) {
 	if teamName == "" {
-		return nil, 0, domain.ErrInvalidInput
+		return nil, 0, nil, domain.ErrInvalidInput
 	}
 
 	exists, err := s.teamRepo.Exists(ctx, teamName)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 	if !exists {
-		return nil, 0, domain.ErrTeamNotFound
+		return nil, 0, nil, domain.ErrTeamNotFound
 	}
 
-	deactivatedCount, err := s.userRepo.DeactivateTeamUsers(ctx, teamName)
+	deactivatedCount, report, err := s.userRepo.DeactivateTeamWithReassignment(ctx, teamName)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
 	team, err := s.teamRepo.GetByName(ctx, teamName)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
 	s.logger.Info("team deactivated",
 		slog.String("team_name", teamName),
 		slog.Int("deactivated_count", deactivatedCount),
+		slog.Int("reassigned", len(report.Reassigned)),
+		slog.Int("left_short", len(report.LeftShort)),
+	)
+
+	syncReassignment(ctx, s.codeHost, report)
+
+	s.events.Emit(ctx, domain.NewEvent(domain.EventTeamDeactivated, domain.TeamDeactivatedData{
+		TeamName:         teamName,
+		DeactivatedCount: deactivatedCount,
+		Reassignment:     report,
+	}))
+
+	return team, deactivatedCount, report, nil
+}
+
+// TransferUser moves a user to another team and records the move in the membership history
+// policy decides what happens to the user's reviews on OPEN PRs of the old team (see TransferReviewPolicy)
+func (s *TeamService) TransferUser(ctx context.Context, userID, teamName string, policy domain.TransferReviewPolicy) (*domain.TransferResult, error) {
+	if userID == "" || teamName == "" || !policy.IsValid() {
+		return nil, domain.ErrInvalidInput
+	}
+
+	s.logger.Info("transferring user",
+		slog.String("user_id", userID),
+		slog.String("team_name", teamName),
+		slog.String("review_policy", string(policy)),
+	)
+
+	exists, err := s.teamRepo.Exists(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	fromTeam, report, err := s.userRepo.Transfer(ctx, userID, teamName, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transferred user: %w", err)
+	}
+
+	s.logger.Info("user transferred",
+		slog.String("user_id", userID),
+		slog.String("from_team", fromTeam),
+		slog.String("to_team", teamName),
+		slog.Int("reassigned", len(report.Reassigned)),
+		slog.Int("left_short", len(report.LeftShort)),
 	)
 
-	return team, deactivatedCount, nil
+	syncReassignment(ctx, s.codeHost, report)
+
+	s.events.Emit(ctx, domain.NewEvent(domain.EventUserTransferred, domain.UserTransferredData{
+		User:         user,
+		FromTeam:     fromTeam,
+		Reassignment: report,
+	}))
+
+	return &domain.TransferResult{
+		User:         user,
+		FromTeam:     fromTeam,
+		Reassignment: report,
+	}, nil
+}
+
+// validateTeam checks the team, its members and its policy before a sync in the given mode
+func (s *TeamService) validateTeam(ctx context.Context, team *domain.Team, mode domain.TeamSyncMode) error {
+	if err := team.Validate(); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(team.Members))
+	for i := range team.Members {
+		team.Members[i].TeamName = team.Name
+		if err := team.Members[i].Validate(); err != nil {
+			s.logger.Warn("invalid member data",
+				slog.String("user_id", team.Members[i].ID),
+				slog.String("error", err.Error()),
+			)
+			return fmt.Errorf("invalid member %s: %w", team.Members[i].ID, err)
+		}
+		// The roster of a replace sync must be unambiguous
+		if mode == domain.TeamSyncReplace && seen[team.Members[i].ID] {
+			return fmt.Errorf("duplicate member %s: %w", team.Members[i].ID, domain.ErrInvalidInput)
+		}
+		seen[team.Members[i].ID] = true
+	}
+
+	return s.validateLead(ctx, team, mode)
+}
+
+// emitMoves sends user.transferred for every member a sync took over from another team,
+// as /users/transfer does; fromTeams maps the moved users to their previous teams
+func (s *TeamService) emitMoves(ctx context.Context, fromTeams map[string]string, report *domain.ReassignmentReport) {
+	for _, userID := range slices.Sorted(maps.Keys(fromTeams)) {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			s.logger.Warn("failed to get moved member",
+				slog.String("user_id", userID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		s.events.Emit(ctx, domain.NewEvent(domain.EventUserTransferred, domain.UserTransferredData{
+			User:         user,
+			FromTeam:     fromTeams[userID],
+			Reassignment: report.ForReviewer(userID),
+		}))
+	}
+}
+
+// validateLead checks that the policy's lead is a member of the team,
+// either listed in the request or already stored in it
+// A replace sync deactivates stored members that are not listed, so there the lead must be listed
+func (s *TeamService) validateLead(ctx context.Context, team *domain.Team, mode domain.TeamSyncMode) error {
+	if team.Policy == nil || team.Policy.LeadUserID == nil {
+		return nil
+	}
+
+	leadID := *team.Policy.LeadUserID
+	for _, member := range team.Members {
+		if member.ID == leadID {
+			return nil
+		}
+	}
+	if mode == domain.TeamSyncReplace {
+		return domain.ErrInvalidTeamPolicy
+	}
+
+	lead, err := s.userRepo.GetByID(ctx, leadID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.ErrInvalidTeamPolicy
+		}
+		return err
+	}
+	if lead.TeamName != team.Name {
+		return domain.ErrInvalidTeamPolicy
+	}
+
+	return nil
 }
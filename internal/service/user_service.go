@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"test_avito/internal/domain"
 	"test_avito/internal/repository"
@@ -11,18 +12,28 @@ import (
 
 type UserService struct {
 	userRepo repository.UserRepository
+	events   EventEmitter
+	codeHost CodeHostClient
 	logger   *slog.Logger
 }
 
-func NewUserService(userRepo repository.UserRepository, logger *slog.Logger) *UserService {
+func NewUserService(userRepo repository.UserRepository, events EventEmitter, codeHost CodeHostClient, logger *slog.Logger) *UserService {
 	return &UserService{
 		userRepo: userRepo,
+		events:   events,
+		codeHost: codeHost,
 		logger:   logger,
 	}
 }
 
 // SetIsActive updates a user's active status
+// Deactivation also reassigns the user's OPEN reviews (see Deactivate)
 func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+	if !isActive {
+		user, _, err := s.Deactivate(ctx, userID)
+		return user, err
+	}
+
 	if userID == "" {
 		return nil, domain.ErrInvalidInput
 	}
@@ -53,6 +64,139 @@ func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive b
 	return user, nil
 }
 
+// Deactivate deactivates a user and, in the same transaction, reassigns every
+// OPEN PR they review to an active teammate
+// PRs without an eligible replacement lose the reviewer and are reported as left short
+func (s *UserService) Deactivate(ctx context.Context, userID string) (*domain.User, *domain.ReassignmentReport, error) {
+	if userID == "" {
+		return nil, nil, domain.ErrInvalidInput
+	}
+
+	s.logger.Info("deactivating user", slog.String("user_id", userID))
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, nil, err
+	}
+
+	report, err := s.userRepo.DeactivateWithReassignment(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to deactivate user: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get updated user: %w", err)
+	}
+
+	s.logger.Info("user deactivated",
+		slog.String("user_id", userID),
+		slog.Int("reassigned", len(report.Reassigned)),
+		slog.Int("left_short", len(report.LeftShort)),
+	)
+
+	syncReassignment(ctx, s.codeHost, report)
+
+	s.events.Emit(ctx, domain.NewEvent(domain.EventUserDeactivated, domain.UserDeactivatedData{
+		User:         user,
+		Reassignment: report,
+	}))
+
+	return user, report, nil
+}
+
+// UpdateUser applies a partial update to user attributes
+func (s *UserService) UpdateUser(ctx context.Context, userID string, update domain.UserUpdate) (*domain.User, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("updating user", slog.String("user_id", userID))
+
+	current, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.HasEmailSettings() {
+		settings := update.ApplyEmailSettings(current.EmailSettings)
+		if err := settings.Validate(); err != nil {
+			return nil, err
+		}
+		if err := s.userRepo.SetEmailSettings(ctx, userID, settings); err != nil {
+			return nil, fmt.Errorf("failed to update user email settings: %w", err)
+		}
+	}
+
+	if update.MaxOpenReviews.Set {
+		if err := s.userRepo.SetMaxOpenReviews(ctx, userID, update.MaxOpenReviews.Value); err != nil {
+			return nil, fmt.Errorf("failed to update user review limit: %w", err)
+		}
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated user: %w", err)
+	}
+
+	s.logger.Info("user updated", slog.String("user_id", userID))
+
+	return user, nil
+}
+
+// GetUser retrieves a user by ID
+func (s *UserService) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	return s.userRepo.GetByID(ctx, userID)
+}
+
+// SearchUsers finds users by a substring of their ID or username, best matches first
+// Zero Limit takes the default
+func (s *UserService) SearchUsers(ctx context.Context, search domain.UserSearch) ([]domain.User, error) {
+	search.Query = strings.TrimSpace(search.Query)
+	if search.Query == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if search.Limit == 0 {
+		search.Limit = domain.DefaultUserSearchLimit
+	}
+	if search.Limit < 0 || search.Limit > domain.MaxUserSearchLimit {
+		return nil, domain.ErrInvalidInput
+	}
+
+	s.logger.Info("searching users", slog.String("query", search.Query))
+
+	users, err := s.userRepo.Search(ctx, search)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	return users, nil
+}
+
+// GetMembershipHistory retrieves the team moves of a user, oldest first
+func (s *UserService) GetMembershipHistory(ctx context.Context, userID string) ([]domain.MembershipChange, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.userRepo.GetMemberships(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get membership history: %w", err)
+	}
+
+	return history, nil
+}
+
 // GetReviewsByUser retrieves all PRs where user is a reviewer
 func (s *UserService) GetReviewsByUser(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
 	if userID == "" {
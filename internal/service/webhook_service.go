@@ -0,0 +1,291 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/repository"
+)
+
+// Headers set on every webhook request
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// maxWebhookBackoff caps the delay between two attempts of one delivery
+const maxWebhookBackoff = time.Hour
+
+// WebhookOptions controls delivery of queued webhook events
+type WebhookOptions struct {
+	// MaxAttempts is how many times a delivery is tried before it goes to the dead letters
+	MaxAttempts int
+	// BackoffBase is the delay after the first failure; it doubles after every next one
+	BackoffBase time.Duration
+	// BatchSize limits how many deliveries one DeliverDue pass sends
+	BatchSize int
+	// Timeout bounds a single HTTP request
+	Timeout time.Duration
+	// Retention is how long delivered deliveries are kept; 0 keeps them forever
+	Retention time.Duration
+}
+
+// DefaultWebhookOptions are used for zero fields of WebhookOptions (except Retention)
+var DefaultWebhookOptions = WebhookOptions{
+	MaxAttempts: 6,
+	BackoffBase: 10 * time.Second,
+	BatchSize:   100,
+	Timeout:     5 * time.Second,
+}
+
+type WebhookService struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+	opts   WebhookOptions
+	logger *slog.Logger
+}
+
+func NewWebhookService(
+	repo repository.WebhookRepository,
+	opts WebhookOptions,
+	logger *slog.Logger,
+) *WebhookService {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultWebhookOptions.MaxAttempts
+	}
+	if opts.BackoffBase <= 0 {
+		opts.BackoffBase = DefaultWebhookOptions.BackoffBase
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultWebhookOptions.BatchSize
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultWebhookOptions.Timeout
+	}
+	return &WebhookService{
+		repo:   repo,
+		client: &http.Client{Timeout: opts.Timeout},
+		opts:   opts,
+		logger: logger,
+	}
+}
+
+// Subscribe registers an endpoint for the given event types (all types when empty)
+func (s *WebhookService) Subscribe(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	if err := sub.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("subscribing webhook",
+		slog.String("url", sub.URL),
+		slog.Int("events", len(sub.Events)),
+	)
+
+	return s.repo.CreateSubscription(ctx, sub)
+}
+
+// ListSubscriptions retrieves all webhook subscriptions
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return s.repo.ListSubscriptions(ctx)
+}
+
+// Unsubscribe removes a subscription; its pending and failed deliveries are dropped
+func (s *WebhookService) Unsubscribe(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domain.ErrInvalidInput
+	}
+
+	s.logger.Info("unsubscribing webhook", slog.Int64("subscription_id", id))
+
+	return s.repo.DeleteSubscription(ctx, id)
+}
+
+// ListDeadLetters retrieves deliveries that ran out of attempts; nil subscriptionID lists all of them
+func (s *WebhookService) ListDeadLetters(ctx context.Context, subscriptionID *int64) ([]domain.WebhookDeadLetter, error) {
+	return s.repo.ListDeadLetters(ctx, subscriptionID)
+}
+
+// Redeliver queues a failed delivery again with a fresh attempt budget
+func (s *WebhookService) Redeliver(ctx context.Context, deadLetterID int64) (*domain.WebhookDeadLetter, error) {
+	if deadLetterID <= 0 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	s.logger.Info("redelivering webhook", slog.Int64("dead_letter_id", deadLetterID))
+
+	return s.repo.Redeliver(ctx, deadLetterID)
+}
+
+// Emit queues the event for every matching subscription
+// Sending happens later in DeliverDue, so a slow endpoint never delays the API
+func (s *WebhookService) Emit(ctx context.Context, event domain.Event) {
+	subs, err := s.repo.ListSubscriptions(ctx)
+	if err != nil {
+		s.logger.Error("failed to queue webhook event",
+			slog.String("event_type", string(event.Type)),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	var subscriptionIDs []int64
+	for i := range subs {
+		if subs[i].Matches(event.Type) {
+			subscriptionIDs = append(subscriptionIDs, subs[i].ID)
+		}
+	}
+	if len(subscriptionIDs) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		s.logger.Error("failed to encode webhook event",
+			slog.String("event_type", string(event.Type)),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	if err := s.repo.EnqueueDeliveries(ctx, subscriptionIDs, event.Type, payload); err != nil {
+		s.logger.Error("failed to queue webhook event",
+			slog.String("event_type", string(event.Type)),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	s.logger.Debug("webhook event queued",
+		slog.String("event_id", event.ID),
+		slog.String("event_type", string(event.Type)),
+		slog.Int("subscriptions", len(subscriptionIDs)),
+	)
+}
+
+// DeliverDue sends deliveries due at now
+// A non-2xx response or transport error is retried after BackoffBase*2^(attempts-1) (at most an hour);
+// after MaxAttempts the delivery is moved to the dead letters
+// Returns the number of deliveries that succeeded
+func (s *WebhookService) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	// Claimed rows are hidden from other instances until the whole batch is sent:
+	// the requests go one after another and each takes at most Timeout, plus one Timeout of margin
+	lease := time.Duration(s.opts.BatchSize+1) * s.opts.Timeout
+	leaseUntil := now.Add(lease)
+
+	deliveries, err := s.repo.ClaimDueDeliveries(ctx, now, leaseUntil, s.opts.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	started := time.Now()
+	delivered := 0
+	for i := range deliveries {
+		if err := ctx.Err(); err != nil {
+			return delivered, err
+		}
+		// Slow bookkeeping may eat into the margin; a request that could outlive the lease
+		// is not started, the rest of the batch is claimed again once the lease expires
+		if time.Since(started) > lease-2*s.opts.Timeout {
+			s.logger.Warn("webhook lease running out, leaving the rest of the batch",
+				slog.Int("left", len(deliveries)-i),
+			)
+			break
+		}
+
+		delivery := &deliveries[i]
+		sendErr := s.send(ctx, delivery)
+		if sendErr == nil {
+			if err := s.repo.MarkDelivered(ctx, delivery.ID, time.Now()); err != nil {
+				s.logger.Error("failed to mark webhook delivered",
+					slog.Int64("delivery_id", delivery.ID),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+			delivered++
+			continue
+		}
+
+		delivery.Attempts++
+		s.logger.Warn("webhook delivery failed",
+			slog.Int64("delivery_id", delivery.ID),
+			slog.Int64("subscription_id", delivery.SubscriptionID),
+			slog.Int("attempts", delivery.Attempts),
+			slog.String("error", sendErr.Error()),
+		)
+
+		if delivery.Attempts >= s.opts.MaxAttempts {
+			err = s.repo.MoveToDeadLetter(ctx, delivery, sendErr.Error())
+		} else {
+			err = s.repo.ScheduleRetry(ctx, delivery.ID, delivery.Attempts, now.Add(s.backoff(delivery.Attempts)), sendErr.Error())
+		}
+		if err != nil {
+			s.logger.Error("failed to record webhook failure",
+				slog.Int64("delivery_id", delivery.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	if s.opts.Retention > 0 {
+		if _, err := s.repo.DeleteDelivered(ctx, now.Add(-s.opts.Retention)); err != nil {
+			return delivered, fmt.Errorf("failed to delete delivered webhooks: %w", err)
+		}
+	}
+
+	return delivered, nil
+}
+
+// backoff returns the delay before the next attempt after the given number of failures
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	delay := s.opts.BackoffBase
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxWebhookBackoff {
+			return maxWebhookBackoff
+		}
+	}
+	return delay
+}
+
+func (s *WebhookService) send(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, string(delivery.EventType))
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(delivery.Secret, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SignWebhookPayload returns the X-Webhook-Signature value for a body: "sha256=" + hex HMAC-SHA256 with the secret
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
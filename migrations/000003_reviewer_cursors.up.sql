@@ -0,0 +1,6 @@
+-- Курсор round-robin стратегии выбора ревьюеров (по одному на команду)
+CREATE TABLE IF NOT EXISTS team_reviewer_cursors (
+    team_name VARCHAR(255) PRIMARY KEY REFERENCES teams(name) ON DELETE CASCADE,
+    last_reviewer_id VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+-- Максимальное число открытых ревью на пользователя (NULL = без ограничения)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS max_open_reviews INTEGER CHECK (max_open_reviews >= 0);
@@ -0,0 +1,7 @@
+-- Политика ревью команды: минимальное и максимальное число ревьюверов на PR
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS min_reviewers INTEGER NOT NULL DEFAULT 1 CHECK (min_reviewers >= 0),
+    ADD COLUMN IF NOT EXISTS max_reviewers INTEGER NOT NULL DEFAULT 2 CHECK (max_reviewers >= 1);
+
+ALTER TABLE teams
+    ADD CONSTRAINT teams_reviewers_range CHECK (min_reviewers <= max_reviewers);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_pr_reviewers_reviewer_state;
+ALTER TABLE pr_reviewers
+    DROP COLUMN IF EXISTS state_updated_at,
+    DROP COLUMN IF EXISTS state;
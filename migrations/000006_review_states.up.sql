@@ -0,0 +1,8 @@
+-- Состояние ревью для каждого назначенного ревьювера
+ALTER TABLE pr_reviewers
+    ADD COLUMN IF NOT EXISTS state VARCHAR(32) NOT NULL DEFAULT 'PENDING'
+        CHECK (state IN ('PENDING', 'APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    ADD COLUMN IF NOT EXISTS state_updated_at TIMESTAMPTZ;
+
+-- Индекс для выборки ожидающих ревью пользователя
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_reviewer_state ON pr_reviewers(reviewer_id, state);
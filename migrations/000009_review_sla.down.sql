@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_pr_reviewers_pending_assigned;
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS escalated_at;
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS lead_user_id,
+    DROP COLUMN IF EXISTS sla_action,
+    DROP COLUMN IF EXISTS review_sla_minutes;
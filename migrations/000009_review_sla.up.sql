@@ -0,0 +1,13 @@
+-- SLA на ревью: через сколько минут ожидающее ревью считается просроченным и что с ним делать
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS review_sla_minutes INTEGER NOT NULL DEFAULT 0 CHECK (review_sla_minutes >= 0),
+    ADD COLUMN IF NOT EXISTS sla_action VARCHAR(32) NOT NULL DEFAULT 'reassign' CHECK (sla_action IN ('reassign', 'escalate')),
+    ADD COLUMN IF NOT EXISTS lead_user_id VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL;
+
+-- Отметка о том, что просроченное ревью эскалировано и больше не обрабатывается планировщиком
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS escalated_at TIMESTAMPTZ;
+
+-- Поиск просроченных ревью: только ожидающие и ещё не эскалированные
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_pending_assigned
+    ON pr_reviewers(assigned_at)
+    WHERE state = 'PENDING' AND escalated_at IS NULL;
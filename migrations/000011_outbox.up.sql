@@ -0,0 +1,23 @@
+-- Transactional outbox: события пишутся в одной транзакции с изменением PR
+-- и публикуются фоновым relay; published_at IS NULL — ещё не опубликовано
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(64) NOT NULL UNIQUE,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMPTZ
+);
+
+-- Выборка неопубликованных событий, которые пора отправить
+CREATE INDEX IF NOT EXISTS idx_outbox_unpublished
+    ON outbox(next_attempt_at)
+    WHERE published_at IS NULL;
+
+-- Очистка опубликованных событий по давности
+CREATE INDEX IF NOT EXISTS idx_outbox_published_at
+    ON outbox(published_at)
+    WHERE published_at IS NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS chat_template,
+    DROP COLUMN IF EXISTS chat_channel;
@@ -0,0 +1,4 @@
+-- Уведомления ревьюверов в чат: канал команды и шаблон сообщения (NULL — значения из конфигурации)
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS chat_channel VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS chat_template TEXT;
@@ -0,0 +1,8 @@
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_quiet_hours_check,
+    DROP COLUMN IF EXISTS last_digest_at,
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS quiet_hours_end,
+    DROP COLUMN IF EXISTS quiet_hours_start,
+    DROP COLUMN IF EXISTS email_opt_out,
+    DROP COLUMN IF EXISTS email;
@@ -0,0 +1,10 @@
+-- Email-уведомления: адрес, отказ от писем, тихие часы (часы 0-23 в часовом поясе пользователя)
+-- и время отправки последнего ежедневного дайджеста
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS email_opt_out BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS quiet_hours_start SMALLINT CHECK (quiet_hours_start BETWEEN 0 AND 23),
+    ADD COLUMN IF NOT EXISTS quiet_hours_end SMALLINT CHECK (quiet_hours_end BETWEEN 0 AND 23),
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    ADD COLUMN IF NOT EXISTS last_digest_at TIMESTAMPTZ,
+    ADD CONSTRAINT users_quiet_hours_check CHECK ((quiet_hours_start IS NULL) = (quiet_hours_end IS NULL));
@@ -0,0 +1,14 @@
+-- Лента событий для SSE (/events/stream): seq — порядковый номер, который клиент
+-- передает в Last-Event-ID при переподключении; team_name и user_ids — для фильтрации
+CREATE TABLE IF NOT EXISTS event_stream (
+    seq BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(64) NOT NULL UNIQUE,
+    event_type VARCHAR(64) NOT NULL,
+    team_name VARCHAR(255),
+    user_ids TEXT[] NOT NULL DEFAULT '{}',
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Очистка старых событий по давности
+CREATE INDEX IF NOT EXISTS idx_event_stream_created_at ON event_stream(created_at);
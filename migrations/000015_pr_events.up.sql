@@ -0,0 +1,20 @@
+-- История PR (/pullRequest/history): только добавление записей, строки не изменяются
+-- actor и request_id — кто и каким запросом сделал изменение; reason — почему выбран ревьювер
+-- (стратегия, explicit, deactivation) или force для принудительного merge
+CREATE TABLE IF NOT EXISTS pr_events (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    event_type VARCHAR(64) NOT NULL,
+    actor VARCHAR(255),
+    request_id VARCHAR(255),
+    reviewer_id VARCHAR(255),
+    old_reviewer_id VARCHAR(255),
+    reason VARCHAR(64),
+    from_status VARCHAR(50),
+    to_status VARCHAR(50),
+    review_state VARCHAR(50),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- История одного PR в порядке записи
+CREATE INDEX IF NOT EXISTS idx_pr_events_pr ON pr_events(pull_request_id, id);
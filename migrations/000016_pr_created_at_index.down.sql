@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pr_created_at_id;
+
+ALTER TABLE pull_requests ALTER COLUMN created_at DROP NOT NULL;
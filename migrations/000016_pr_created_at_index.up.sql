@@ -0,0 +1,5 @@
+-- Постраничная выдача PR (keyset по (created_at, id)) требует, чтобы у каждого PR была дата создания
+UPDATE pull_requests SET created_at = NOW() WHERE created_at IS NULL;
+ALTER TABLE pull_requests ALTER COLUMN created_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_pr_created_at_id ON pull_requests(created_at, id);
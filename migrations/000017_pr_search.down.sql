@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_pr_name_fts;
+DROP INDEX IF EXISTS idx_pr_name_trgm;
+
+-- Расширение pg_trgm не удаляется: им могут пользоваться и другие объекты базы
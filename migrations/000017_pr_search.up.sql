@@ -0,0 +1,5 @@
+-- Поиск PR по названию (/pullRequest/list): name — подстрока без учета регистра, search — полнотекстовый по словам
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_pr_name_trgm ON pull_requests USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_pr_name_fts ON pull_requests USING GIN (to_tsvector('simple', name));
@@ -0,0 +1,5 @@
+-- Поиск пользователей по подстроке ID и имени (/users/search)
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_users_id_trgm ON users USING GIN (id gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_username_trgm ON users USING GIN (username gin_trgm_ops);
@@ -0,0 +1,18 @@
+-- История членства в командах: переводы через /users/transfer и переходы через /team/add (allow_moves)
+-- Только добавление записей; source — каким способом переведён пользователь,
+-- review_policy — что сделано с его открытыми ревью (только для transfer)
+-- Команды хранятся без внешних ключей, чтобы история пережила удаление команды
+CREATE TABLE IF NOT EXISTS team_memberships (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    from_team VARCHAR(255) NOT NULL,
+    to_team VARCHAR(255) NOT NULL,
+    source VARCHAR(64) NOT NULL,
+    review_policy VARCHAR(64),
+    actor VARCHAR(255),
+    request_id VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- История одного пользователя в порядке записи
+CREATE INDEX IF NOT EXISTS idx_team_memberships_user ON team_memberships(user_id, id);
@@ -0,0 +1,4 @@
+-- Очистка доставленных webhook-событий по давности
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_delivered_at
+    ON webhook_deliveries(delivered_at)
+    WHERE status = 'DELIVERED';
@@ -10,9 +10,19 @@ import (
 
 // Config конфигурация приложения
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Log      LogConfig      `mapstructure:"log"`
+	Server    ServerConfig    `mapstructure:"server"`
+	Database  DatabaseConfig  `mapstructure:"database"`
+	Log       LogConfig       `mapstructure:"log"`
+	Reviewer  ReviewerConfig  `mapstructure:"reviewer"`
+	ReviewSLA ReviewSLAConfig `mapstructure:"review_sla"`
+	Merge     MergeConfig     `mapstructure:"merge"`
+	Webhook   WebhookConfig   `mapstructure:"webhook"`
+	Outbox    OutboxConfig    `mapstructure:"outbox"`
+	GitHub    GitHubConfig    `mapstructure:"github"`
+	GitLab    GitLabConfig    `mapstructure:"gitlab"`
+	Chat      ChatConfig      `mapstructure:"chat"`
+	Email     EmailConfig     `mapstructure:"email"`
+	Stream    StreamConfig    `mapstructure:"stream"`
 }
 
 // ServerConfig конфигурация сервера
@@ -40,6 +50,132 @@ type LogConfig struct {
 	Format string `mapstructure:"format"`
 }
 
+// ReviewerConfig конфигурация выбора ревьюеров
+type ReviewerConfig struct {
+	// Strategy стратегия выбора: random, round_robin, least_loaded
+	Strategy string `mapstructure:"strategy"`
+}
+
+// ReviewSLAConfig конфигурация фоновой проверки SLA на ревью
+type ReviewSLAConfig struct {
+	// CheckInterval период проверки просроченных ревью (0 — проверка отключена)
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+	// BatchSize максимальное число просроченных ревью за один проход
+	BatchSize int `mapstructure:"batch_size"`
+}
+
+// MergeConfig конфигурация merge PR через API
+type MergeConfig struct {
+	// ForceToken административный токен для merge с force, передается в X-Admin-Token (пусто — force через API запрещён)
+	ForceToken string `mapstructure:"force_token"`
+}
+
+// WebhookConfig конфигурация отправки webhook-событий
+type WebhookConfig struct {
+	// DeliveryInterval период отправки накопившихся событий (0 — отправка отключена)
+	DeliveryInterval time.Duration `mapstructure:"delivery_interval"`
+	// MaxAttempts число попыток доставки, после которого событие попадает в dead letters
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// BackoffBase задержка после первой неудачной попытки, далее удваивается
+	BackoffBase time.Duration `mapstructure:"backoff_base"`
+	// Timeout таймаут одного HTTP-запроса к подписчику
+	Timeout time.Duration `mapstructure:"timeout"`
+	// BatchSize максимальное число доставок за один проход
+	BatchSize int `mapstructure:"batch_size"`
+	// Retention сколько хранить доставленные события (0 — не удалять)
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+// OutboxConfig конфигурация публикации событий из transactional outbox
+type OutboxConfig struct {
+	// RelayInterval период публикации накопившихся событий (0 — публикация отключена)
+	RelayInterval time.Duration `mapstructure:"relay_interval"`
+	// BatchSize максимальное число событий за один проход
+	BatchSize int `mapstructure:"batch_size"`
+	// Publisher куда публикуются события: log, http
+	Publisher string `mapstructure:"publisher"`
+	// HTTPURL адрес, на который http-публикатор отправляет события
+	HTTPURL string `mapstructure:"http_url"`
+	// HTTPTimeout таймаут одного HTTP-запроса публикатора
+	HTTPTimeout time.Duration `mapstructure:"http_timeout"`
+	// Retention сколько хранить опубликованные события (0 — не удалять)
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+// GitHubConfig конфигурация приема webhook-событий GitHub
+type GitHubConfig struct {
+	// WebhookSecret секрет для проверки X-Hub-Signature-256 (пусто — прием отключен)
+	WebhookSecret string `mapstructure:"webhook_secret"`
+	// Logins соответствие логинов GitHub и users.id: "login=user_id,login2=user_id2"
+	Logins string `mapstructure:"logins"`
+	// APIURL адрес REST API (для GitHub Enterprise — https://<host>/api/v3)
+	APIURL string `mapstructure:"api_url"`
+	// Token токен для запроса ревью в GitHub (пусто — назначения не отправляются)
+	Token string `mapstructure:"token"`
+	// APITimeout таймаут одного запроса к REST API
+	APITimeout time.Duration `mapstructure:"api_timeout"`
+	// SyncQueueSize сколько запросов к REST API ждут фоновой отправки (при переполнении новые отбрасываются)
+	SyncQueueSize int `mapstructure:"sync_queue_size"`
+}
+
+// GitLabConfig конфигурация приема webhook-событий GitLab
+type GitLabConfig struct {
+	// WebhookToken секретный токен, который GitLab передает в X-Gitlab-Token (пусто — прием отключен)
+	WebhookToken string `mapstructure:"webhook_token"`
+	// Logins соответствие имен пользователей GitLab и users.id: "username=user_id,username2=user_id2"
+	Logins string `mapstructure:"logins"`
+}
+
+// ChatConfig конфигурация уведомлений ревьюверов в чат (Slack-совместимый incoming webhook)
+type ChatConfig struct {
+	// WebhookURL адрес incoming webhook (пусто — уведомления отключены)
+	WebhookURL string `mapstructure:"webhook_url"`
+	// DefaultChannel канал для команд без chat_channel (пусто — канал самого webhook)
+	DefaultChannel string `mapstructure:"default_channel"`
+	// Username имя бота в чате
+	Username string `mapstructure:"username"`
+	// PRLinkTemplate ссылка на PR, {pull_request_id} заменяется на ID PR
+	PRLinkTemplate string `mapstructure:"pr_link_template"`
+	// Timeout таймаут одного запроса к webhook
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// EmailConfig конфигурация email-уведомлений ревьюверов и ежедневного дайджеста
+type EmailConfig struct {
+	// SMTPHost адрес SMTP-сервера (пусто — письма не отправляются)
+	SMTPHost string `mapstructure:"smtp_host"`
+	// SMTPPort порт SMTP-сервера
+	SMTPPort int `mapstructure:"smtp_port"`
+	// SMTPUsername логин для AUTH PLAIN (пусто — без авторизации)
+	SMTPUsername string `mapstructure:"smtp_username"`
+	// SMTPPassword пароль для AUTH PLAIN
+	SMTPPassword string `mapstructure:"smtp_password"`
+	// From отправитель писем
+	From string `mapstructure:"from"`
+	// PRLinkTemplate ссылка на PR, {pull_request_id} заменяется на ID PR
+	PRLinkTemplate string `mapstructure:"pr_link_template"`
+	// Timeout таймаут одной SMTP-сессии
+	Timeout time.Duration `mapstructure:"timeout"`
+	// DigestInterval период проверки, кому пора отправить дайджест (0 — дайджест отключен)
+	DigestInterval time.Duration `mapstructure:"digest_interval"`
+	// DigestHour час по местному времени пользователя, начиная с которого отправляется дайджест
+	DigestHour int `mapstructure:"digest_hour"`
+}
+
+// StreamConfig конфигурация потока событий SSE (/events/stream)
+type StreamConfig struct {
+	// PollInterval период проверки событий, записанных другими экземплярами сервиса
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	// HeartbeatInterval период keep-alive комментариев в простаивающем потоке
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
+	// BatchSize максимальное число событий за одно чтение
+	BatchSize int `mapstructure:"batch_size"`
+	// Retention сколько хранить события для переподключения по Last-Event-ID (0 — не удалять)
+	Retention time.Duration `mapstructure:"retention"`
+	// CleanupInterval период удаления устаревших событий (0 — очистка отключена)
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+}
+
 // Configuration priority (highest to lowest):
 // 1. Environment variables with APP_ prefix (APP_DATABASE_HOST, APP_SERVER_PORT, etc.)
 // 2. .env file in root directory (POSTGRES_HOST=postgres, SERVER_PORT=8080, etc.)
@@ -80,6 +216,69 @@ func Load() (*Config, error) {
 	_ = v.BindEnv("log.level", "LOG_LEVEL")
 	_ = v.BindEnv("log.format", "LOG_FORMAT")
 
+	// Reviewer
+	_ = v.BindEnv("reviewer.strategy", "REVIEWER_STRATEGY")
+
+	// Review SLA
+	_ = v.BindEnv("review_sla.check_interval", "REVIEW_SLA_CHECK_INTERVAL")
+	_ = v.BindEnv("review_sla.batch_size", "REVIEW_SLA_BATCH_SIZE")
+
+	// Merge
+	_ = v.BindEnv("merge.force_token", "MERGE_FORCE_TOKEN")
+
+	// Webhook
+	_ = v.BindEnv("webhook.delivery_interval", "WEBHOOK_DELIVERY_INTERVAL")
+	_ = v.BindEnv("webhook.max_attempts", "WEBHOOK_MAX_ATTEMPTS")
+	_ = v.BindEnv("webhook.backoff_base", "WEBHOOK_BACKOFF_BASE")
+	_ = v.BindEnv("webhook.timeout", "WEBHOOK_TIMEOUT")
+	_ = v.BindEnv("webhook.batch_size", "WEBHOOK_BATCH_SIZE")
+	_ = v.BindEnv("webhook.retention", "WEBHOOK_RETENTION")
+
+	// Outbox
+	_ = v.BindEnv("outbox.relay_interval", "OUTBOX_RELAY_INTERVAL")
+	_ = v.BindEnv("outbox.batch_size", "OUTBOX_BATCH_SIZE")
+	_ = v.BindEnv("outbox.publisher", "OUTBOX_PUBLISHER")
+	_ = v.BindEnv("outbox.http_url", "OUTBOX_HTTP_URL")
+	_ = v.BindEnv("outbox.http_timeout", "OUTBOX_HTTP_TIMEOUT")
+	_ = v.BindEnv("outbox.retention", "OUTBOX_RETENTION")
+
+	// GitHub
+	_ = v.BindEnv("github.webhook_secret", "GITHUB_WEBHOOK_SECRET")
+	_ = v.BindEnv("github.logins", "GITHUB_LOGINS")
+	_ = v.BindEnv("github.api_url", "GITHUB_API_URL")
+	_ = v.BindEnv("github.token", "GITHUB_TOKEN")
+	_ = v.BindEnv("github.api_timeout", "GITHUB_API_TIMEOUT")
+	_ = v.BindEnv("github.sync_queue_size", "GITHUB_SYNC_QUEUE_SIZE")
+
+	// GitLab
+	_ = v.BindEnv("gitlab.webhook_token", "GITLAB_WEBHOOK_TOKEN")
+	_ = v.BindEnv("gitlab.logins", "GITLAB_LOGINS")
+
+	// Chat
+	_ = v.BindEnv("chat.webhook_url", "CHAT_WEBHOOK_URL")
+	_ = v.BindEnv("chat.default_channel", "CHAT_DEFAULT_CHANNEL")
+	_ = v.BindEnv("chat.username", "CHAT_USERNAME")
+	_ = v.BindEnv("chat.pr_link_template", "CHAT_PR_LINK_TEMPLATE")
+	_ = v.BindEnv("chat.timeout", "CHAT_TIMEOUT")
+
+	// Email
+	_ = v.BindEnv("email.smtp_host", "SMTP_HOST")
+	_ = v.BindEnv("email.smtp_port", "SMTP_PORT")
+	_ = v.BindEnv("email.smtp_username", "SMTP_USERNAME")
+	_ = v.BindEnv("email.smtp_password", "SMTP_PASSWORD")
+	_ = v.BindEnv("email.from", "EMAIL_FROM")
+	_ = v.BindEnv("email.pr_link_template", "EMAIL_PR_LINK_TEMPLATE")
+	_ = v.BindEnv("email.timeout", "EMAIL_TIMEOUT")
+	_ = v.BindEnv("email.digest_interval", "EMAIL_DIGEST_INTERVAL")
+	_ = v.BindEnv("email.digest_hour", "EMAIL_DIGEST_HOUR")
+
+	// Event stream
+	_ = v.BindEnv("stream.poll_interval", "STREAM_POLL_INTERVAL")
+	_ = v.BindEnv("stream.heartbeat_interval", "STREAM_HEARTBEAT_INTERVAL")
+	_ = v.BindEnv("stream.batch_size", "STREAM_BATCH_SIZE")
+	_ = v.BindEnv("stream.retention", "STREAM_RETENTION")
+	_ = v.BindEnv("stream.cleanup_interval", "STREAM_CLEANUP_INTERVAL")
+
 	v.AutomaticEnv()
 	v.SetEnvPrefix("APP")
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
@@ -115,6 +314,69 @@ func setDefaults(v *viper.Viper) {
 	// Log defaults
 	v.SetDefault("log.level", "info")
 	v.SetDefault("log.format", "json")
+
+	// Reviewer defaults
+	v.SetDefault("reviewer.strategy", "least_loaded")
+
+	// Review SLA defaults
+	v.SetDefault("review_sla.check_interval", time.Minute)
+	v.SetDefault("review_sla.batch_size", 100)
+
+	// Merge defaults
+	v.SetDefault("merge.force_token", "")
+
+	// Webhook defaults
+	v.SetDefault("webhook.delivery_interval", 5*time.Second)
+	v.SetDefault("webhook.max_attempts", 6)
+	v.SetDefault("webhook.backoff_base", 10*time.Second)
+	v.SetDefault("webhook.timeout", 5*time.Second)
+	v.SetDefault("webhook.batch_size", 100)
+	v.SetDefault("webhook.retention", 24*time.Hour)
+
+	// Outbox defaults
+	v.SetDefault("outbox.relay_interval", time.Second)
+	v.SetDefault("outbox.batch_size", 100)
+	v.SetDefault("outbox.publisher", "log")
+	v.SetDefault("outbox.http_url", "")
+	v.SetDefault("outbox.http_timeout", 5*time.Second)
+	v.SetDefault("outbox.retention", 24*time.Hour)
+
+	// GitHub defaults
+	v.SetDefault("github.webhook_secret", "")
+	v.SetDefault("github.logins", "")
+	v.SetDefault("github.api_url", "https://api.github.com")
+	v.SetDefault("github.token", "")
+	v.SetDefault("github.api_timeout", 5*time.Second)
+	v.SetDefault("github.sync_queue_size", 1000)
+
+	// GitLab defaults
+	v.SetDefault("gitlab.webhook_token", "")
+	v.SetDefault("gitlab.logins", "")
+
+	// Chat defaults
+	v.SetDefault("chat.webhook_url", "")
+	v.SetDefault("chat.default_channel", "")
+	v.SetDefault("chat.username", "pr-reviewer")
+	v.SetDefault("chat.pr_link_template", "http://localhost:8080/pullRequest/get?pull_request_id={pull_request_id}")
+	v.SetDefault("chat.timeout", 5*time.Second)
+
+	// Email defaults
+	v.SetDefault("email.smtp_host", "")
+	v.SetDefault("email.smtp_port", 587)
+	v.SetDefault("email.smtp_username", "")
+	v.SetDefault("email.smtp_password", "")
+	v.SetDefault("email.from", "PR Reviewer <pr-reviewer@localhost>")
+	v.SetDefault("email.pr_link_template", "http://localhost:8080/pullRequest/get?pull_request_id={pull_request_id}")
+	v.SetDefault("email.timeout", 10*time.Second)
+	v.SetDefault("email.digest_interval", 15*time.Minute)
+	v.SetDefault("email.digest_hour", 9)
+
+	// Event stream defaults
+	v.SetDefault("stream.poll_interval", time.Second)
+	v.SetDefault("stream.heartbeat_interval", 15*time.Second)
+	v.SetDefault("stream.batch_size", 100)
+	v.SetDefault("stream.retention", 24*time.Hour)
+	v.SetDefault("stream.cleanup_interval", time.Hour)
 }
 
 func validate(cfg *Config) error {
@@ -152,6 +414,131 @@ func validate(cfg *Config) error {
 		return fmt.Errorf("invalid log format: %s", cfg.Log.Format)
 	}
 
+	validStrategies := map[string]bool{
+		"random":       true,
+		"round_robin":  true,
+		"least_loaded": true,
+	}
+	if !validStrategies[cfg.Reviewer.Strategy] {
+		return fmt.Errorf("invalid reviewer strategy: %s", cfg.Reviewer.Strategy)
+	}
+
+	if cfg.ReviewSLA.CheckInterval < 0 {
+		return fmt.Errorf("invalid review SLA check interval: %s", cfg.ReviewSLA.CheckInterval)
+	}
+
+	if cfg.ReviewSLA.BatchSize <= 0 {
+		return fmt.Errorf("invalid review SLA batch size: %d", cfg.ReviewSLA.BatchSize)
+	}
+
+	if cfg.Webhook.DeliveryInterval < 0 {
+		return fmt.Errorf("invalid webhook delivery interval: %s", cfg.Webhook.DeliveryInterval)
+	}
+
+	if cfg.Webhook.MaxAttempts <= 0 {
+		return fmt.Errorf("invalid webhook max attempts: %d", cfg.Webhook.MaxAttempts)
+	}
+
+	if cfg.Webhook.BackoffBase <= 0 {
+		return fmt.Errorf("invalid webhook backoff base: %s", cfg.Webhook.BackoffBase)
+	}
+
+	if cfg.Webhook.Timeout <= 0 {
+		return fmt.Errorf("invalid webhook timeout: %s", cfg.Webhook.Timeout)
+	}
+
+	if cfg.Webhook.BatchSize <= 0 {
+		return fmt.Errorf("invalid webhook batch size: %d", cfg.Webhook.BatchSize)
+	}
+
+	if cfg.Webhook.Retention < 0 {
+		return fmt.Errorf("invalid webhook retention: %s", cfg.Webhook.Retention)
+	}
+
+	if cfg.Outbox.RelayInterval < 0 {
+		return fmt.Errorf("invalid outbox relay interval: %s", cfg.Outbox.RelayInterval)
+	}
+
+	if cfg.Outbox.BatchSize <= 0 {
+		return fmt.Errorf("invalid outbox batch size: %d", cfg.Outbox.BatchSize)
+	}
+
+	validPublishers := map[string]bool{
+		"log":  true,
+		"http": true,
+	}
+	if !validPublishers[cfg.Outbox.Publisher] {
+		return fmt.Errorf("invalid outbox publisher: %s", cfg.Outbox.Publisher)
+	}
+
+	if cfg.Outbox.Publisher == "http" && cfg.Outbox.HTTPURL == "" {
+		return fmt.Errorf("outbox http url is required for the http publisher")
+	}
+
+	if cfg.Outbox.HTTPTimeout <= 0 {
+		return fmt.Errorf("invalid outbox http timeout: %s", cfg.Outbox.HTTPTimeout)
+	}
+
+	if cfg.Outbox.Retention < 0 {
+		return fmt.Errorf("invalid outbox retention: %s", cfg.Outbox.Retention)
+	}
+
+	if cfg.GitHub.Token != "" && cfg.GitHub.APIURL == "" {
+		return fmt.Errorf("github api url is required when github token is set")
+	}
+
+	if cfg.GitHub.APITimeout <= 0 {
+		return fmt.Errorf("invalid github api timeout: %s", cfg.GitHub.APITimeout)
+	}
+
+	if cfg.GitHub.SyncQueueSize <= 0 {
+		return fmt.Errorf("invalid github sync queue size: %d", cfg.GitHub.SyncQueueSize)
+	}
+
+	if cfg.Chat.Timeout <= 0 {
+		return fmt.Errorf("invalid chat timeout: %s", cfg.Chat.Timeout)
+	}
+
+	if cfg.Email.SMTPHost != "" && (cfg.Email.SMTPPort <= 0 || cfg.Email.SMTPPort > 65535) {
+		return fmt.Errorf("invalid smtp port: %d", cfg.Email.SMTPPort)
+	}
+
+	if cfg.Email.SMTPHost != "" && cfg.Email.From == "" {
+		return fmt.Errorf("email sender is required when smtp host is set")
+	}
+
+	if cfg.Email.Timeout <= 0 {
+		return fmt.Errorf("invalid email timeout: %s", cfg.Email.Timeout)
+	}
+
+	if cfg.Email.DigestInterval < 0 {
+		return fmt.Errorf("invalid email digest interval: %s", cfg.Email.DigestInterval)
+	}
+
+	if cfg.Email.DigestHour < 0 || cfg.Email.DigestHour > 23 {
+		return fmt.Errorf("invalid email digest hour: %d", cfg.Email.DigestHour)
+	}
+
+	if cfg.Stream.PollInterval <= 0 {
+		return fmt.Errorf("invalid stream poll interval: %s", cfg.Stream.PollInterval)
+	}
+
+	if cfg.Stream.HeartbeatInterval <= 0 {
+		return fmt.Errorf("invalid stream heartbeat interval: %s", cfg.Stream.HeartbeatInterval)
+	}
+
+	if cfg.Stream.BatchSize <= 0 {
+		return fmt.Errorf("invalid stream batch size: %d", cfg.Stream.BatchSize)
+	}
+
+	if cfg.Stream.Retention < 0 {
+		return fmt.Errorf("invalid stream retention: %s", cfg.Stream.Retention)
+	}
+
+	if cfg.Stream.CleanupInterval < 0 {
+		return fmt.Errorf("invalid stream cleanup interval: %s", cfg.Stream.CleanupInterval)
+	}
+
 	return nil
 }
 
@@ -62,6 +62,12 @@ func WithRequestID(ctx context.Context, logger *slog.Logger) *slog.Logger {
 	return logger
 }
 
+// Достаём из контекста request ID (пустая строка, если его нет)
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDKey).(string)
+	return requestID
+}
+
 // Достаём из контекста логгер
 func FromContext(ctx context.Context) *slog.Logger {
 	if logger, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
@@ -0,0 +1,165 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/notify"
+	"test_avito/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type chatPayload struct {
+	Text     string `json:"text"`
+	Channel  string `json:"channel"`
+	Username string `json:"username"`
+}
+
+func setupChatTest(t *testing.T, webhookURL string) (*service.TeamService, *service.PullRequestService, *notify.ChatNotifier, func()) {
+	t.Helper()
+
+	var notifier *notify.ChatNotifier
+	teamService, _, prService, _, cleanup := setupTestServices(t, withEmitter(func(repos testRepos) service.EventEmitter {
+		var err error
+		notifier, err = notify.NewChatNotifier(repos.User, repos.Team, repos.PR, notify.ChatOptions{
+			WebhookURL:     webhookURL,
+			DefaultChannel: "#reviews",
+			Username:       "pr-reviewer",
+			PRLinkTemplate: "https://review.example.com/pr?id=" + notify.PRLinkPlaceholder,
+			Timeout:        time.Second,
+		}, repos.Logger)
+		require.NoError(t, err)
+		return notifier
+	}))
+
+	return teamService, prService, notifier, cleanup
+}
+
+// chatMessages waits for in-flight notifications and returns what the chat received
+func chatMessages(t *testing.T, receiver *webhookReceiver, notifier *notify.ChatNotifier) []chatPayload {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, notifier.Wait(ctx))
+
+	_, bodies := receiver.received()
+	messages := make([]chatPayload, len(bodies))
+	for i, body := range bodies {
+		require.NoError(t, json.Unmarshal(body, &messages[i]))
+	}
+	return messages
+}
+
+func TestChatNotifier_AssignedAndReassigned(t *testing.T) {
+	receiver := &webhookReceiver{status: http.StatusOK}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	teamSvc, prSvc, notifier, cleanup := setupChatTest(t, srv.URL)
+	defer cleanup()
+
+	ctx := context.Background()
+	teamName, userIDs := setupTestTeam(t, ctx, teamSvc, 4)
+
+	prID := testID("pr")
+	pr, err := prSvc.CreatePR(ctx, prID, "Add caching", userIDs[0])
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 2)
+
+	t.Run("DefaultTemplateAndChannel", func(t *testing.T) {
+		messages := chatMessages(t, receiver, notifier)
+		require.Len(t, messages, 2)
+
+		for _, msg := range messages {
+			assert.Equal(t, "#reviews", msg.Channel)
+			assert.Equal(t, "pr-reviewer", msg.Username)
+			assert.Contains(t, msg.Text, "assigned to review \"Add caching\" by User 0")
+			assert.Contains(t, msg.Text, "https://review.example.com/pr?id="+prID)
+		}
+	})
+
+	t.Run("TeamTemplateOnReassign", func(t *testing.T) {
+		channel := "#team-widgets"
+		tmpl := "{{if .Reassigned}}reassigned{{end}} {{.ReviewerName}} {{.PullRequestName}} {{.AuthorName}} {{.Link}}"
+		setSLAPolicy(t, ctx, teamSvc, teamName, &domain.TeamPolicy{
+			MinReviewers: 1,
+			MaxReviewers: 2,
+			SLAAction:    domain.SLAActionReassign,
+			ChatChannel:  &channel,
+			ChatTemplate: &tmpl,
+		})
+
+		before := len(chatMessages(t, receiver, notifier))
+
+		newReviewerID, _, err := prSvc.ReassignReviewer(ctx, prID, pr.AssignedReviewers[0], "")
+		require.NoError(t, err)
+
+		messages := chatMessages(t, receiver, notifier)
+		require.Len(t, messages, before+1)
+
+		msg := messages[before]
+		assert.Equal(t, channel, msg.Channel)
+		var newReviewerName string
+		for i, id := range userIDs {
+			if id == newReviewerID {
+				newReviewerName = fmt.Sprintf("User %d", i)
+			}
+		}
+		assert.Equal(t, "reassigned "+newReviewerName+" Add caching User 0 https://review.example.com/pr?id="+prID, msg.Text)
+	})
+
+	t.Run("TransferReplacementNotified", func(t *testing.T) {
+		otherTeam, _ := setupTestTeam(t, ctx, teamSvc, 1)
+		before := len(chatMessages(t, receiver, notifier))
+
+		// The only free teammate is the reviewer replaced above
+		result, err := teamSvc.TransferUser(ctx, pr.AssignedReviewers[1], otherTeam, domain.TransferReassignReviews)
+		require.NoError(t, err)
+		require.Len(t, result.Reassignment.Reassigned, 1)
+		assert.Equal(t, pr.AssignedReviewers[0], result.Reassignment.Reassigned[0].NewReviewerID)
+
+		messages := chatMessages(t, receiver, notifier)
+		require.Len(t, messages, before+1)
+		assert.True(t, strings.HasPrefix(messages[before].Text, "reassigned "))
+		assert.Contains(t, messages[before].Text, "https://review.example.com/pr?id="+prID)
+	})
+
+	t.Run("InvalidTemplateRejected", func(t *testing.T) {
+		tmpl := "{{.ReviewerName"
+		team, err := teamSvc.GetTeam(ctx, teamName)
+		require.NoError(t, err)
+		team.Policy = &domain.TeamPolicy{MinReviewers: 1, MaxReviewers: 2, ChatTemplate: &tmpl}
+
+		err = teamSvc.AddTeam(ctx, team)
+		assert.ErrorIs(t, err, domain.ErrInvalidTeamPolicy)
+	})
+}
+
+func TestChatNotifier_FailureDoesNotFailAPI(t *testing.T) {
+	receiver := &webhookReceiver{status: http.StatusInternalServerError}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	teamSvc, prSvc, notifier, cleanup := setupChatTest(t, srv.URL)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+
+	pr, err := prSvc.CreatePR(ctx, testID("pr"), "Fix flaky test", userIDs[0])
+	require.NoError(t, err)
+	assert.NotEmpty(t, pr.AssignedReviewers)
+
+	// The chat was called and failed, the PR is still created
+	assert.Len(t, chatMessages(t, receiver, notifier), len(pr.AssignedReviewers))
+}
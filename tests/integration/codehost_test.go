@@ -0,0 +1,165 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"test_avito/internal/integrations"
+	"test_avito/internal/integrations/github"
+	"test_avito/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubClient_SyncsReviewers(t *testing.T) {
+	// Fake GitHub API
+	fake := &webhookReceiver{status: http.StatusCreated}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	// The client reads logins on every call, so they can be filled in once the team exists
+	logins := integrations.LoginMap{}
+	teamSvc, userSvc, prSvc, _, cleanup := setupTestServices(t, withCodeHost(func(repos testRepos) service.CodeHostClient {
+		return github.NewClient(srv.URL+"/api/v3/", "gh-token", logins, time.Second, repos.Logger)
+	}))
+	defer cleanup()
+
+	ctx := context.Background()
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 4)
+	for i, id := range userIDs {
+		logins[fmt.Sprintf("gh-user%d", i)] = id
+	}
+
+	requestedLogins := func(body []byte) []string {
+		var payload struct {
+			Reviewers []string `json:"reviewers"`
+		}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		return payload.Reviewers
+	}
+
+	prID := github.PullRequestID("acme/widgets", 7)
+	pr, err := prSvc.CreatePR(ctx, prID, "Add caching", userIDs[0])
+	require.NoError(t, err)
+	require.NotEmpty(t, pr.AssignedReviewers)
+
+	t.Run("CreateRequestsReviewers", func(t *testing.T) {
+		requests, bodies := fake.received()
+		require.Len(t, requests, 1)
+		assert.Equal(t, http.MethodPost, requests[0].Method)
+		assert.Equal(t, "/api/v3/repos/acme/widgets/pulls/7/requested_reviewers", requests[0].URL.Path)
+		assert.Equal(t, "Bearer gh-token", requests[0].Header.Get("Authorization"))
+
+		expected := make([]string, len(pr.AssignedReviewers))
+		for i, id := range pr.AssignedReviewers {
+			expected[i], _ = logins.Login(id)
+		}
+		assert.ElementsMatch(t, expected, requestedLogins(bodies[0]))
+	})
+
+	t.Run("ReassignRemovesAndRequests", func(t *testing.T) {
+		oldReviewerID := pr.AssignedReviewers[0]
+		newReviewerID, _, err := prSvc.ReassignReviewer(ctx, prID, oldReviewerID, "")
+		require.NoError(t, err)
+
+		requests, bodies := fake.received()
+		require.Len(t, requests, 3)
+
+		oldLogin, _ := logins.Login(oldReviewerID)
+		newLogin, _ := logins.Login(newReviewerID)
+		assert.Equal(t, http.MethodDelete, requests[1].Method)
+		assert.Equal(t, []string{oldLogin}, requestedLogins(bodies[1]))
+		assert.Equal(t, http.MethodPost, requests[2].Method)
+		assert.Equal(t, []string{newLogin}, requestedLogins(bodies[2]))
+	})
+
+	t.Run("DeactivationRemovesAndRequests", func(t *testing.T) {
+		current, err := prSvc.GetPR(ctx, prID)
+		require.NoError(t, err)
+		before, _ := fake.received()
+
+		deactivatedID := current.AssignedReviewers[0]
+		_, report, err := userSvc.Deactivate(ctx, deactivatedID)
+		require.NoError(t, err)
+		require.Len(t, report.Reassigned, 1)
+
+		requests, bodies := fake.received()
+		require.Len(t, requests, len(before)+2)
+
+		oldLogin, _ := logins.Login(deactivatedID)
+		newLogin, _ := logins.Login(report.Reassigned[0].NewReviewerID)
+		assert.Equal(t, http.MethodDelete, requests[len(before)].Method)
+		assert.Equal(t, []string{oldLogin}, requestedLogins(bodies[len(before)]))
+		assert.Equal(t, http.MethodPost, requests[len(before)+1].Method)
+		assert.Equal(t, []string{newLogin}, requestedLogins(bodies[len(before)+1]))
+	})
+
+	t.Run("APIErrorDoesNotFailOperation", func(t *testing.T) {
+		fake.mu.Lock()
+		fake.status = http.StatusUnprocessableEntity
+		fake.mu.Unlock()
+
+		otherID := github.PullRequestID("acme/widgets", 8)
+		_, err := prSvc.CreatePR(ctx, otherID, "Fix flaky test", userIDs[0])
+		require.NoError(t, err)
+	})
+
+	t.Run("NonGitHubPRNotSynced", func(t *testing.T) {
+		before, _ := fake.received()
+
+		_, err := prSvc.CreatePR(ctx, testID("pr"), "Local PR", userIDs[0])
+		require.NoError(t, err)
+
+		after, _ := fake.received()
+		assert.Len(t, after, len(before))
+	})
+}
+
+// recordingCodeHost records code host calls as "METHOD pr_id reviewers"
+type recordingCodeHost struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (c *recordingCodeHost) RequestReviewers(_ context.Context, prID string, reviewerIDs []string) {
+	c.record(http.MethodPost, prID, reviewerIDs)
+}
+
+func (c *recordingCodeHost) RemoveRequestedReviewers(_ context.Context, prID string, reviewerIDs []string) {
+	c.record(http.MethodDelete, prID, reviewerIDs)
+}
+
+func (c *recordingCodeHost) record(method, prID string, reviewerIDs []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, fmt.Sprintf("%s %s %v", method, prID, reviewerIDs))
+}
+
+func TestAsyncCodeHost_KeepsOrder(t *testing.T) {
+	recorder := &recordingCodeHost{}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	async := service.NewAsyncCodeHost(recorder, 10, logger)
+
+	// The request context is canceled before the calls are made
+	ctx, cancel := context.WithCancel(context.Background())
+	reviewers := []string{"u1"}
+	async.RemoveRequestedReviewers(ctx, "acme/widgets#1", reviewers)
+	reviewers[0] = "u2"
+	async.RequestReviewers(ctx, "acme/widgets#1", reviewers)
+	cancel()
+
+	require.NoError(t, async.Close(context.Background()))
+	assert.Equal(t, []string{
+		"DELETE acme/widgets#1 [u1]",
+		"POST acme/widgets#1 [u2]",
+	}, recorder.calls)
+}
@@ -0,0 +1,137 @@
+package integration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"test_avito/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamService_ListTeams(t *testing.T) {
+	teamSvc, userSvc, _, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	teamName, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+	inactiveTeam, _ := setupTestTeam(t, ctx, teamSvc, 2)
+
+	_, _, err := userSvc.Deactivate(ctx, userIDs[2])
+	require.NoError(t, err)
+	_, _, _, err = teamSvc.DeactivateTeam(ctx, inactiveTeam)
+	require.NoError(t, err)
+
+	// list indexes the listed teams by name
+	list := func(isActive *bool) map[string]domain.TeamSummary {
+		t.Helper()
+		teams, err := teamSvc.ListTeams(ctx, isActive)
+		require.NoError(t, err)
+		byName := make(map[string]domain.TeamSummary, len(teams))
+		for _, team := range teams {
+			byName[team.Name] = team
+		}
+		return byName
+	}
+	active, inactive := true, false
+
+	all := list(nil)
+	require.Contains(t, all, teamName)
+	assert.Equal(t, 3, all[teamName].MemberCount)
+	assert.Equal(t, 2, all[teamName].ActiveCount)
+	require.Contains(t, all, inactiveTeam)
+	assert.Equal(t, 2, all[inactiveTeam].MemberCount)
+	assert.Equal(t, 0, all[inactiveTeam].ActiveCount)
+
+	onlyActive := list(&active)
+	assert.Contains(t, onlyActive, teamName)
+	assert.NotContains(t, onlyActive, inactiveTeam)
+
+	onlyInactive := list(&inactive)
+	assert.NotContains(t, onlyInactive, teamName)
+	assert.Contains(t, onlyInactive, inactiveTeam)
+}
+
+func TestUserService_GetUser(t *testing.T) {
+	teamSvc, userSvc, _, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	teamName, userIDs := setupTestTeam(t, ctx, teamSvc, 1)
+
+	user, err := userSvc.GetUser(ctx, userIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, "User 0", user.Username)
+	assert.Equal(t, teamName, user.TeamName)
+	assert.True(t, user.IsActive)
+
+	_, err = userSvc.GetUser(ctx, testID("missing"))
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestUserService_SearchUsers(t *testing.T) {
+	teamSvc, userSvc, _, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	prefix := testID("dir")
+	teamName := testID("team")
+	team := domain.NewTeam(teamName, []domain.User{
+		{ID: prefix + "_a", Username: "Zed", TeamName: teamName, IsActive: true},
+		{ID: prefix + "_ab", Username: "Amy", TeamName: teamName, IsActive: true},
+		{ID: prefix + "_c", Username: "Bob 100%", TeamName: teamName, IsActive: true},
+	})
+	require.NoError(t, teamSvc.AddTeam(ctx, team))
+
+	_, _, err := userSvc.Deactivate(ctx, prefix+"_c")
+	require.NoError(t, err)
+
+	search := func(search domain.UserSearch) []string {
+		t.Helper()
+		users, err := userSvc.SearchUsers(ctx, search)
+		require.NoError(t, err)
+		ids := make([]string, len(users))
+		for i, user := range users {
+			ids[i] = user.ID
+		}
+		return ids
+	}
+
+	t.Run("ExactMatchFirst", func(t *testing.T) {
+		// Without the exact match Amy would come first by username
+		assert.Equal(t, []string{prefix + "_a", prefix + "_ab"}, search(domain.UserSearch{Query: strings.ToUpper(prefix + "_a")}))
+	})
+
+	t.Run("OrderedByUsername", func(t *testing.T) {
+		assert.Equal(t, []string{prefix + "_ab", prefix + "_c", prefix + "_a"}, search(domain.UserSearch{Query: prefix}))
+	})
+
+	t.Run("IsActive", func(t *testing.T) {
+		active, inactive := true, false
+		assert.Equal(t, []string{prefix + "_ab", prefix + "_a"}, search(domain.UserSearch{Query: prefix, IsActive: &active}))
+		assert.Equal(t, []string{prefix + "_c"}, search(domain.UserSearch{Query: prefix, IsActive: &inactive}))
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		assert.Equal(t, []string{prefix + "_ab"}, search(domain.UserSearch{Query: prefix, Limit: 1}))
+	})
+
+	t.Run("LiteralWildcards", func(t *testing.T) {
+		users, err := userSvc.SearchUsers(ctx, domain.UserSearch{Query: "0%", Limit: domain.MaxUserSearchLimit})
+		require.NoError(t, err)
+		assert.NotEmpty(t, users)
+		for _, user := range users {
+			assert.True(t, strings.Contains(user.ID, "0%") || strings.Contains(user.Username, "0%"))
+		}
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		_, err := userSvc.SearchUsers(ctx, domain.UserSearch{Query: "   "})
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+		_, err = userSvc.SearchUsers(ctx, domain.UserSearch{Query: prefix, Limit: domain.MaxUserSearchLimit + 1})
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+}
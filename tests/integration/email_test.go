@@ -0,0 +1,296 @@
+package integration
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/notify"
+	"test_avito/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpStub is an in-process SMTP server that accepts every message and keeps it
+type smtpStub struct {
+	listener net.Listener
+	mu       sync.Mutex
+	messages []sentEmail
+}
+
+type sentEmail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+func newSMTPStub(t *testing.T) *smtpStub {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	stub := &smtpStub{listener: listener}
+	go stub.serve()
+	t.Cleanup(func() { _ = listener.Close() })
+	return stub
+}
+
+func (s *smtpStub) addr() (string, int) {
+	addr := s.listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port
+}
+
+func (s *smtpStub) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle speaks just enough SMTP for net/smtp: EHLO, MAIL, RCPT, DATA and QUIT
+func (s *smtpStub) handle(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+
+	var rcpt string
+	_ = tp.PrintfLine("220 localhost ESMTP stub")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			_ = tp.PrintfLine("250 localhost")
+		case "MAIL", "RSET", "NOOP":
+			_ = tp.PrintfLine("250 OK")
+		case "RCPT":
+			rcpt = strings.Trim(strings.TrimPrefix(line[len("RCPT"):], " TO:"), "<> ")
+			_ = tp.PrintfLine("250 OK")
+		case "DATA":
+			_ = tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := io.ReadAll(tp.DotReader())
+			if err != nil {
+				return
+			}
+			s.record(rcpt, data)
+			_ = tp.PrintfLine("250 OK")
+		case "QUIT":
+			_ = tp.PrintfLine("221 Bye")
+			return
+		default:
+			_ = tp.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+func (s *smtpStub) record(rcpt string, data []byte) {
+	email := sentEmail{To: rcpt}
+	if msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(string(data)))); err == nil {
+		email.Subject, _ = new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		body, _ := io.ReadAll(quotedprintable.NewReader(msg.Body))
+		email.Body = strings.ReplaceAll(string(body), "\r\n", "\n")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, email)
+}
+
+func setupEmailTest(t *testing.T, stub *smtpStub) (*service.TeamService, *service.UserService, *service.PullRequestService, *notify.EmailNotifier, func()) {
+	t.Helper()
+
+	var notifier *notify.EmailNotifier
+	teamService, userService, prService, _, cleanup := setupTestServices(t, withEmitter(func(repos testRepos) service.EventEmitter {
+		host, port := stub.addr()
+		var err error
+		notifier, err = notify.NewEmailNotifier(repos.User, repos.PR, notify.EmailOptions{
+			Host:           host,
+			Port:           port,
+			From:           "PR Reviewer <reviews@example.com>",
+			PRLinkTemplate: "https://review.example.com/pr?id=" + notify.PRLinkPlaceholder,
+			DigestHour:     0,
+			Timeout:        time.Second,
+		}, repos.Logger)
+		require.NoError(t, err)
+		return notifier
+	}))
+
+	return teamService, userService, prService, notifier, cleanup
+}
+
+// sentEmails waits for in-flight assignment emails and returns everything sent to the given addresses
+func sentEmails(t *testing.T, stub *smtpStub, notifier *notify.EmailNotifier, to ...string) []sentEmail {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, notifier.Wait(ctx))
+
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+	var emails []sentEmail
+	for _, email := range stub.messages {
+		for _, addr := range to {
+			if email.To == addr {
+				emails = append(emails, email)
+			}
+		}
+	}
+	return emails
+}
+
+func setEmail(t *testing.T, ctx context.Context, userSvc *service.UserService, userID string, update domain.UserUpdate) {
+	t.Helper()
+
+	_, err := userSvc.UpdateUser(ctx, userID, update)
+	require.NoError(t, err)
+}
+
+func emailAddr(userID string) string {
+	return strings.ToLower(userID) + "@example.com"
+}
+
+func TestEmailNotifier_AssignmentEmails(t *testing.T) {
+	stub := newSMTPStub(t)
+	teamSvc, userSvc, prSvc, notifier, cleanup := setupEmailTest(t, stub)
+	defer cleanup()
+
+	ctx := context.Background()
+	// Authors have exactly max_reviewers candidates, so both PRs get every teammate as a reviewer
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+	_, quietIDs := setupTestTeam(t, ctx, teamSvc, 2)
+	userIDs = append(userIDs, quietIDs[1])
+
+	optOut := true
+	hour := time.Now().UTC().Hour()
+	quiet := domain.QuietHours{Start: hour, End: (hour + 1) % 24}
+	addrs := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		addrs[i] = emailAddr(id)
+		update := domain.UserUpdate{Email: domain.NewOptional(&addrs[i])}
+		switch i {
+		case 2:
+			update.EmailOptOut = domain.NewOptional(&optOut)
+		case 3:
+			update.QuietHours = domain.NewOptional(&quiet)
+		}
+		setEmail(t, ctx, userSvc, id, update)
+	}
+
+	prID := testID("pr")
+	pr, err := prSvc.CreatePR(ctx, prID, "Add caching", userIDs[0])
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{userIDs[1], userIDs[2]}, pr.AssignedReviewers)
+
+	quietPR, err := prSvc.CreatePR(ctx, testID("pr"), "Fix typo", quietIDs[0])
+	require.NoError(t, err)
+	require.Equal(t, []string{userIDs[3]}, quietPR.AssignedReviewers)
+
+	// Only the reviewer who accepts email now gets one: the other opted out or is in quiet hours
+	emails := sentEmails(t, stub, notifier, addrs...)
+	require.Len(t, emails, 1)
+	assert.Equal(t, addrs[1], emails[0].To)
+	assert.Equal(t, "Review requested: Add caching", emails[0].Subject)
+	assert.Contains(t, emails[0].Body, "you were assigned to review \"Add caching\" by User 0")
+	assert.Contains(t, emails[0].Body, "https://review.example.com/pr?id="+prID)
+
+	t.Run("InvalidSettingsRejected", func(t *testing.T) {
+		bad := "not an email"
+		_, err := userSvc.UpdateUser(ctx, userIDs[1], domain.UserUpdate{Email: domain.NewOptional(&bad)})
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+		zone := "Mars/Olympus_Mons"
+		_, err = userSvc.UpdateUser(ctx, userIDs[1], domain.UserUpdate{Timezone: domain.NewOptional(&zone)})
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+		empty := domain.QuietHours{Start: 5, End: 5}
+		_, err = userSvc.UpdateUser(ctx, userIDs[1], domain.UserUpdate{QuietHours: domain.NewOptional(&empty)})
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+}
+
+func TestEmailNotifier_DailyDigest(t *testing.T) {
+	stub := newSMTPStub(t)
+	teamSvc, userSvc, prSvc, notifier, cleanup := setupEmailTest(t, stub)
+	defer cleanup()
+
+	ctx := context.Background()
+	teamName, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+	setSLAPolicy(t, ctx, teamSvc, teamName, &domain.TeamPolicy{
+		MinReviewers:     1,
+		MaxReviewers:     2,
+		ReviewSLAMinutes: 60,
+	})
+
+	optOut := true
+	reviewer, optedOut := emailAddr(userIDs[1]), emailAddr(userIDs[2])
+	setEmail(t, ctx, userSvc, userIDs[1], domain.UserUpdate{Email: domain.NewOptional(&reviewer)})
+	setEmail(t, ctx, userSvc, userIDs[2], domain.UserUpdate{
+		Email:       domain.NewOptional(&optedOut),
+		EmailOptOut: domain.NewOptional(&optOut),
+	})
+
+	prID := testID("pr")
+	_, err := prSvc.CreatePR(ctx, prID, "Add caching", userIDs[0])
+	require.NoError(t, err)
+	// Assignment emails are not part of the digest
+	before := len(sentEmails(t, stub, notifier, reviewer))
+
+	digests := func() []sentEmail {
+		return sentEmails(t, stub, notifier, reviewer, optedOut)[before:]
+	}
+
+	now := time.Now()
+	_, err = notifier.SendDigests(ctx, now)
+	require.NoError(t, err)
+
+	emails := digests()
+	require.Len(t, emails, 1)
+	assert.Equal(t, reviewer, emails[0].To)
+	assert.Equal(t, "1 pending review(s)", emails[0].Subject)
+	assert.Contains(t, emails[0].Body, fmt.Sprintf("- \"Add caching\" (%s) by %s, waiting ", prID, userIDs[0]))
+	assert.Contains(t, emails[0].Body, ", due in ")
+	assert.Contains(t, emails[0].Body, "https://review.example.com/pr?id="+prID)
+
+	t.Run("OncePerDay", func(t *testing.T) {
+		_, err := notifier.SendDigests(ctx, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Len(t, digests(), 1)
+	})
+
+	t.Run("NextDayShowsOverdue", func(t *testing.T) {
+		_, err := notifier.SendDigests(ctx, now.Add(26*time.Hour))
+		require.NoError(t, err)
+
+		emails := digests()
+		require.Len(t, emails, 2)
+		assert.Contains(t, emails[1].Body, "waiting 1d 2h, overdue (SLA 1h 0m)")
+	})
+
+	t.Run("NoPendingReviewsNoDigest", func(t *testing.T) {
+		_, err := prSvc.SubmitReview(ctx, prID, userIDs[1], domain.ReviewStateApproved)
+		require.NoError(t, err)
+
+		_, err = notifier.SendDigests(ctx, now.Add(50*time.Hour))
+		require.NoError(t, err)
+		assert.Len(t, digests(), 2)
+	})
+}
@@ -0,0 +1,177 @@
+package integration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"test_avito/internal/api"
+	"test_avito/internal/api/handlers"
+	"test_avito/internal/domain"
+	"test_avito/internal/repository"
+	"test_avito/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sseEvent is one event read from /events/stream
+type sseEvent struct {
+	ID    int64
+	Type  string
+	Event domain.Event
+}
+
+// setupEventStreamTest starts the API with a WriteTimeout much shorter than the test streams live
+func setupEventStreamTest(t *testing.T) (*service.TeamService, *service.PullRequestService, *httptest.Server, func()) {
+	t.Helper()
+
+	var repos testRepos
+	var streamService *service.EventStreamService
+	teamService, _, prService, _, cleanup := setupTestServices(t, withRepos(&repos), withEmitter(func(repos testRepos) service.EventEmitter {
+		streamService = service.NewEventStreamService(repository.NewStreamRepository(repos.Pool, repos.Logger), repos.User, repos.Team, service.EventStreamOptions{
+			PollInterval:      50 * time.Millisecond,
+			HeartbeatInterval: 100 * time.Millisecond,
+		}, repos.Logger)
+		return streamService
+	}))
+
+	handler := handlers.NewHandler(teamService, nil, prService, nil, nil, streamService, nil, nil, "", repos.Logger)
+	srv := httptest.NewUnstartedServer(api.NewRouter(handler, repos.Logger))
+	srv.Config.WriteTimeout = 200 * time.Millisecond
+	srv.Config.RegisterOnShutdown(streamService.Close)
+	srv.Start()
+
+	return teamService, prService, srv, func() {
+		streamService.Close()
+		srv.Close()
+		cleanup()
+	}
+}
+
+// openStream connects to /events/stream and returns a channel of received events
+func openStream(t *testing.T, ctx context.Context, srv *httptest.Server, query string, lastEventID string) <-chan sseEvent {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/events/stream?"+query, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := make(chan sseEvent, 16)
+	go func() {
+		defer resp.Body.Close()
+		defer close(events)
+
+		var event sseEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			field, value, _ := strings.Cut(scanner.Text(), ": ")
+			switch field {
+			case "id":
+				event.ID, _ = strconv.ParseInt(value, 10, 64)
+			case "event":
+				event.Type = value
+			case "data":
+				_ = json.Unmarshal([]byte(value), &event.Event)
+			case "":
+				if event.Type != "" {
+					events <- event
+				}
+				event = sseEvent{}
+			}
+		}
+	}()
+	return events
+}
+
+func nextEvent(t *testing.T, events <-chan sseEvent) sseEvent {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "stream closed")
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+		return sseEvent{}
+	}
+}
+
+func TestEventStream_FilterAndResume(t *testing.T) {
+	teamSvc, prSvc, srv, cleanup := setupEventStreamTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	teamName, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+	_, otherIDs := setupTestTeam(t, ctx, teamSvc, 3)
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	teamEvents := openStream(t, streamCtx, srv, "team_name="+teamName, "")
+	userEvents := openStream(t, streamCtx, srv, "user_id="+otherIDs[0], "")
+
+	// The stream outlives the server's WriteTimeout
+	time.Sleep(500 * time.Millisecond)
+
+	firstID, secondID, otherID := testID("pr"), testID("pr"), testID("pr")
+	_, err := prSvc.CreatePR(ctx, otherID, "Other team", otherIDs[0])
+	require.NoError(t, err)
+	_, err = prSvc.CreatePR(ctx, firstID, "First", userIDs[0])
+	require.NoError(t, err)
+	_, err = prSvc.CreatePR(ctx, secondID, "Second", userIDs[0])
+	require.NoError(t, err)
+
+	// Only events of the team's PRs, in order
+	first := nextEvent(t, teamEvents)
+	assert.Equal(t, string(domain.EventPRCreated), first.Type)
+	assert.Equal(t, first.Type, string(first.Event.Type))
+	second := nextEvent(t, teamEvents)
+	assert.Greater(t, second.ID, first.ID)
+
+	var data struct {
+		PR domain.PullRequest `json:"pr"`
+	}
+	raw, err := json.Marshal(first.Event.Data)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(raw, &data))
+	assert.Equal(t, firstID, data.PR.ID)
+
+	other := nextEvent(t, userEvents)
+	raw, err = json.Marshal(other.Event.Data)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(raw, &data))
+	assert.Equal(t, otherID, data.PR.ID)
+
+	t.Run("ResumeFromLastEventID", func(t *testing.T) {
+		resumed := openStream(t, streamCtx, srv, "team_name="+teamName, strconv.FormatInt(first.ID, 10))
+		event := nextEvent(t, resumed)
+		assert.Equal(t, second.ID, event.ID)
+	})
+
+	t.Run("UnknownTeam", func(t *testing.T) {
+		resp, err := srv.Client().Get(srv.URL + "/events/stream?team_name=" + testID("missing"))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("InvalidLastEventID", func(t *testing.T) {
+		resp, err := srv.Client().Get(srv.URL + "/events/stream?last_event_id=abc")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
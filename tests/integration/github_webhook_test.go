@@ -0,0 +1,144 @@
+package integration
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/integrations"
+	"test_avito/internal/integrations/github"
+	"test_avito/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const githubTestSecret = "gh-secret"
+
+// loadGitHubFixture reads a recorded payload and signs it like GitHub does
+func loadGitHubFixture(t *testing.T, name string) ([]byte, string) {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", "github", name))
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte(githubTestSecret))
+	mac.Write(body)
+	return body, "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// countReviews returns how many PRs are assigned to the given reviewers
+func countReviews(t *testing.T, ctx context.Context, prSvc *service.PullRequestService, reviewerIDs []string) int {
+	t.Helper()
+
+	count := 0
+	for _, id := range reviewerIDs {
+		reviews, err := prSvc.GetPRsByReviewer(ctx, id)
+		require.NoError(t, err)
+		count += len(reviews)
+	}
+	return count
+}
+
+func TestGitHubReceiver_PullRequestLifecycle(t *testing.T) {
+	teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 4)
+
+	testLogger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	receiver := github.NewReceiver(githubTestSecret, integrations.LoginMap{"octocat": userIDs[0]}, prSvc, testLogger)
+	prID := github.PullRequestID("acme/widgets", 42)
+
+	t.Run("InvalidSignatureRejected", func(t *testing.T) {
+		body, _ := loadGitHubFixture(t, "pull_request_opened.json")
+
+		_, err := receiver.Handle(ctx, "pull_request", "sha256=deadbeef", body)
+		assert.ErrorIs(t, err, domain.ErrInvalidSignature)
+
+		_, err = receiver.Handle(ctx, "pull_request", "", body)
+		assert.ErrorIs(t, err, domain.ErrInvalidSignature)
+	})
+
+	t.Run("OpenedCreatesDraft", func(t *testing.T) {
+		body, sig := loadGitHubFixture(t, "pull_request_opened.json")
+
+		result, err := receiver.Handle(ctx, "pull_request", sig, body)
+		require.NoError(t, err)
+		assert.Equal(t, integrations.StatusApplied, result.Status)
+		assert.Equal(t, prID, result.PullRequestID)
+
+		// A draft gets no reviewers
+		assert.Zero(t, countReviews(t, ctx, prSvc, userIDs[1:]))
+
+		// Redelivery of the same event doesn't fail
+		result, err = receiver.Handle(ctx, "pull_request", sig, body)
+		require.NoError(t, err)
+		assert.Equal(t, integrations.StatusIgnored, result.Status)
+	})
+
+	t.Run("ReadyForReviewAssignsReviewers", func(t *testing.T) {
+		body, sig := loadGitHubFixture(t, "pull_request_ready_for_review.json")
+
+		result, err := receiver.Handle(ctx, "pull_request", sig, body)
+		require.NoError(t, err)
+		assert.Equal(t, integrations.StatusApplied, result.Status)
+
+		assert.Positive(t, countReviews(t, ctx, prSvc, userIDs[1:]))
+	})
+
+	t.Run("ClosedMergedMergesPR", func(t *testing.T) {
+		body, sig := loadGitHubFixture(t, "pull_request_closed_merged.json")
+
+		result, err := receiver.Handle(ctx, "pull_request", sig, body)
+		require.NoError(t, err)
+		assert.Equal(t, integrations.StatusApplied, result.Status)
+
+		// Merge is idempotent, so a repeated call returns the PR merged by the event
+		pr, err := prSvc.MergePR(ctx, prID, false)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRStatusMerged, pr.Status)
+	})
+
+	t.Run("ReadyForReviewOnClosedPRIgnored", func(t *testing.T) {
+		// The PR was closed in the service before the delayed ready_for_review arrived
+		closedID := github.PullRequestID("acme/widgets", 43)
+		_, err := prSvc.CreatePR(ctx, closedID, "Drop legacy widget renderer", userIDs[0])
+		require.NoError(t, err)
+		_, err = prSvc.ClosePR(ctx, closedID)
+		require.NoError(t, err)
+
+		body, sig := loadGitHubFixture(t, "pull_request_ready_for_review_closed.json")
+
+		result, err := receiver.Handle(ctx, "pull_request", sig, body)
+		require.NoError(t, err)
+		assert.Equal(t, integrations.StatusIgnored, result.Status)
+		assert.Equal(t, "pull request is closed", result.Reason)
+	})
+
+	t.Run("UnknownLoginIgnored", func(t *testing.T) {
+		other := github.NewReceiver(githubTestSecret, integrations.LoginMap{}, prSvc, testLogger)
+		body, sig := loadGitHubFixture(t, "pull_request_opened.json")
+
+		result, err := other.Handle(ctx, "pull_request", sig, body)
+		require.NoError(t, err)
+		assert.Equal(t, integrations.StatusIgnored, result.Status)
+	})
+
+	t.Run("OtherEventsIgnored", func(t *testing.T) {
+		body, sig := loadGitHubFixture(t, "pull_request_opened.json")
+
+		result, err := receiver.Handle(ctx, "ping", sig, body)
+		require.NoError(t, err)
+		assert.Equal(t, integrations.StatusIgnored, result.Status)
+	})
+}
@@ -0,0 +1,113 @@
+package integration
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/integrations"
+	"test_avito/internal/integrations/gitlab"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gitlabTestToken = "gl-token"
+
+func loadGitLabFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", "gitlab", name))
+	require.NoError(t, err)
+	return body
+}
+
+func TestGitLabReceiver_MergeRequestLifecycle(t *testing.T) {
+	teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 4)
+
+	testLogger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	receiver := gitlab.NewReceiver(gitlabTestToken, integrations.LoginMap{"jdoe": userIDs[0]}, prSvc, testLogger)
+	prID := gitlab.PullRequestID(310, 7)
+
+	t.Run("InvalidTokenRejected", func(t *testing.T) {
+		body := loadGitLabFixture(t, "merge_request_open.json")
+
+		_, err := receiver.Handle(ctx, gitlab.MergeRequestHook, "wrong", body)
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+
+		_, err = receiver.Handle(ctx, gitlab.MergeRequestHook, "", body)
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+
+	t.Run("OpenCreatesDraft", func(t *testing.T) {
+		body := loadGitLabFixture(t, "merge_request_open.json")
+
+		result, err := receiver.Handle(ctx, gitlab.MergeRequestHook, gitlabTestToken, body)
+		require.NoError(t, err)
+		assert.Equal(t, integrations.StatusApplied, result.Status)
+		assert.Equal(t, "310!7", result.PullRequestID)
+
+		// A draft gets no reviewers
+		assert.Zero(t, countReviews(t, ctx, prSvc, userIDs[1:]))
+
+		// Redelivery of the same event doesn't fail
+		result, err = receiver.Handle(ctx, gitlab.MergeRequestHook, gitlabTestToken, body)
+		require.NoError(t, err)
+		assert.Equal(t, integrations.StatusIgnored, result.Status)
+	})
+
+	t.Run("DraftToggleMarksReady", func(t *testing.T) {
+		body := loadGitLabFixture(t, "merge_request_update_ready.json")
+
+		result, err := receiver.Handle(ctx, gitlab.MergeRequestHook, gitlabTestToken, body)
+		require.NoError(t, err)
+		assert.Equal(t, integrations.StatusApplied, result.Status)
+
+		assert.Positive(t, countReviews(t, ctx, prSvc, userIDs[1:]))
+	})
+
+	t.Run("MergeMergesPR", func(t *testing.T) {
+		body := loadGitLabFixture(t, "merge_request_merge.json")
+
+		result, err := receiver.Handle(ctx, gitlab.MergeRequestHook, gitlabTestToken, body)
+		require.NoError(t, err)
+		assert.Equal(t, integrations.StatusApplied, result.Status)
+
+		// Merge is idempotent, so a repeated call returns the PR merged by the event
+		pr, err := prSvc.MergePR(ctx, prID, false)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRStatusMerged, pr.Status)
+	})
+
+	t.Run("DraftToggleWithoutReviewersIgnored", func(t *testing.T) {
+		// The author is alone in the team, so the fallback assignment finds nobody
+		_, soloIDs := setupTestTeam(t, ctx, teamSvc, 1)
+		soloID := gitlab.PullRequestID(310, 8)
+		_, err := prSvc.CreatePR(ctx, soloID, "Archive paid invoices", soloIDs[0])
+		require.NoError(t, err)
+
+		body := loadGitLabFixture(t, "merge_request_update_ready_no_reviewers.json")
+
+		result, err := receiver.Handle(ctx, gitlab.MergeRequestHook, gitlabTestToken, body)
+		require.NoError(t, err)
+		assert.Equal(t, integrations.StatusIgnored, result.Status)
+		assert.Equal(t, "no available reviewer", result.Reason)
+	})
+
+	t.Run("OtherEventsIgnored", func(t *testing.T) {
+		body := loadGitLabFixture(t, "merge_request_open.json")
+
+		result, err := receiver.Handle(ctx, "Push Hook", gitlabTestToken, body)
+		require.NoError(t, err)
+		assert.Equal(t, integrations.StatusIgnored, result.Status)
+	})
+}
@@ -0,0 +1,144 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/outbox"
+	"test_avito/internal/repository"
+	"test_avito/internal/service"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupOutboxTest(t *testing.T) (*pgxpool.Pool, *service.TeamService, *service.PullRequestService, repository.OutboxRepository, func()) {
+	t.Helper()
+
+	var repos testRepos
+	teamService, _, prService, _, cleanup := setupTestServices(t, withRepos(&repos))
+
+	return repos.Pool, teamService, prService, repository.NewOutboxRepository(repos.Pool, repos.Logger), cleanup
+}
+
+func TestOutbox_EventsWrittenWithChanges(t *testing.T) {
+	pool, teamSvc, prSvc, outboxRepo, cleanup := setupOutboxTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 4)
+
+	prID := testID("pr_outbox")
+	pr, err := prSvc.CreatePR(ctx, prID, "Outbox PR", userIDs[0])
+	require.NoError(t, err)
+	require.NotEmpty(t, pr.AssignedReviewers)
+
+	oldReviewer := pr.AssignedReviewers[0]
+	newReviewer, _, err := prSvc.ReassignReviewer(ctx, prID, oldReviewer, "")
+	require.NoError(t, err)
+
+	_, err = prSvc.MergePR(ctx, prID, true)
+	require.NoError(t, err)
+
+	// Idempotent merge doesn't write a second event
+	_, err = prSvc.MergePR(ctx, prID, true)
+	require.NoError(t, err)
+
+	receiver := &webhookReceiver{status: http.StatusOK}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	testLogger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	relay := outbox.NewRelay(outboxRepo, outbox.NewHTTPPublisher(srv.URL, time.Second), outbox.Options{}, testLogger)
+
+	// Events are stored with the database clock; a second ahead absorbs clock skew
+	published, err := relay.RunOnce(ctx, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 3, published)
+
+	requests, bodies := receiver.received()
+	require.Len(t, requests, 3)
+
+	wantTypes := []domain.EventType{domain.EventPRCreated, domain.EventReviewerReassigned, domain.EventPRMerged}
+	for i, req := range requests {
+		assert.Equal(t, string(wantTypes[i]), req.Header.Get(outbox.EventTypeHeader))
+		assert.NotEmpty(t, req.Header.Get(outbox.EventIDHeader))
+	}
+
+	var reassigned struct {
+		ID   string                        `json:"id"`
+		Data domain.ReviewerReassignedData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(bodies[1], &reassigned))
+	assert.Equal(t, requests[1].Header.Get(outbox.EventIDHeader), reassigned.ID)
+	assert.Equal(t, oldReviewer, reassigned.Data.OldReviewerID)
+	assert.Equal(t, newReviewer, reassigned.Data.NewReviewerID)
+	assert.Contains(t, reassigned.Data.PR.AssignedReviewers, newReviewer)
+
+	t.Run("PublishedOnce", func(t *testing.T) {
+		published, err := relay.RunOnce(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, published)
+	})
+
+	t.Run("RolledBackChangeWritesNoEvent", func(t *testing.T) {
+		// The PR already has reviewers, so AssignReviewers fails and its transaction is rolled back
+		err := repository.NewPullRequestRepository(pool, testLogger).AssignReviewers(ctx, prID, []string{userIDs[3]}, "")
+		require.ErrorIs(t, err, domain.ErrReviewersAlreadyAssigned)
+
+		var count int
+		err = pool.QueryRow(ctx, "SELECT COUNT(*) FROM outbox WHERE event_type = $1", string(domain.EventReviewersAssigned)).Scan(&count)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+func TestOutbox_RelayRetriesFailedPublish(t *testing.T) {
+	_, teamSvc, prSvc, outboxRepo, cleanup := setupOutboxTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 2)
+	_, err := prSvc.CreatePR(ctx, testID("pr_outbox_retry"), "Outbox retry PR", userIDs[0])
+	require.NoError(t, err)
+
+	receiver := &webhookReceiver{status: http.StatusServiceUnavailable}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	testLogger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	relay := outbox.NewRelay(outboxRepo, outbox.NewHTTPPublisher(srv.URL, time.Second), outbox.Options{
+		BackoffBase: time.Minute,
+	}, testLogger)
+
+	now := time.Now().Add(time.Second)
+	published, err := relay.RunOnce(ctx, now)
+	require.NoError(t, err)
+	assert.Zero(t, published)
+
+	// Not retried before the backoff expires
+	published, err = relay.RunOnce(ctx, now.Add(30*time.Second))
+	require.NoError(t, err)
+	assert.Zero(t, published)
+
+	receiver.mu.Lock()
+	receiver.status = http.StatusAccepted
+	receiver.mu.Unlock()
+
+	published, err = relay.RunOnce(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+
+	requests, _ := receiver.received()
+	require.Len(t, requests, 2)
+	assert.Equal(t, requests[0].Header.Get(outbox.EventIDHeader), requests[1].Header.Get(outbox.EventIDHeader),
+		"a retried event keeps its id")
+}
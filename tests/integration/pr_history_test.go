@@ -0,0 +1,222 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"test_avito/internal/api"
+	"test_avito/internal/api/handlers"
+	"test_avito/internal/domain"
+	"test_avito/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPRHistory_RecordsEveryChange(t *testing.T) {
+	teamSvc, userSvc, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 5)
+
+	// Changes are attributed to the actor and request of the context they were made in
+	asAlice := context.WithValue(domain.WithActor(ctx, "alice"), logger.RequestIDKey, "req-1")
+	asBob := context.WithValue(domain.WithActor(ctx, "bob"), logger.RequestIDKey, "req-2")
+
+	prID := testID("pr")
+	pr, err := prSvc.CreatePR(asAlice, prID, "Add caching", userIDs[0])
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 2)
+
+	replaced := pr.AssignedReviewers[0]
+	replacement := ""
+	for _, id := range userIDs[1:] {
+		if !pr.HasReviewer(id) {
+			replacement = id
+			break
+		}
+	}
+	_, _, err = prSvc.ReassignReviewer(asBob, prID, replaced, replacement)
+	require.NoError(t, err)
+
+	reviewer := pr.AssignedReviewers[1]
+	_, err = prSvc.SubmitReview(asBob, prID, reviewer, domain.ReviewStateApproved)
+	require.NoError(t, err)
+
+	// Deactivation takes the replacement off the PR in the same transaction
+	_, _, err = userSvc.Deactivate(asBob, replacement)
+	require.NoError(t, err)
+
+	_, err = prSvc.MergePR(asAlice, prID, true)
+	require.NoError(t, err)
+
+	history, err := prSvc.GetHistory(ctx, prID)
+	require.NoError(t, err)
+	require.Len(t, history, 7)
+
+	types := make([]domain.PRHistoryType, len(history))
+	for i, entry := range history {
+		types[i] = entry.Type
+		assert.Equal(t, prID, entry.PullRequestID)
+		if i > 0 {
+			assert.Greater(t, entry.ID, history[i-1].ID)
+		}
+	}
+	assert.Equal(t, []domain.PRHistoryType{
+		domain.PRHistoryCreated,
+		domain.PRHistoryReviewerAssigned,
+		domain.PRHistoryReviewerAssigned,
+		domain.PRHistoryReviewerReassigned,
+		domain.PRHistoryReviewSubmitted,
+		domain.PRHistoryReviewerReassigned,
+		domain.PRHistoryMerged,
+	}, types)
+
+	created := history[0]
+	assert.Equal(t, "alice", created.Actor)
+	assert.Equal(t, "req-1", created.RequestID)
+	assert.Equal(t, domain.PRStatusOpen, created.ToStatus)
+
+	assert.ElementsMatch(t, pr.AssignedReviewers, []string{history[1].ReviewerID, history[2].ReviewerID})
+	assert.Equal(t, "least_loaded", history[1].Reason)
+
+	manual := history[3]
+	assert.Equal(t, "bob", manual.Actor)
+	assert.Equal(t, "req-2", manual.RequestID)
+	assert.Equal(t, replaced, manual.OldReviewerID)
+	assert.Equal(t, replacement, manual.ReviewerID)
+	assert.Equal(t, domain.ReasonExplicit, manual.Reason)
+
+	assert.Equal(t, reviewer, history[4].ReviewerID)
+	assert.Equal(t, domain.ReviewStateApproved, history[4].ReviewState)
+
+	deactivation := history[5]
+	assert.Equal(t, replacement, deactivation.OldReviewerID)
+	assert.NotEmpty(t, deactivation.ReviewerID)
+	assert.Equal(t, domain.ReasonDeactivation, deactivation.Reason)
+
+	merged := history[6]
+	assert.Equal(t, domain.PRStatusOpen, merged.FromStatus)
+	assert.Equal(t, domain.PRStatusMerged, merged.ToStatus)
+	assert.Equal(t, domain.ReasonForceMerge, merged.Reason)
+
+	t.Run("IdempotentMergeNotRecorded", func(t *testing.T) {
+		_, err := prSvc.MergePR(ctx, prID, false)
+		require.NoError(t, err)
+
+		again, err := prSvc.GetHistory(ctx, prID)
+		require.NoError(t, err)
+		assert.Len(t, again, len(history))
+	})
+
+	t.Run("UnknownPR", func(t *testing.T) {
+		_, err := prSvc.GetHistory(ctx, testID("missing"))
+		assert.ErrorIs(t, err, domain.ErrPRNotFound)
+	})
+}
+
+func TestPRHistory_ActorHeaders(t *testing.T) {
+	teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+
+	testLogger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	handler := handlers.NewHandler(teamSvc, nil, prSvc, nil, nil, nil, nil, nil, "", testLogger)
+	srv := httptest.NewServer(api.NewRouter(handler, testLogger))
+	defer srv.Close()
+
+	create := func(prID string, headers map[string]string) int {
+		t.Helper()
+		body := fmt.Sprintf(`{"pull_request_id": %q, "pull_request_name": "Headers", "author_id": %q}`, prID, userIDs[0])
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/pullRequest/create", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		return resp.StatusCode
+	}
+
+	// The service's own actors can't be claimed by clients
+	for _, actor := range []string{domain.ActorGitHub, "GitLab", domain.ActorReviewSLA} {
+		assert.Equal(t, http.StatusBadRequest, create(testID("pr"), map[string]string{"X-Actor": actor}), actor)
+	}
+	tooLong := strings.Repeat("a", domain.MaxActorLength+1)
+	assert.Equal(t, http.StatusBadRequest, create(testID("pr"), map[string]string{"X-Actor": tooLong}))
+	assert.Equal(t, http.StatusBadRequest, create(testID("pr"), map[string]string{"X-Request-ID": tooLong}))
+
+	prID := testID("pr")
+	longest := strings.Repeat("a", domain.MaxActorLength)
+	require.Equal(t, http.StatusCreated, create(prID, map[string]string{"X-Actor": longest, "X-Request-ID": "req-1"}))
+
+	history, err := prSvc.GetHistory(ctx, prID)
+	require.NoError(t, err)
+	require.NotEmpty(t, history)
+	assert.Equal(t, longest, history[0].Actor)
+	assert.Equal(t, "req-1", history[0].RequestID)
+}
+
+func TestPRMerge_ForceToken(t *testing.T) {
+	teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+	prID := testID("pr_force")
+	_, err := prSvc.CreatePR(ctx, prID, "Force", userIDs[0])
+	require.NoError(t, err)
+
+	testLogger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	handler := handlers.NewHandler(teamSvc, nil, prSvc, nil, nil, nil, nil, nil, "admin-secret", testLogger)
+	srv := httptest.NewServer(api.NewRouter(handler, testLogger))
+	defer srv.Close()
+	// With no token configured an empty header must not match the empty token
+	unconfigured := handlers.NewHandler(teamSvc, nil, prSvc, nil, nil, nil, nil, nil, "", testLogger)
+	unconfiguredSrv := httptest.NewServer(api.NewRouter(unconfigured, testLogger))
+	defer unconfiguredSrv.Close()
+
+	merge := func(srv *httptest.Server, token, actor string) int {
+		t.Helper()
+		body := fmt.Sprintf(`{"pull_request_id": %q, "force": true}`, prID)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/pullRequest/merge", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set(handlers.ForceMergeTokenHeader, token)
+		}
+		if actor != "" {
+			req.Header.Set("X-Actor", actor)
+		}
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusForbidden, merge(srv, "", ""))
+	assert.Equal(t, http.StatusForbidden, merge(srv, "", "alice"))
+	assert.Equal(t, http.StatusForbidden, merge(srv, "admin-secre", "alice"))
+	assert.Equal(t, http.StatusForbidden, merge(srv, "admin-secret-", "alice"))
+	assert.Equal(t, http.StatusForbidden, merge(unconfiguredSrv, "", ""))
+
+	pr, err := prSvc.GetPR(ctx, prID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PRStatusOpen, pr.Status)
+
+	require.Equal(t, http.StatusOK, merge(srv, "admin-secret", "alice"))
+	pr, err = prSvc.GetPR(ctx, prID)
+	require.NoError(t, err)
+	assert.True(t, pr.ForceMerged)
+}
@@ -92,7 +92,7 @@ func TestPullRequestService_MergePR(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("MergePR", func(t *testing.T) {
-		pr, err := prSvc.MergePR(ctx, prID)
+		pr, err := prSvc.MergePR(ctx, prID, false)
 		require.NoError(t, err)
 
 		assert.Equal(t, prID, pr.ID)
@@ -102,19 +102,163 @@ func TestPullRequestService_MergePR(t *testing.T) {
 
 	t.Run("MergePRIdempotent", func(t *testing.T) {
 		// Merge again - should be idempotent
-		pr, err := prSvc.MergePR(ctx, prID)
+		pr, err := prSvc.MergePR(ctx, prID, false)
 		require.NoError(t, err)
 
 		assert.Equal(t, domain.PRStatus("MERGED"), pr.Status)
 	})
 
 	t.Run("MergeNonExistentPR", func(t *testing.T) {
-		_, err := prSvc.MergePR(ctx, "nonexistent_pr")
+		_, err := prSvc.MergePR(ctx, "nonexistent_pr", false)
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, domain.ErrPRNotFound)
 	})
 }
 
+func TestPullRequestService_MergeGating(t *testing.T) {
+	teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	teamName, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+
+	team, err := teamSvc.GetTeam(ctx, teamName)
+	require.NoError(t, err)
+	team.Policy = &domain.TeamPolicy{
+		MinReviewers:            1,
+		MaxReviewers:            2,
+		RequiredApprovals:       1,
+		BlockOnChangesRequested: true,
+	}
+	require.NoError(t, teamSvc.AddTeam(ctx, team))
+
+	prID := testID("pr_gated")
+	pr, err := prSvc.CreatePR(ctx, prID, "Gated PR", userIDs[0])
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 2)
+
+	t.Run("RejectWithoutApprovals", func(t *testing.T) {
+		_, err := prSvc.MergePR(ctx, prID, false)
+		assert.ErrorIs(t, err, domain.ErrNotApproved)
+	})
+
+	t.Run("RejectWithChangesRequested", func(t *testing.T) {
+		_, err := prSvc.SubmitReview(ctx, prID, pr.AssignedReviewers[0], domain.ReviewStateApproved)
+		require.NoError(t, err)
+		_, err = prSvc.SubmitReview(ctx, prID, pr.AssignedReviewers[1], domain.ReviewStateChangesRequested)
+		require.NoError(t, err)
+
+		_, err = prSvc.MergePR(ctx, prID, false)
+		assert.ErrorIs(t, err, domain.ErrNotApproved)
+	})
+
+	t.Run("MergeWhenRuleSatisfied", func(t *testing.T) {
+		_, err := prSvc.SubmitReview(ctx, prID, pr.AssignedReviewers[1], domain.ReviewStateCommented)
+		require.NoError(t, err)
+
+		merged, err := prSvc.MergePR(ctx, prID, false)
+		require.NoError(t, err)
+		assert.True(t, merged.IsMerged())
+		assert.False(t, merged.ForceMerged)
+	})
+
+	t.Run("ForceMerge", func(t *testing.T) {
+		forcedID := testID("pr_forced")
+		_, err := prSvc.CreatePR(ctx, forcedID, "Forced PR", userIDs[0])
+		require.NoError(t, err)
+
+		merged, err := prSvc.MergePR(ctx, forcedID, true)
+		require.NoError(t, err)
+		assert.True(t, merged.IsMerged())
+		assert.True(t, merged.ForceMerged)
+
+		// Idempotent: repeated merge neither fails nor changes the flag
+		again, err := prSvc.MergePR(ctx, forcedID, false)
+		require.NoError(t, err)
+		assert.True(t, again.ForceMerged)
+		assert.Equal(t, merged.MergedAt.Unix(), again.MergedAt.Unix())
+	})
+
+	t.Run("InvalidPolicy", func(t *testing.T) {
+		team := domain.NewTeam(testID("team_invalid"), nil)
+		team.Policy = &domain.TeamPolicy{MinReviewers: 1, MaxReviewers: 2, RequiredApprovals: 3}
+		err := teamSvc.AddTeam(ctx, team)
+		assert.ErrorIs(t, err, domain.ErrInvalidTeamPolicy)
+	})
+}
+
+func TestPullRequestService_Lifecycle(t *testing.T) {
+	teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 4)
+
+	prID := testID("pr_draft")
+	pr, err := prSvc.CreateDraftPR(ctx, prID, "Draft PR", userIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, domain.PRStatusDraft, pr.Status)
+	assert.Empty(t, pr.AssignedReviewers)
+
+	t.Run("DraftRejectsReviewerChanges", func(t *testing.T) {
+		_, _, err := prSvc.AddReviewer(ctx, prID, "")
+		assert.ErrorIs(t, err, domain.ErrPRDraft)
+
+		_, err = prSvc.MergePR(ctx, prID, true)
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	})
+
+	t.Run("ReadyAssignsReviewers", func(t *testing.T) {
+		ready, err := prSvc.MarkReady(ctx, prID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRStatusOpen, ready.Status)
+		assert.Len(t, ready.AssignedReviewers, 2)
+		assert.NotContains(t, ready.AssignedReviewers, userIDs[0])
+
+		_, err = prSvc.MarkReady(ctx, prID)
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	})
+
+	t.Run("CloseFreezesReviewers", func(t *testing.T) {
+		closed, err := prSvc.ClosePR(ctx, prID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRStatusClosed, closed.Status)
+		assert.NotNil(t, closed.ClosedAt)
+		assert.Len(t, closed.AssignedReviewers, 2)
+
+		_, _, err = prSvc.AddReviewer(ctx, prID, "")
+		assert.ErrorIs(t, err, domain.ErrPRClosed)
+
+		_, err = prSvc.SubmitReview(ctx, prID, closed.AssignedReviewers[0], domain.ReviewStateApproved)
+		assert.ErrorIs(t, err, domain.ErrPRClosed)
+
+		_, err = prSvc.MergePR(ctx, prID, true)
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	})
+
+	t.Run("ReopenKeepsReviewers", func(t *testing.T) {
+		reopened, err := prSvc.ReopenPR(ctx, prID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRStatusOpen, reopened.Status)
+		assert.Nil(t, reopened.ClosedAt)
+		assert.Len(t, reopened.AssignedReviewers, 2)
+
+		_, err = prSvc.ReopenPR(ctx, prID)
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	})
+
+	t.Run("MergedIsFinal", func(t *testing.T) {
+		_, err := prSvc.MergePR(ctx, prID, true)
+		require.NoError(t, err)
+
+		_, err = prSvc.ClosePR(ctx, prID)
+		assert.ErrorIs(t, err, domain.ErrPRMerged)
+
+		_, err = prSvc.ReopenPR(ctx, prID)
+		assert.ErrorIs(t, err, domain.ErrPRMerged)
+	})
+}
+
 func TestPullRequestService_ReassignReviewer(t *testing.T) {
 	teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
 	defer cleanup()
@@ -131,7 +275,7 @@ func TestPullRequestService_ReassignReviewer(t *testing.T) {
 		t.Run("ReassignReviewer", func(t *testing.T) {
 			oldReviewerID := pr.AssignedReviewers[0]
 
-			newReviewerID, updatedPR, err := prSvc.ReassignReviewer(ctx, prID, oldReviewerID)
+			newReviewerID, updatedPR, err := prSvc.ReassignReviewer(ctx, prID, oldReviewerID, "")
 
 			// Может быть успешно или ошибка если нет кандидатов
 			if err == nil {
@@ -150,29 +294,237 @@ func TestPullRequestService_ReassignReviewer(t *testing.T) {
 	}
 
 	t.Run("ReassignNonExistentReviewer", func(t *testing.T) {
-		_, _, err := prSvc.ReassignReviewer(ctx, prID, "nonexistent_reviewer")
+		_, _, err := prSvc.ReassignReviewer(ctx, prID, "nonexistent_reviewer", "")
 		assert.Error(t, err)
 	})
 
 	t.Run("ReassignAfterMerge", func(t *testing.T) {
 		// Merge PR first
-		_, err := prSvc.MergePR(ctx, prID)
+		_, err := prSvc.MergePR(ctx, prID, false)
 		require.NoError(t, err)
 
 		// Try to reassign - should fail
 		if len(pr.AssignedReviewers) > 0 {
-			_, _, err = prSvc.ReassignReviewer(ctx, prID, pr.AssignedReviewers[0])
+			_, _, err = prSvc.ReassignReviewer(ctx, prID, pr.AssignedReviewers[0], "")
 			assert.Error(t, err, "Should not reassign after merge")
 		}
 	})
 
 	t.Run("ReassignNonExistentPR", func(t *testing.T) {
-		_, _, err := prSvc.ReassignReviewer(ctx, "nonexistent_pr", userIDs[1])
+		_, _, err := prSvc.ReassignReviewer(ctx, "nonexistent_pr", userIDs[1], "")
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, domain.ErrPRNotFound)
 	})
 }
 
+func TestPullRequestService_ReassignToExplicitReviewer(t *testing.T) {
+	teamSvc, userSvc, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 5)
+	_, otherTeamIDs := setupTestTeam(t, ctx, teamSvc, 1)
+
+	prID := testID("pr_explicit")
+	pr, err := prSvc.CreatePR(ctx, prID, "Explicit Reassign", userIDs[0])
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 2)
+
+	oldReviewerID := pr.AssignedReviewers[0]
+	var free []string
+	for _, id := range userIDs[1:] {
+		if !pr.HasReviewer(id) {
+			free = append(free, id)
+		}
+	}
+	require.Len(t, free, 2)
+
+	t.Run("RejectsAuthor", func(t *testing.T) {
+		_, _, err := prSvc.ReassignReviewer(ctx, prID, oldReviewerID, userIDs[0])
+		assert.ErrorIs(t, err, domain.ErrAuthorAsReviewer)
+	})
+
+	t.Run("RejectsAlreadyAssigned", func(t *testing.T) {
+		_, _, err := prSvc.ReassignReviewer(ctx, prID, oldReviewerID, pr.AssignedReviewers[1])
+		assert.ErrorIs(t, err, domain.ErrAlreadyReviewer)
+	})
+
+	t.Run("RejectsOtherTeam", func(t *testing.T) {
+		_, _, err := prSvc.ReassignReviewer(ctx, prID, oldReviewerID, otherTeamIDs[0])
+		assert.ErrorIs(t, err, domain.ErrReplacementNotInTeam)
+	})
+
+	t.Run("RejectsInactive", func(t *testing.T) {
+		_, err := userSvc.SetIsActive(ctx, free[1], false)
+		require.NoError(t, err)
+
+		_, _, err = prSvc.ReassignReviewer(ctx, prID, oldReviewerID, free[1])
+		assert.ErrorIs(t, err, domain.ErrUserNotActive)
+	})
+
+	t.Run("ReassignsToChosenReviewer", func(t *testing.T) {
+		newReviewerID, updatedPR, err := prSvc.ReassignReviewer(ctx, prID, oldReviewerID, free[0])
+		require.NoError(t, err)
+		assert.Equal(t, free[0], newReviewerID)
+		assert.True(t, updatedPR.HasReviewer(free[0]))
+		assert.False(t, updatedPR.HasReviewer(oldReviewerID))
+	})
+}
+
+func TestPullRequestService_AddRemoveReviewer(t *testing.T) {
+	teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 4)
+
+	prID := testID("pr_add_remove")
+	pr, err := prSvc.CreatePR(ctx, prID, "Add Remove Reviewer", userIDs[0])
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, domain.DefaultMaxReviewers)
+
+	removed := pr.AssignedReviewers[0]
+	kept := pr.AssignedReviewers[1]
+
+	t.Run("AddAboveMaxReviewers", func(t *testing.T) {
+		_, _, err := prSvc.AddReviewer(ctx, prID, "")
+		assert.ErrorIs(t, err, domain.ErrTooManyReviewers)
+	})
+
+	t.Run("RemoveReviewer", func(t *testing.T) {
+		updatedPR, err := prSvc.RemoveReviewer(ctx, prID, removed)
+		require.NoError(t, err)
+		assert.Equal(t, []string{kept}, updatedPR.AssignedReviewers)
+	})
+
+	t.Run("RemoveBelowMinReviewers", func(t *testing.T) {
+		_, err := prSvc.RemoveReviewer(ctx, prID, kept)
+		assert.ErrorIs(t, err, domain.ErrTooFewReviewers)
+	})
+
+	t.Run("RemoveNotAssigned", func(t *testing.T) {
+		_, err := prSvc.RemoveReviewer(ctx, prID, removed)
+		assert.ErrorIs(t, err, domain.ErrReviewerNotFound)
+	})
+
+	t.Run("AddRejectsAuthor", func(t *testing.T) {
+		_, _, err := prSvc.AddReviewer(ctx, prID, userIDs[0])
+		assert.ErrorIs(t, err, domain.ErrAuthorAsReviewer)
+	})
+
+	t.Run("AddExplicitReviewer", func(t *testing.T) {
+		addedID, updatedPR, err := prSvc.AddReviewer(ctx, prID, removed)
+		require.NoError(t, err)
+		assert.Equal(t, removed, addedID)
+		assert.ElementsMatch(t, []string{kept, removed}, updatedPR.AssignedReviewers)
+	})
+
+	t.Run("ConcurrentRemovalsKeepMinReviewers", func(t *testing.T) {
+		// Both removals pass the check on their own snapshot; only one may commit
+		errs := make(chan error, 2)
+		for _, reviewerID := range []string{kept, removed} {
+			go func() {
+				_, err := prSvc.RemoveReviewer(ctx, prID, reviewerID)
+				errs <- err
+			}()
+		}
+
+		failed := 0
+		for range 2 {
+			if err := <-errs; err != nil {
+				assert.ErrorIs(t, err, domain.ErrTooFewReviewers)
+				failed++
+			}
+		}
+		assert.Equal(t, 1, failed)
+
+		updatedPR, err := prSvc.GetPR(ctx, prID)
+		require.NoError(t, err)
+		assert.Len(t, updatedPR.AssignedReviewers, domain.DefaultMinReviewers)
+	})
+
+	t.Run("AfterMerge", func(t *testing.T) {
+		_, err := prSvc.MergePR(ctx, prID, false)
+		require.NoError(t, err)
+
+		_, _, err = prSvc.AddReviewer(ctx, prID, "")
+		assert.ErrorIs(t, err, domain.ErrPRMerged)
+
+		_, err = prSvc.RemoveReviewer(ctx, prID, removed)
+		assert.ErrorIs(t, err, domain.ErrPRMerged)
+	})
+}
+
+func TestPullRequestService_SubmitReview(t *testing.T) {
+	teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+
+	prID := testID("pr_review")
+	pr, err := prSvc.CreatePR(ctx, prID, "Review States", userIDs[0])
+	require.NoError(t, err)
+	require.Len(t, pr.Reviews, 2)
+	for _, review := range pr.Reviews {
+		assert.Equal(t, domain.ReviewStatePending, review.State)
+		assert.Nil(t, review.UpdatedAt)
+	}
+
+	approver := pr.AssignedReviewers[0]
+	other := pr.AssignedReviewers[1]
+
+	t.Run("Approve", func(t *testing.T) {
+		updatedPR, err := prSvc.SubmitReview(ctx, prID, approver, domain.ReviewStateApproved)
+		require.NoError(t, err)
+
+		for _, review := range updatedPR.Reviews {
+			if review.ReviewerID == approver {
+				assert.Equal(t, domain.ReviewStateApproved, review.State)
+				assert.NotNil(t, review.UpdatedAt)
+			} else {
+				assert.Equal(t, domain.ReviewStatePending, review.State)
+			}
+		}
+	})
+
+	t.Run("PendingFilter", func(t *testing.T) {
+		pending, err := prSvc.GetPendingReviews(ctx, approver)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		pending, err = prSvc.GetPendingReviews(ctx, other)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, prID, pending[0].ID)
+	})
+
+	t.Run("InvalidState", func(t *testing.T) {
+		_, err := prSvc.SubmitReview(ctx, prID, other, domain.ReviewStatePending)
+		assert.ErrorIs(t, err, domain.ErrInvalidReviewState)
+
+		_, err = prSvc.SubmitReview(ctx, prID, other, domain.ReviewState("LGTM"))
+		assert.ErrorIs(t, err, domain.ErrInvalidReviewState)
+	})
+
+	t.Run("NotAssigned", func(t *testing.T) {
+		_, err := prSvc.SubmitReview(ctx, prID, userIDs[0], domain.ReviewStateCommented)
+		assert.ErrorIs(t, err, domain.ErrReviewerNotFound)
+	})
+
+	t.Run("AfterMerge", func(t *testing.T) {
+		_, err := prSvc.MergePR(ctx, prID, false)
+		require.NoError(t, err)
+
+		_, err = prSvc.SubmitReview(ctx, prID, other, domain.ReviewStateChangesRequested)
+		assert.ErrorIs(t, err, domain.ErrPRMerged)
+
+		pending, err := prSvc.GetPendingReviews(ctx, other)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+}
+
 func TestPullRequestService_WithInactiveUsers(t *testing.T) {
 	teamSvc, userSvc, prSvc, _, cleanup := setupTestServices(t)
 	defer cleanup()
@@ -234,7 +586,7 @@ func TestPullRequestService_ConcurrentOperations(t *testing.T) {
 		// Try to merge same PR concurrently - should be idempotent
 		for i := 0; i < 5; i++ {
 			go func() {
-				_, err := prSvc.MergePR(ctx, prID)
+				_, err := prSvc.MergePR(ctx, prID, false)
 				done <- err
 			}()
 		}
@@ -0,0 +1,141 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"test_avito/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPR(t *testing.T) {
+	teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+
+	prID := testID("pr")
+	created, err := prSvc.CreatePR(ctx, prID, "Add caching", userIDs[0])
+	require.NoError(t, err)
+
+	pr, err := prSvc.GetPR(ctx, prID)
+	require.NoError(t, err)
+	assert.Equal(t, "Add caching", pr.Name)
+	assert.ElementsMatch(t, created.AssignedReviewers, pr.AssignedReviewers)
+	assert.Len(t, pr.Reviews, len(pr.AssignedReviewers))
+
+	_, err = prSvc.GetPR(ctx, testID("missing"))
+	assert.ErrorIs(t, err, domain.ErrPRNotFound)
+}
+
+func TestListPRs_Filters(t *testing.T) {
+	teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	teamName, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+	otherTeam, otherIDs := setupTestTeam(t, ctx, teamSvc, 3)
+	// The only member of a team can't be reviewed by anyone
+	soloTeam, soloIDs := setupTestTeam(t, ctx, teamSvc, 1)
+
+	create := func(name, authorID string) *domain.PullRequest {
+		t.Helper()
+		pr, err := prSvc.CreatePR(ctx, testID("pr"), name, authorID)
+		require.NoError(t, err)
+		return pr
+	}
+	redis := create("Add Redis cache", userIDs[0])
+	percent := create("Fix cache_size 100%", userIDs[0])
+	logging := create("Refactor logging", userIDs[1])
+	create("Redis cluster", otherIDs[0])
+	lonely := create("Lonely change", soloIDs[0])
+
+	list := func(filter domain.PRFilter) []string {
+		t.Helper()
+		page, err := prSvc.ListPRs(ctx, filter)
+		require.NoError(t, err)
+		ids := make([]string, len(page.PullRequests))
+		for i, pr := range page.PullRequests {
+			ids[i] = pr.ID
+		}
+		return ids
+	}
+
+	t.Run("AuthorAndTeam", func(t *testing.T) {
+		assert.Equal(t, []string{percent.ID, redis.ID}, list(domain.PRFilter{AuthorID: userIDs[0]}))
+		assert.Equal(t, []string{logging.ID, percent.ID, redis.ID}, list(domain.PRFilter{TeamName: teamName}))
+	})
+
+	t.Run("Summary", func(t *testing.T) {
+		page, err := prSvc.ListPRs(ctx, domain.PRFilter{AuthorID: userIDs[1]})
+		require.NoError(t, err)
+		require.Len(t, page.PullRequests, 1)
+
+		pr := page.PullRequests[0]
+		assert.Equal(t, teamName, pr.TeamName)
+		assert.Equal(t, domain.PRStatusOpen, pr.Status)
+		assert.ElementsMatch(t, logging.AssignedReviewers, pr.AssignedReviewers)
+		assert.False(t, pr.CreatedAt.IsZero())
+	})
+
+	t.Run("StatusAndReviewer", func(t *testing.T) {
+		_, err := prSvc.MergePR(ctx, redis.ID, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{redis.ID}, list(domain.PRFilter{TeamName: teamName, Status: domain.PRStatusMerged}))
+
+		reviewer := logging.AssignedReviewers[0]
+		assert.Contains(t, list(domain.PRFilter{TeamName: teamName, ReviewerID: reviewer}), logging.ID)
+	})
+
+	t.Run("NoReviewers", func(t *testing.T) {
+		assert.Equal(t, []string{lonely.ID}, list(domain.PRFilter{TeamName: soloTeam, NoReviewers: true}))
+		assert.Empty(t, list(domain.PRFilter{TeamName: teamName, NoReviewers: true}))
+	})
+
+	t.Run("NameSubstring", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{redis.ID, percent.ID}, list(domain.PRFilter{TeamName: teamName, NameContains: "CACHE"}))
+		// LIKE wildcards in the input match literally
+		assert.Equal(t, []string{percent.ID}, list(domain.PRFilter{TeamName: teamName, NameContains: "%"}))
+		assert.Empty(t, list(domain.PRFilter{TeamName: teamName, NameContains: "redis_cache"}))
+	})
+
+	t.Run("FullText", func(t *testing.T) {
+		assert.Equal(t, []string{redis.ID}, list(domain.PRFilter{TeamName: teamName, Search: "redis cache"}))
+		assert.ElementsMatch(t, []string{redis.ID, logging.ID}, list(domain.PRFilter{TeamName: teamName, Search: "redis or logging"}))
+		assert.Len(t, list(domain.PRFilter{Search: "redis", TeamName: otherTeam}), 1)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		page, err := prSvc.ListPRs(ctx, domain.PRFilter{TeamName: teamName, Sort: domain.PRSortOldest, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, page.PullRequests, 2)
+		require.NotEmpty(t, page.NextCursor)
+		assert.Equal(t, redis.ID, page.PullRequests[0].ID)
+
+		after, err := domain.DecodePRCursor(page.NextCursor)
+		require.NoError(t, err)
+		page, err = prSvc.ListPRs(ctx, domain.PRFilter{TeamName: teamName, Sort: domain.PRSortOldest, Limit: 2, After: after})
+		require.NoError(t, err)
+		require.Len(t, page.PullRequests, 1)
+		assert.Equal(t, logging.ID, page.PullRequests[0].ID)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("InvalidFilter", func(t *testing.T) {
+		_, err := prSvc.ListPRs(ctx, domain.PRFilter{ReviewerID: userIDs[1], NoReviewers: true})
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+		_, err = prSvc.ListPRs(ctx, domain.PRFilter{Status: "UNKNOWN"})
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+		_, err = prSvc.ListPRs(ctx, domain.PRFilter{AuthorID: testID("missing")})
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		_, err = prSvc.ListPRs(ctx, domain.PRFilter{TeamName: testID("missing")})
+		assert.ErrorIs(t, err, domain.ErrTeamNotFound)
+	})
+}
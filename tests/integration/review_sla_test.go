@@ -0,0 +1,133 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/repository"
+	"test_avito/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupReviewSLATest(t *testing.T) (*service.TeamService, *service.PullRequestService, *service.ReviewSLAService, repository.PullRequestRepository, func()) {
+	t.Helper()
+
+	var repos testRepos
+	teamService, _, prService, _, cleanup := setupTestServices(t, withRepos(&repos))
+	slaService := service.NewReviewSLAService(repos.PR, prService, 0, repos.Logger)
+
+	return teamService, prService, slaService, repos.PR, cleanup
+}
+
+func setSLAPolicy(t *testing.T, ctx context.Context, teamSvc *service.TeamService, teamName string, policy *domain.TeamPolicy) {
+	t.Helper()
+
+	team, err := teamSvc.GetTeam(ctx, teamName)
+	require.NoError(t, err)
+	team.Policy = policy
+	require.NoError(t, teamSvc.AddTeam(ctx, team))
+}
+
+func TestReviewSLAService_Reassign(t *testing.T) {
+	teamSvc, prSvc, slaSvc, prRepo, cleanup := setupReviewSLATest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	teamName, userIDs := setupTestTeam(t, ctx, teamSvc, 4)
+	setSLAPolicy(t, ctx, teamSvc, teamName, &domain.TeamPolicy{
+		MinReviewers:     1,
+		MaxReviewers:     2,
+		ReviewSLAMinutes: 60,
+		SLAAction:        domain.SLAActionReassign,
+	})
+
+	prID := testID("pr_sla")
+	pr, err := prSvc.CreatePR(ctx, prID, "SLA PR", userIDs[0])
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 2)
+
+	approved, stale := pr.AssignedReviewers[0], pr.AssignedReviewers[1]
+	_, err = prSvc.SubmitReview(ctx, prID, approved, domain.ReviewStateApproved)
+	require.NoError(t, err)
+
+	t.Run("NotOverdueYet", func(t *testing.T) {
+		report, err := slaSvc.CheckOverdueReviews(ctx, time.Now().Add(30*time.Minute))
+		require.NoError(t, err)
+		assert.Empty(t, report.Reassigned)
+		assert.Empty(t, report.Escalated)
+	})
+
+	t.Run("PendingReviewReassigned", func(t *testing.T) {
+		report, err := slaSvc.CheckOverdueReviews(ctx, time.Now().Add(2*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, report.Reassigned, 1)
+		assert.Equal(t, stale, report.Reassigned[0].OldReviewerID)
+
+		updated, err := prRepo.GetByID(ctx, prID)
+		require.NoError(t, err)
+		assert.Contains(t, updated.AssignedReviewers, approved)
+		assert.NotContains(t, updated.AssignedReviewers, stale)
+		assert.Contains(t, updated.AssignedReviewers, report.Reassigned[0].NewReviewerID)
+	})
+}
+
+func TestReviewSLAService_Escalate(t *testing.T) {
+	teamSvc, prSvc, slaSvc, prRepo, cleanup := setupReviewSLATest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	teamName, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+	lead := userIDs[2]
+	setSLAPolicy(t, ctx, teamSvc, teamName, &domain.TeamPolicy{
+		MinReviewers:     1,
+		MaxReviewers:     1,
+		ReviewSLAMinutes: 60,
+		SLAAction:        domain.SLAActionEscalate,
+		LeadUserID:       &lead,
+	})
+
+	prID := testID("pr_escalate")
+	pr, err := prSvc.CreatePR(ctx, prID, "Escalated PR", userIDs[0])
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 1)
+	if pr.AssignedReviewers[0] == lead {
+		_, _, err = prSvc.ReassignReviewer(ctx, prID, lead, userIDs[1])
+		require.NoError(t, err)
+	}
+
+	t.Run("LeadTakesOver", func(t *testing.T) {
+		report, err := slaSvc.CheckOverdueReviews(ctx, time.Now().Add(2*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, report.Escalated, 1)
+		assert.Equal(t, userIDs[1], report.Escalated[0].OldReviewerID)
+		assert.Equal(t, lead, report.Escalated[0].NewReviewerID)
+	})
+
+	t.Run("MarkedWhenLeadCannotTakeOver", func(t *testing.T) {
+		report, err := slaSvc.CheckOverdueReviews(ctx, time.Now().Add(4*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, report.Escalated, 1)
+		assert.Empty(t, report.Escalated[0].NewReviewerID)
+
+		updated, err := prRepo.GetByID(ctx, prID)
+		require.NoError(t, err)
+		require.Len(t, updated.Reviews, 1)
+		assert.NotNil(t, updated.Reviews[0].EscalatedAt)
+
+		// Escalated reviews are not picked up again
+		report, err = slaSvc.CheckOverdueReviews(ctx, time.Now().Add(6*time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, report.Escalated)
+	})
+
+	t.Run("EscalateRequiresLead", func(t *testing.T) {
+		team := domain.NewTeam(testID("team_no_lead"), nil)
+		team.Policy = &domain.TeamPolicy{MinReviewers: 1, MaxReviewers: 2, SLAAction: domain.SLAActionEscalate}
+		err := teamSvc.AddTeam(ctx, team)
+		assert.ErrorIs(t, err, domain.ErrInvalidTeamPolicy)
+	})
+}
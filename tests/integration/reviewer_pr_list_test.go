@@ -0,0 +1,128 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"test_avito/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListReviewerPRs_FilterAndPaginate(t *testing.T) {
+	teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	teamName, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+	otherTeam, _ := setupTestTeam(t, ctx, teamSvc, 3)
+	reviewer := userIDs[1]
+
+	// With two candidates both are assigned, so every PR is reviewed by reviewer
+	var created []string
+	var mid time.Time
+	for i := 0; i < 5; i++ {
+		if i == 2 {
+			// Timestamps are stored with microsecond precision; keep mid clear of both neighbours
+			time.Sleep(time.Millisecond)
+			mid = time.Now()
+			time.Sleep(time.Millisecond)
+		}
+		prID := testID("pr")
+		pr, err := prSvc.CreatePR(ctx, prID, "List test", userIDs[0])
+		require.NoError(t, err)
+		require.True(t, pr.HasReviewer(reviewer))
+		created = append(created, prID)
+	}
+	_, err := prSvc.MergePR(ctx, created[4], true)
+	require.NoError(t, err)
+
+	// readAll follows next_cursor until the last page
+	readAll := func(t *testing.T, filter domain.ReviewerPRFilter) []string {
+		t.Helper()
+		var ids []string
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 10, "pagination does not end")
+			page, err := prSvc.ListReviewerPRs(ctx, filter)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(page.PullRequests), filter.Limit)
+			for _, pr := range page.PullRequests {
+				ids = append(ids, pr.ID)
+			}
+			if page.NextCursor == "" {
+				return ids
+			}
+			filter.After, err = domain.DecodePRCursor(page.NextCursor)
+			require.NoError(t, err)
+		}
+	}
+
+	t.Run("NewestFirstByDefault", func(t *testing.T) {
+		ids := readAll(t, domain.ReviewerPRFilter{ReviewerID: reviewer, Limit: 2})
+		assert.Equal(t, []string{created[4], created[3], created[2], created[1], created[0]}, ids)
+	})
+
+	t.Run("OldestFirst", func(t *testing.T) {
+		ids := readAll(t, domain.ReviewerPRFilter{ReviewerID: reviewer, Sort: domain.PRSortOldest, Limit: 2})
+		assert.Equal(t, created, ids)
+	})
+
+	t.Run("ExactLastPageHasNoCursor", func(t *testing.T) {
+		page, err := prSvc.ListReviewerPRs(ctx, domain.ReviewerPRFilter{ReviewerID: reviewer, Limit: 5})
+		require.NoError(t, err)
+		assert.Len(t, page.PullRequests, 5)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		ids := readAll(t, domain.ReviewerPRFilter{ReviewerID: reviewer, Status: domain.PRStatusMerged, Limit: 10})
+		assert.Equal(t, []string{created[4]}, ids)
+
+		ids = readAll(t, domain.ReviewerPRFilter{ReviewerID: reviewer, PendingOnly: true, Limit: 10})
+		assert.ElementsMatch(t, created[:4], ids)
+
+		ids = readAll(t, domain.ReviewerPRFilter{ReviewerID: reviewer, CreatedAfter: &mid, Limit: 10})
+		assert.ElementsMatch(t, created[2:], ids)
+
+		ids = readAll(t, domain.ReviewerPRFilter{ReviewerID: reviewer, CreatedBefore: &mid, Limit: 10})
+		assert.ElementsMatch(t, created[:2], ids)
+
+		ids = readAll(t, domain.ReviewerPRFilter{ReviewerID: reviewer, TeamName: teamName, Limit: 10})
+		assert.Len(t, ids, 5)
+
+		ids = readAll(t, domain.ReviewerPRFilter{ReviewerID: reviewer, TeamName: otherTeam, Limit: 10})
+		assert.Empty(t, ids)
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		page, err := prSvc.ListReviewerPRs(ctx, domain.ReviewerPRFilter{ReviewerID: reviewer, Limit: 1})
+		require.NoError(t, err)
+		cursor, err := domain.DecodePRCursor(page.NextCursor)
+		require.NoError(t, err)
+
+		// A cursor only continues the sort it was issued for
+		_, err = prSvc.ListReviewerPRs(ctx, domain.ReviewerPRFilter{ReviewerID: reviewer, Sort: domain.PRSortOldest, After: cursor})
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+		_, err = domain.DecodePRCursor("not-a-cursor")
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+		_, err = prSvc.ListReviewerPRs(ctx, domain.ReviewerPRFilter{ReviewerID: reviewer, Limit: domain.MaxPRPageLimit + 1})
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+		_, err = prSvc.ListReviewerPRs(ctx, domain.ReviewerPRFilter{ReviewerID: reviewer, CreatedAfter: &mid, CreatedBefore: &mid})
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+
+	t.Run("UnknownTeam", func(t *testing.T) {
+		_, err := prSvc.ListReviewerPRs(ctx, domain.ReviewerPRFilter{ReviewerID: reviewer, TeamName: testID("missing")})
+		assert.ErrorIs(t, err, domain.ErrTeamNotFound)
+	})
+
+	t.Run("UnknownUser", func(t *testing.T) {
+		_, err := prSvc.ListReviewerPRs(ctx, domain.ReviewerPRFilter{ReviewerID: testID("missing")})
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
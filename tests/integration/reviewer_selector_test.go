@@ -0,0 +1,136 @@
+package integration
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/repository"
+	"test_avito/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewerSelectors(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	teamRepo := repository.NewTeamRepository(pool, logger)
+	userRepo := repository.NewUserRepository(pool, logger)
+	prRepo := repository.NewPullRequestRepository(pool, logger)
+
+	teamSvc := service.NewTeamService(teamRepo, userRepo, service.NopEmitter{}, service.NopCodeHost{}, logger)
+	teamName, userIDs := setupTestTeam(t, ctx, teamSvc, 4)
+
+	candidates, err := userRepo.GetReviewCandidates(ctx, teamName, []string{userIDs[0]})
+	require.NoError(t, err)
+	require.Len(t, candidates, 3)
+
+	t.Run("UnknownStrategy", func(t *testing.T) {
+		_, err := service.NewReviewerSelector("lottery", teamRepo)
+		assert.Error(t, err)
+	})
+
+	t.Run("RoundRobinRotatesThroughTeam", func(t *testing.T) {
+		selector, err := service.NewReviewerSelector(service.StrategyRoundRobin, teamRepo)
+		require.NoError(t, err)
+
+		seen := make(map[string]int)
+		for i := 0; i < 3; i++ {
+			picked, err := selector.Select(ctx, teamName, candidates, 1)
+			require.NoError(t, err)
+			require.Len(t, picked, 1)
+			seen[picked[0]]++
+		}
+
+		// Three picks over three candidates must visit each of them once
+		assert.Len(t, seen, 3)
+		for id, n := range seen {
+			assert.Equal(t, 1, n, "candidate %s picked more than once", id)
+		}
+	})
+
+	t.Run("RoundRobinConcurrentSelections", func(t *testing.T) {
+		selector, err := service.NewReviewerSelector(service.StrategyRoundRobin, teamRepo)
+		require.NoError(t, err)
+
+		// Concurrent selections take consecutive turns instead of starting from the same cursor
+		picks := make(chan string, len(candidates))
+		var wg sync.WaitGroup
+		for range candidates {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				picked, err := selector.Select(ctx, teamName, candidates, 1)
+				if assert.NoError(t, err) && assert.Len(t, picked, 1) {
+					picks <- picked[0]
+				}
+			}()
+		}
+		wg.Wait()
+		close(picks)
+
+		seen := make(map[string]bool)
+		for id := range picks {
+			seen[id] = true
+		}
+		assert.Len(t, seen, len(candidates))
+	})
+
+	t.Run("LeastLoadedPrefersIdleReviewers", func(t *testing.T) {
+		selector, err := service.NewReviewerSelector(service.StrategyLeastLoaded, teamRepo)
+		require.NoError(t, err)
+
+		busy := candidates[0].ID
+		for i := 0; i < 2; i++ {
+			err := prRepo.Create(ctx, &domain.PullRequest{
+				ID:                testID("pr_load"),
+				Name:              "Load PR",
+				AuthorID:          userIDs[0],
+				Status:            domain.PRStatusOpen,
+				AssignedReviewers: []string{busy},
+			}, "")
+			require.NoError(t, err)
+		}
+
+		loaded, err := userRepo.GetReviewCandidates(ctx, teamName, []string{userIDs[0]})
+		require.NoError(t, err)
+		require.Len(t, loaded, 3)
+
+		// Candidates come ordered by load with counts from the same query
+		assert.Equal(t, busy, loaded[2].ID)
+		assert.Equal(t, 2, loaded[2].OpenReviews)
+		assert.Equal(t, 0, loaded[0].OpenReviews)
+
+		for i := 0; i < 5; i++ {
+			picked, err := selector.Select(ctx, teamName, loaded, 2)
+			require.NoError(t, err)
+			assert.Len(t, picked, 2)
+			assert.NotContains(t, picked, busy, "busiest reviewer should not be picked")
+		}
+	})
+
+	t.Run("MergedReviewsDoNotCountAsLoad", func(t *testing.T) {
+		selector, err := service.NewReviewerSelector(service.StrategyLeastLoaded, teamRepo)
+		require.NoError(t, err)
+		prSvc := service.NewPullRequestService(prRepo, userRepo, teamRepo, selector, service.NopEmitter{}, service.NopCodeHost{}, logger)
+
+		otherTeam, otherIDs := setupTestTeam(t, ctx, teamSvc, 2)
+		prID := testID("pr_merged_load")
+		_, err = prSvc.CreatePR(ctx, prID, "Merged PR", otherIDs[0])
+		require.NoError(t, err)
+		_, err = prSvc.MergePR(ctx, prID, false)
+		require.NoError(t, err)
+
+		loaded, err := userRepo.GetReviewCandidates(ctx, otherTeam, []string{otherIDs[0]})
+		require.NoError(t, err)
+		require.Len(t, loaded, 1)
+		assert.Equal(t, 0, loaded[0].OpenReviews)
+	})
+}
@@ -46,7 +46,7 @@ func TestStatsService_GetStats(t *testing.T) {
 		require.NoError(t, err)
 
 		// Merge one PR
-		_, err = prSvc.MergePR(ctx, pr1ID)
+		_, err = prSvc.MergePR(ctx, pr1ID, false)
 		require.NoError(t, err)
 
 		// Get stats
@@ -90,7 +90,7 @@ func TestStatsService_GetStats(t *testing.T) {
 		initialActive := stats1.ActiveUsers
 
 		// Deactivate team
-		_, _, err = teamSvc.DeactivateTeam(ctx, teamName)
+		_, _, _, err = teamSvc.DeactivateTeam(ctx, teamName)
 		require.NoError(t, err)
 
 		// Get updated stats
@@ -140,7 +140,7 @@ func TestStatsService_Consistency(t *testing.T) {
 		assert.Greater(t, stats2.TotalUsers, stats1.TotalUsers, "Total users should increase")
 
 		// Merge PR
-		_, err = prSvc.MergePR(ctx, prID)
+		_, err = prSvc.MergePR(ctx, prID, false)
 		require.NoError(t, err)
 
 		// Get final stats
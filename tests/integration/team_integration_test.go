@@ -151,7 +151,7 @@ func TestTeamService_DeactivateTeam(t *testing.T) {
 	teamName, _ := setupTestTeam(t, ctx, teamSvc, 3)
 
 	t.Run("DeactivateAllMembers", func(t *testing.T) {
-		deactivatedTeam, deactivatedCount, err := teamSvc.DeactivateTeam(ctx, teamName)
+		deactivatedTeam, deactivatedCount, _, err := teamSvc.DeactivateTeam(ctx, teamName)
 		require.NoError(t, err)
 		assert.Equal(t, 3, deactivatedCount)
 		assert.Equal(t, teamName, deactivatedTeam.Name)
@@ -166,14 +166,14 @@ func TestTeamService_DeactivateTeam(t *testing.T) {
 	})
 
 	t.Run("DeactivateNonExistentTeam", func(t *testing.T) {
-		_, _, err := teamSvc.DeactivateTeam(ctx, "nonexistent_team")
+		_, _, _, err := teamSvc.DeactivateTeam(ctx, "nonexistent_team")
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, domain.ErrTeamNotFound)
 	})
 
 	t.Run("DeactivateAlreadyDeactivated", func(t *testing.T) {
 		// Deactivate again - should be idempotent
-		_, deactivatedCount, err := teamSvc.DeactivateTeam(ctx, teamName)
+		_, deactivatedCount, _, err := teamSvc.DeactivateTeam(ctx, teamName)
 		require.NoError(t, err)
 		assert.Equal(t, 0, deactivatedCount, "No users should be deactivated second time")
 	})
@@ -217,3 +217,183 @@ func TestTeamService_ConcurrentAccess(t *testing.T) {
 		assert.Equal(t, teamName, team.Name)
 	})
 }
+
+func TestTeamService_ReviewerPolicy(t *testing.T) {
+	teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	teamName, userIDs := setupTestTeam(t, ctx, teamSvc, 5)
+
+	t.Run("DefaultPolicy", func(t *testing.T) {
+		team, err := teamSvc.GetTeam(ctx, teamName)
+		require.NoError(t, err)
+		require.NotNil(t, team.Policy)
+		assert.Equal(t, domain.DefaultMinReviewers, team.Policy.MinReviewers)
+		assert.Equal(t, domain.DefaultMaxReviewers, team.Policy.MaxReviewers)
+	})
+
+	t.Run("CreatePRUsesMaxReviewers", func(t *testing.T) {
+		team, err := teamSvc.GetTeam(ctx, teamName)
+		require.NoError(t, err)
+		team.Policy = &domain.TeamPolicy{MinReviewers: 2, MaxReviewers: 3}
+		require.NoError(t, teamSvc.AddTeam(ctx, team))
+
+		pr, err := prSvc.CreatePR(ctx, testID("pr_policy"), "Policy PR", userIDs[0])
+		require.NoError(t, err)
+		assert.Len(t, pr.AssignedReviewers, 3)
+	})
+
+	t.Run("UpdateWithoutPolicyKeepsIt", func(t *testing.T) {
+		team, err := teamSvc.GetTeam(ctx, teamName)
+		require.NoError(t, err)
+		team.Policy = nil
+		require.NoError(t, teamSvc.AddTeam(ctx, team))
+
+		team, err = teamSvc.GetTeam(ctx, teamName)
+		require.NoError(t, err)
+		assert.Equal(t, 3, team.Policy.MaxReviewers)
+	})
+
+	t.Run("ExplicitAssignmentOutsidePolicy", func(t *testing.T) {
+		prID := testID("pr_explicit_policy")
+		_, err := prSvc.CreatePR(ctx, prID, "Explicit PR", userIDs[0])
+		require.NoError(t, err)
+
+		_, err = prSvc.AssignReviewersToPR(ctx, prID, userIDs[1:])
+		assert.ErrorIs(t, err, domain.ErrTooManyReviewers)
+
+		_, err = prSvc.AssignReviewersToPR(ctx, prID, userIDs[1:2])
+		assert.ErrorIs(t, err, domain.ErrTooFewReviewers)
+	})
+
+	t.Run("InvalidPolicy", func(t *testing.T) {
+		team := domain.NewTeam(testID("team_invalid"), nil)
+		team.Policy = &domain.TeamPolicy{MinReviewers: 3, MaxReviewers: 2}
+		err := teamSvc.AddTeam(ctx, team)
+		assert.ErrorIs(t, err, domain.ErrInvalidTeamPolicy)
+	})
+}
+
+func TestTeamService_ReplaceTeam(t *testing.T) {
+	teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	teamName, userIDs := setupTestTeam(t, ctx, teamSvc, 4)
+	otherTeam, otherIDs := setupTestTeam(t, ctx, teamSvc, 2)
+
+	pr, err := prSvc.CreatePR(ctx, testID("pr_replace"), "Replace PR", userIDs[0])
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 2)
+
+	removed := pr.AssignedReviewers[0]
+	team, err := teamSvc.GetTeam(ctx, teamName)
+	require.NoError(t, err)
+	var kept []domain.User
+	for _, member := range team.Members {
+		if member.ID != removed {
+			kept = append(kept, member)
+		}
+	}
+	renamed := kept[len(kept)-1].ID
+	kept[len(kept)-1].Username = "Renamed"
+	newID := testID("user_new")
+	members := append(kept,
+		domain.User{ID: otherIDs[0], Username: "User 0", IsActive: true},
+		domain.User{ID: newID, Username: "Newcomer", IsActive: true},
+	)
+
+	// Taking a member of another team has to be allowed explicitly
+	replace := domain.NewTeam(teamName, members)
+	_, err = teamSvc.ReplaceTeam(ctx, replace)
+	require.ErrorIs(t, err, domain.ErrUserInOtherTeam)
+
+	replace.AllowMoves = true
+	replace.MoveReviewPolicy = domain.TransferFailOnReviews
+	changes, err := teamSvc.ReplaceTeam(ctx, replace)
+	require.NoError(t, err)
+	assert.Equal(t, []string{newID}, changes.Added)
+	assert.Equal(t, []string{renamed}, changes.Updated)
+	assert.Equal(t, []string{otherIDs[0]}, changes.Moved)
+	assert.Equal(t, []string{removed}, changes.Removed)
+	require.Len(t, changes.Reassignment.Reassigned, 1)
+	assert.Equal(t, pr.ID, changes.Reassignment.Reassigned[0].PullRequestID)
+	assert.Equal(t, removed, changes.Reassignment.Reassigned[0].OldReviewerID)
+
+	t.Run("RemovedMemberDeactivated", func(t *testing.T) {
+		team, err := teamSvc.GetTeam(ctx, teamName)
+		require.NoError(t, err)
+		assert.Len(t, team.Members, len(members)+1)
+		for _, member := range team.Members {
+			assert.Equal(t, member.ID != removed, member.IsActive, member.ID)
+		}
+
+		updated, err := prSvc.GetPR(ctx, pr.ID)
+		require.NoError(t, err)
+		assert.False(t, updated.HasReviewer(removed))
+		assert.Len(t, updated.AssignedReviewers, 2)
+
+		other, err := teamSvc.GetTeam(ctx, otherTeam)
+		require.NoError(t, err)
+		assert.Len(t, other.Members, 1)
+	})
+
+	t.Run("Idempotent", func(t *testing.T) {
+		changes, err := teamSvc.ReplaceTeam(ctx, domain.NewTeam(teamName, members))
+		require.NoError(t, err)
+		assert.Empty(t, changes.Added)
+		assert.Empty(t, changes.Updated)
+		assert.Empty(t, changes.Moved)
+		assert.Empty(t, changes.Removed)
+	})
+
+	t.Run("ListedInactiveMemberReassigned", func(t *testing.T) {
+		current, err := prSvc.GetPR(ctx, pr.ID)
+		require.NoError(t, err)
+		deactivated := current.AssignedReviewers[0]
+
+		listed := make([]domain.User, len(members))
+		copy(listed, members)
+		for i := range listed {
+			if listed[i].ID == deactivated {
+				listed[i].IsActive = false
+			}
+		}
+
+		// Switching is_active off in the list takes the member off OPEN PRs like removing it
+		changes, err := teamSvc.ReplaceTeam(ctx, domain.NewTeam(teamName, listed))
+		require.NoError(t, err)
+		assert.Equal(t, []string{deactivated}, changes.Updated)
+		assert.Empty(t, changes.Removed)
+		require.Len(t, changes.Reassignment.Reassigned, 1)
+		assert.Equal(t, deactivated, changes.Reassignment.Reassigned[0].OldReviewerID)
+
+		updated, err := prSvc.GetPR(ctx, pr.ID)
+		require.NoError(t, err)
+		assert.False(t, updated.HasReviewer(deactivated))
+		assert.Len(t, updated.AssignedReviewers, 2)
+	})
+
+	t.Run("NewTeam", func(t *testing.T) {
+		id := testID("user_fresh")
+		changes, err := teamSvc.ReplaceTeam(ctx, domain.NewTeam(testID("team_fresh"), []domain.User{
+			{ID: id, Username: "Fresh", IsActive: true},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{id}, changes.Added)
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		duplicated := append([]domain.User{}, members...)
+		duplicated = append(duplicated, members[0])
+		_, err := teamSvc.ReplaceTeam(ctx, domain.NewTeam(teamName, duplicated))
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+		// The stored lead is not enough: unlisted members get deactivated
+		team := domain.NewTeam(teamName, members)
+		team.Policy = &domain.TeamPolicy{MinReviewers: 1, MaxReviewers: 2, LeadUserID: &removed}
+		_, err = teamSvc.ReplaceTeam(ctx, team)
+		assert.ErrorIs(t, err, domain.ErrInvalidTeamPolicy)
+	})
+}
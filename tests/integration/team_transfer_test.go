@@ -0,0 +1,160 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"test_avito/internal/domain"
+	"test_avito/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamService_TransferUser(t *testing.T) {
+	teamSvc, userSvc, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	teamName, userIDs := setupTestTeam(t, ctx, teamSvc, 4)
+	otherTeam, otherIDs := setupTestTeam(t, ctx, teamSvc, 2)
+	asAlice := context.WithValue(domain.WithActor(ctx, "alice"), logger.RequestIDKey, "req-1")
+
+	pr, err := prSvc.CreatePR(ctx, testID("pr_transfer"), "Transfer PR", userIDs[0])
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 2)
+	moved := pr.AssignedReviewers[0]
+
+	t.Run("FailOnOpenReviews", func(t *testing.T) {
+		_, err := teamSvc.TransferUser(ctx, moved, otherTeam, domain.TransferFailOnReviews)
+		assert.ErrorIs(t, err, domain.ErrHasOpenReviews)
+
+		user, err := userSvc.GetUser(ctx, moved)
+		require.NoError(t, err)
+		assert.Equal(t, teamName, user.TeamName)
+	})
+
+	t.Run("Reassign", func(t *testing.T) {
+		result, err := teamSvc.TransferUser(asAlice, moved, otherTeam, domain.TransferReassignReviews)
+		require.NoError(t, err)
+		assert.Equal(t, teamName, result.FromTeam)
+		assert.Equal(t, otherTeam, result.User.TeamName)
+		require.Len(t, result.Reassignment.Reassigned, 1)
+		replacement := result.Reassignment.Reassigned[0]
+		assert.Equal(t, pr.ID, replacement.PullRequestID)
+		assert.Equal(t, moved, replacement.OldReviewerID)
+
+		// The replacement comes from the old team
+		updated, err := prSvc.GetPR(ctx, pr.ID)
+		require.NoError(t, err)
+		assert.False(t, updated.HasReviewer(moved))
+		assert.Contains(t, userIDs, replacement.NewReviewerID)
+
+		history, err := prSvc.GetHistory(ctx, pr.ID)
+		require.NoError(t, err)
+		last := history[len(history)-1]
+		assert.Equal(t, domain.PRHistoryReviewerReassigned, last.Type)
+		assert.Equal(t, moved, last.OldReviewerID)
+		assert.Equal(t, domain.ReasonTransfer, last.Reason)
+	})
+
+	t.Run("Keep", func(t *testing.T) {
+		kept := pr.AssignedReviewers[1]
+		result, err := teamSvc.TransferUser(ctx, kept, otherTeam, domain.TransferKeepReviews)
+		require.NoError(t, err)
+		assert.Empty(t, result.Reassignment.Reassigned)
+
+		updated, err := prSvc.GetPR(ctx, pr.ID)
+		require.NoError(t, err)
+		assert.True(t, updated.HasReviewer(kept))
+	})
+
+	t.Run("MembershipHistory", func(t *testing.T) {
+		history, err := userSvc.GetMembershipHistory(ctx, moved)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, teamName, history[0].FromTeam)
+		assert.Equal(t, otherTeam, history[0].ToTeam)
+		assert.Equal(t, domain.MembershipSourceTransfer, history[0].Source)
+		assert.Equal(t, domain.TransferReassignReviews, history[0].ReviewPolicy)
+		assert.Equal(t, "alice", history[0].Actor)
+		assert.Equal(t, "req-1", history[0].RequestID)
+
+		history, err = userSvc.GetMembershipHistory(ctx, otherIDs[0])
+		require.NoError(t, err)
+		assert.Empty(t, history)
+
+		_, err = userSvc.GetMembershipHistory(ctx, testID("missing"))
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("TeamAddMove", func(t *testing.T) {
+		members := []domain.User{{ID: otherIDs[1], Username: "User 1", IsActive: true}}
+		err := teamSvc.AddTeam(ctx, domain.NewTeam(teamName, members))
+		assert.ErrorIs(t, err, domain.ErrUserInOtherTeam)
+
+		user, err := userSvc.GetUser(ctx, otherIDs[1])
+		require.NoError(t, err)
+		assert.Equal(t, otherTeam, user.TeamName)
+
+		team := domain.NewTeam(teamName, members)
+		team.AllowMoves = true
+		team.MoveReviewPolicy = domain.TransferKeepReviews
+		require.NoError(t, teamSvc.AddTeam(ctx, team))
+
+		history, err := userSvc.GetMembershipHistory(ctx, otherIDs[1])
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, otherTeam, history[0].FromTeam)
+		assert.Equal(t, teamName, history[0].ToTeam)
+		assert.Equal(t, domain.MembershipSourceTeamAdd, history[0].Source)
+		assert.Equal(t, domain.TransferKeepReviews, history[0].ReviewPolicy)
+	})
+
+	t.Run("TeamAddMoveReviews", func(t *testing.T) {
+		kept := pr.AssignedReviewers[1]
+		team := domain.NewTeam(teamName, []domain.User{{ID: kept, Username: "Kept", IsActive: true}})
+		team.AllowMoves = true
+		team.MoveReviewPolicy = domain.TransferFailOnReviews
+		assert.ErrorIs(t, teamSvc.AddTeam(ctx, team), domain.ErrHasOpenReviews)
+
+		team.MoveReviewPolicy = domain.TransferReassignReviews
+		require.NoError(t, teamSvc.AddTeam(ctx, team))
+
+		updated, err := prSvc.GetPR(ctx, pr.ID)
+		require.NoError(t, err)
+		assert.False(t, updated.HasReviewer(kept))
+	})
+
+	t.Run("LeadCannotMove", func(t *testing.T) {
+		lead := otherIDs[0]
+		policy := &domain.TeamPolicy{MinReviewers: 1, MaxReviewers: 2, LeadUserID: &lead}
+		require.NoError(t, teamSvc.AddTeam(ctx, &domain.Team{Name: otherTeam, Policy: policy}))
+
+		_, err := teamSvc.TransferUser(ctx, lead, teamName, domain.TransferKeepReviews)
+		assert.ErrorIs(t, err, domain.ErrUserIsTeamLead)
+
+		team := domain.NewTeam(teamName, []domain.User{{ID: lead, Username: "Lead", IsActive: true}})
+		team.AllowMoves = true
+		team.MoveReviewPolicy = domain.TransferKeepReviews
+		assert.ErrorIs(t, teamSvc.AddTeam(ctx, team), domain.ErrUserIsTeamLead)
+
+		user, err := userSvc.GetUser(ctx, lead)
+		require.NoError(t, err)
+		assert.Equal(t, otherTeam, user.TeamName)
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		_, err := teamSvc.TransferUser(ctx, userIDs[0], teamName, domain.TransferKeepReviews)
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+		_, err = teamSvc.TransferUser(ctx, userIDs[0], otherTeam, "drop")
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+		_, err = teamSvc.TransferUser(ctx, userIDs[0], testID("missing"), domain.TransferKeepReviews)
+		assert.ErrorIs(t, err, domain.ErrTeamNotFound)
+
+		_, err = teamSvc.TransferUser(ctx, testID("missing"), otherTeam, domain.TransferKeepReviews)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
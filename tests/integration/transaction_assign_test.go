@@ -36,7 +36,7 @@ func TestAssignTransactions(t *testing.T) {
 				{ID: "reviewer-a3", Username: "reviewer3", IsActive: true},
 			},
 		}
-		err := teamRepo.CreateWithMembers(context.Background(), team)
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Create PR without reviewers
@@ -47,11 +47,11 @@ func TestAssignTransactions(t *testing.T) {
 			Status:            domain.PRStatusOpen,
 			AssignedReviewers: []string{},
 		}
-		err = prRepo.Create(context.Background(), pr)
+		err = prRepo.Create(context.Background(), pr, "")
 		require.NoError(t, err)
 
 		// Assign with empty list should succeed (assigns no one, PR still has no reviewers)
-		err = prRepo.AssignReviewers(context.Background(), "pr-assign-random", []string{})
+		err = prRepo.AssignReviewers(context.Background(), "pr-assign-random", []string{}, "")
 		require.NoError(t, err) // Should succeed - assigns nobody
 
 		// Verify PR still has no reviewers
@@ -77,7 +77,7 @@ func TestAssignTransactions(t *testing.T) {
 				{ID: "reviewer-a5", Username: "reviewer2", IsActive: true},
 			},
 		}
-		err := teamRepo.CreateWithMembers(context.Background(), team)
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Create PR without reviewers
@@ -88,11 +88,11 @@ func TestAssignTransactions(t *testing.T) {
 			Status:            domain.PRStatusOpen,
 			AssignedReviewers: []string{},
 		}
-		err = prRepo.Create(context.Background(), pr)
+		err = prRepo.Create(context.Background(), pr, "")
 		require.NoError(t, err)
 
 		// Assign specific reviewers
-		err = prRepo.AssignReviewers(context.Background(), "pr-assign-specific", []string{"reviewer-a4", "reviewer-a5"})
+		err = prRepo.AssignReviewers(context.Background(), "pr-assign-specific", []string{"reviewer-a4", "reviewer-a5"}, "")
 		require.NoError(t, err)
 
 		// Verify reviewers were assigned
@@ -120,7 +120,7 @@ func TestAssignTransactions(t *testing.T) {
 				{ID: "reviewer-a7", Username: "reviewer2", IsActive: true},
 			},
 		}
-		err := teamRepo.CreateWithMembers(context.Background(), team)
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Create PR with reviewers already assigned
@@ -131,11 +131,11 @@ func TestAssignTransactions(t *testing.T) {
 			Status:            domain.PRStatusOpen,
 			AssignedReviewers: []string{"reviewer-a6"},
 		}
-		err = prRepo.Create(context.Background(), pr)
+		err = prRepo.Create(context.Background(), pr, "")
 		require.NoError(t, err)
 
 		// Try to assign reviewers (should fail - PR already has reviewers)
-		err = prRepo.AssignReviewers(context.Background(), "pr-assign-error", []string{"reviewer-a7"})
+		err = prRepo.AssignReviewers(context.Background(), "pr-assign-error", []string{"reviewer-a7"}, "")
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, domain.ErrReviewersAlreadyAssigned)
 	})
@@ -152,7 +152,7 @@ func TestAssignTransactions(t *testing.T) {
 		defer cancel()
 		time.Sleep(10 * time.Millisecond) // Ensure timeout expires
 
-		err := prRepo.AssignReviewers(ctx, "pr-any", []string{"reviewer1"})
+		err := prRepo.AssignReviewers(ctx, "pr-any", []string{"reviewer1"}, "")
 		assert.Error(t, err, "Expected timeout error")
 	})
 
@@ -172,7 +172,7 @@ func TestAssignTransactions(t *testing.T) {
 				{ID: "reviewer-a8", Username: "reviewer1", IsActive: true},
 			},
 		}
-		err := teamRepo.CreateWithMembers(context.Background(), team)
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Create PR without reviewers
@@ -183,11 +183,11 @@ func TestAssignTransactions(t *testing.T) {
 			Status:            domain.PRStatusOpen,
 			AssignedReviewers: []string{},
 		}
-		err = prRepo.Create(context.Background(), pr)
+		err = prRepo.Create(context.Background(), pr, "")
 		require.NoError(t, err)
 
 		// Try to assign with invalid reviewer (empty ID)
-		err = prRepo.AssignReviewers(context.Background(), "pr-assign-rollback", []string{"reviewer-a8", ""})
+		err = prRepo.AssignReviewers(context.Background(), "pr-assign-rollback", []string{"reviewer-a8", ""}, "")
 		assert.Error(t, err)
 
 		// Verify PR still has no reviewers (transaction rolled back)
@@ -219,7 +219,7 @@ func TestAssignTransactions(t *testing.T) {
 			Name:    "test-team-assign-deadlock",
 			Members: members,
 		}
-		err := teamRepo.CreateWithMembers(context.Background(), team)
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Create multiple PRs without reviewers
@@ -232,7 +232,7 @@ func TestAssignTransactions(t *testing.T) {
 				Status:            domain.PRStatusOpen,
 				AssignedReviewers: []string{},
 			}
-			err = prRepo.Create(context.Background(), pr)
+			err = prRepo.Create(context.Background(), pr, "")
 			require.NoError(t, err)
 		}
 
@@ -250,7 +250,7 @@ func TestAssignTransactions(t *testing.T) {
 					fmt.Sprintf("reviewer-d%d", (idx+2)%5+1),
 					fmt.Sprintf("reviewer-d%d", (idx+1)%5+1),
 				}
-				errors[idx] = prRepo.AssignReviewers(context.Background(), prID, reviewers)
+				errors[idx] = prRepo.AssignReviewers(context.Background(), prID, reviewers, "")
 			}(i)
 		}
 
@@ -286,7 +286,7 @@ func TestAssignTransactions(t *testing.T) {
 				{ID: "reviewer-a10", Username: "reviewer2", IsActive: true},
 			},
 		}
-		err := teamRepo.CreateWithMembers(context.Background(), team)
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Create PR without reviewers
@@ -297,11 +297,11 @@ func TestAssignTransactions(t *testing.T) {
 			Status:            domain.PRStatusOpen,
 			AssignedReviewers: []string{},
 		}
-		err = prRepo.Create(context.Background(), pr)
+		err = prRepo.Create(context.Background(), pr, "")
 		require.NoError(t, err)
 
 		// Assign reviewers
-		err = prRepo.AssignReviewers(context.Background(), "pr-assign-commit", []string{"reviewer-a9", "reviewer-a10"})
+		err = prRepo.AssignReviewers(context.Background(), "pr-assign-commit", []string{"reviewer-a9", "reviewer-a10"}, "")
 		require.NoError(t, err)
 
 		// Verify transaction was committed (PR has reviewers)
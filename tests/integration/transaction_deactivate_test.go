@@ -33,7 +33,7 @@ func TestDeactivateTransactions(t *testing.T) {
 				{ID: "user-d3", Username: "user3", IsActive: true},
 			},
 		}
-		err := teamRepo.CreateWithMembers(context.Background(), team)
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Deactivate all team users
@@ -101,3 +101,121 @@ func TestDeactivateTransactions(t *testing.T) {
 		assert.Equal(t, 0, count, "Should deactivate 0 users")
 	})
 }
+
+// TestDeactivateWithReassignment tests reassignment of OPEN reviews on deactivation
+func TestDeactivateWithReassignment(t *testing.T) {
+	t.Run("DeactivateUser_ReassignsOpenReviews", func(t *testing.T) {
+		teamSvc, userSvc, prSvc, _, cleanup := setupTestServices(t)
+		defer cleanup()
+
+		ctx := context.Background()
+		_, userIDs := setupTestTeam(t, ctx, teamSvc, 4)
+
+		prID := testID("pr_deactivate")
+		pr, err := prSvc.CreatePR(ctx, prID, "Deactivate PR", userIDs[0])
+		require.NoError(t, err)
+		require.Len(t, pr.AssignedReviewers, 2)
+		leaving := pr.AssignedReviewers[0]
+
+		user, report, err := userSvc.Deactivate(ctx, leaving)
+		require.NoError(t, err)
+		assert.False(t, user.IsActive)
+		require.Len(t, report.Reassigned, 1)
+		assert.Empty(t, report.LeftShort)
+		assert.Equal(t, prID, report.Reassigned[0].PullRequestID)
+		assert.Equal(t, leaving, report.Reassigned[0].OldReviewerID)
+
+		reviews, err := prSvc.GetPRsByReviewer(ctx, report.Reassigned[0].NewReviewerID)
+		require.NoError(t, err)
+		require.Len(t, reviews, 1)
+		assert.Equal(t, prID, reviews[0].ID)
+
+		reviews, err = prSvc.GetPRsByReviewer(ctx, leaving)
+		require.NoError(t, err)
+		assert.Empty(t, reviews)
+	})
+
+	t.Run("DeactivateTeam_LeavesPRsShort", func(t *testing.T) {
+		teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
+		defer cleanup()
+
+		ctx := context.Background()
+		teamName, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+
+		prID := testID("pr_team_deactivate")
+		pr, err := prSvc.CreatePR(ctx, prID, "Team PR", userIDs[0])
+		require.NoError(t, err)
+		require.Len(t, pr.AssignedReviewers, 2)
+
+		_, count, report, err := teamSvc.DeactivateTeam(ctx, teamName)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+		assert.Empty(t, report.Reassigned)
+		assert.Len(t, report.LeftShort, 2)
+
+		for _, reviewerID := range pr.AssignedReviewers {
+			reviews, err := prSvc.GetPRsByReviewer(ctx, reviewerID)
+			require.NoError(t, err)
+			assert.Empty(t, reviews)
+		}
+	})
+
+	t.Run("DeactivateTeam_ReassignsFromAuthorTeam", func(t *testing.T) {
+		teamSvc, _, prSvc, _, cleanup := setupTestServices(t)
+		defer cleanup()
+
+		ctx := context.Background()
+		teamName, _ := setupTestTeam(t, ctx, teamSvc, 2)
+		_, otherIDs := setupTestTeam(t, ctx, teamSvc, 4)
+
+		prID := testID("pr_cross_team")
+		pr, err := prSvc.CreatePR(ctx, prID, "Cross team PR", otherIDs[0])
+		require.NoError(t, err)
+		require.Len(t, pr.AssignedReviewers, 2)
+
+		// The reviewer moves to the team being deactivated but keeps the review
+		moved := pr.AssignedReviewers[0]
+		_, err = teamSvc.TransferUser(ctx, moved, teamName, domain.TransferKeepReviews)
+		require.NoError(t, err)
+
+		_, _, report, err := teamSvc.DeactivateTeam(ctx, teamName)
+		require.NoError(t, err)
+		assert.Empty(t, report.LeftShort)
+		require.Len(t, report.Reassigned, 1)
+		assert.Equal(t, moved, report.Reassigned[0].OldReviewerID)
+		assert.Contains(t, otherIDs, report.Reassigned[0].NewReviewerID)
+		assert.NotContains(t, pr.AssignedReviewers, report.Reassigned[0].NewReviewerID)
+	})
+
+	t.Run("DeactivateUser_CountsPicksAgainstLimit", func(t *testing.T) {
+		teamSvc, userSvc, prSvc, _, cleanup := setupTestServices(t)
+		defer cleanup()
+
+		ctx := context.Background()
+		_, userIDs := setupTestTeam(t, ctx, teamSvc, 4)
+		spare := userIDs[3]
+		setLimit := func(limit int) {
+			t.Helper()
+			_, err := userSvc.UpdateUser(ctx, spare, domain.UserUpdate{MaxOpenReviews: domain.NewOptional(&limit)})
+			require.NoError(t, err)
+		}
+
+		// Keep the spare member off both PRs, so the third member reviews both
+		setLimit(0)
+		first, err := prSvc.CreatePR(ctx, testID("pr_limit"), "First", userIDs[0])
+		require.NoError(t, err)
+		second, err := prSvc.CreatePR(ctx, testID("pr_limit"), "Second", userIDs[1])
+		require.NoError(t, err)
+		leaving := userIDs[2]
+		require.True(t, first.HasReviewer(leaving))
+		require.True(t, second.HasReviewer(leaving))
+
+		// The spare member is the only candidate for both PRs but has room for one review
+		setLimit(1)
+		_, report, err := userSvc.Deactivate(ctx, leaving)
+		require.NoError(t, err)
+		require.Len(t, report.Reassigned, 1)
+		assert.Equal(t, spare, report.Reassigned[0].NewReviewerID)
+		assert.Len(t, report.LeftShort, 1)
+	})
+}
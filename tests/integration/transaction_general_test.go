@@ -36,7 +36,7 @@ func TestGeneralTransactions(t *testing.T) {
 		}
 
 		// Normal execution should work
-		err := repo.CreateWithMembers(context.Background(), team)
+		_, err := repo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Verify team was created
@@ -60,7 +60,7 @@ func TestGeneralTransactions(t *testing.T) {
 			},
 		}
 
-		err := repo.CreateWithMembers(context.Background(), team)
+		_, err := repo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Verify transaction committed successfully
@@ -91,7 +91,7 @@ func TestGeneralTransactions(t *testing.T) {
 						{ID: fmt.Sprintf("user-%d", idx), Username: fmt.Sprintf("user%d", idx), IsActive: true},
 					},
 				}
-				errors[idx] = repo.CreateWithMembers(context.Background(), team)
+				_, errors[idx] = repo.CreateWithMembers(context.Background(), team)
 			}(i)
 		}
 
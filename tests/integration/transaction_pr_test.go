@@ -35,7 +35,7 @@ func TestPRTransactions(t *testing.T) {
 				{ID: "reviewer2", Username: "reviewer2", IsActive: true},
 			},
 		}
-		err := teamRepo.CreateWithMembers(context.Background(), team)
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Create PR with reviewers
@@ -47,7 +47,7 @@ func TestPRTransactions(t *testing.T) {
 			AssignedReviewers: []string{"reviewer1", "reviewer2"},
 		}
 
-		err = prRepo.Create(context.Background(), pr)
+		err = prRepo.Create(context.Background(), pr, "")
 		require.NoError(t, err)
 
 		// Verify PR was created
@@ -77,7 +77,7 @@ func TestPRTransactions(t *testing.T) {
 			AssignedReviewers: []string{"reviewer1"},
 		}
 
-		err := prRepo.Create(ctx, pr)
+		err := prRepo.Create(ctx, pr, "")
 		assert.Error(t, err, "Expected timeout error")
 	})
 
@@ -96,7 +96,7 @@ func TestPRTransactions(t *testing.T) {
 				{ID: "author2", Username: "author", IsActive: true},
 			},
 		}
-		err := teamRepo.CreateWithMembers(context.Background(), team)
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Try to create PR with invalid reviewer (empty ID)
@@ -108,7 +108,7 @@ func TestPRTransactions(t *testing.T) {
 			AssignedReviewers: []string{""}, // Invalid empty reviewer ID
 		}
 
-		err = prRepo.Create(context.Background(), pr)
+		err = prRepo.Create(context.Background(), pr, "")
 		assert.Error(t, err, "Expected error due to invalid reviewer")
 
 		// Verify PR was NOT created (transaction rolled back)
@@ -135,7 +135,7 @@ func TestPRTransactions(t *testing.T) {
 				{ID: "rev3", Username: "reviewer3", IsActive: true},
 			},
 		}
-		err := teamRepo.CreateWithMembers(context.Background(), team)
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Create PRs concurrently with different reviewer orders
@@ -158,7 +158,7 @@ func TestPRTransactions(t *testing.T) {
 				if idx%2 == 0 {
 					pr.AssignedReviewers = []string{"rev2", "rev3", "rev1"}
 				}
-				errors[idx] = prRepo.Create(context.Background(), pr)
+				errors[idx] = prRepo.Create(context.Background(), pr, "")
 			}(i)
 		}
 
@@ -185,7 +185,7 @@ func TestPRTransactions(t *testing.T) {
 				{ID: "author4", Username: "author", IsActive: true},
 			},
 		}
-		err := teamRepo.CreateWithMembers(context.Background(), team)
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Create PR
@@ -196,17 +196,17 @@ func TestPRTransactions(t *testing.T) {
 			Status:            domain.PRStatusOpen,
 			AssignedReviewers: []string{},
 		}
-		err = prRepo.Create(context.Background(), pr)
+		err = prRepo.Create(context.Background(), pr, "")
 		require.NoError(t, err)
 
 		// Merge PR first time
-		mergedPR1, err := prRepo.Merge(context.Background(), "pr-merge")
+		mergedPR1, err := prRepo.Merge(context.Background(), "pr-merge", false)
 		require.NoError(t, err)
 		assert.Equal(t, domain.PRStatusMerged, mergedPR1.Status)
 		assert.NotNil(t, mergedPR1.MergedAt)
 
 		// Merge PR second time (should be idempotent)
-		mergedPR2, err := prRepo.Merge(context.Background(), "pr-merge")
+		mergedPR2, err := prRepo.Merge(context.Background(), "pr-merge", false)
 		require.NoError(t, err)
 		assert.Equal(t, domain.PRStatusMerged, mergedPR2.Status)
 		assert.NotNil(t, mergedPR2.MergedAt)
@@ -223,7 +223,7 @@ func TestPRTransactions(t *testing.T) {
 		prRepo := repository.NewPullRequestRepository(pool, logger)
 
 		// Try to merge non-existent PR
-		_, err := prRepo.Merge(context.Background(), "non-existent-pr")
+		_, err := prRepo.Merge(context.Background(), "non-existent-pr", false)
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, domain.ErrPRNotFound)
 	})
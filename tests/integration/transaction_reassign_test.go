@@ -35,7 +35,7 @@ func TestReassignTransactions(t *testing.T) {
 				{ID: "reviewer2", Username: "reviewer2", IsActive: true},
 			},
 		}
-		err := teamRepo.CreateWithMembers(context.Background(), team)
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Create PR with reviewer1
@@ -46,11 +46,11 @@ func TestReassignTransactions(t *testing.T) {
 			Status:            domain.PRStatusOpen,
 			AssignedReviewers: []string{"reviewer1"},
 		}
-		err = prRepo.Create(context.Background(), pr)
+		err = prRepo.Create(context.Background(), pr, "")
 		require.NoError(t, err)
 
 		// Reassign from reviewer1 to reviewer2
-		err = prRepo.ReassignReviewer(context.Background(), "pr-reassign", "reviewer1", "reviewer2")
+		err = prRepo.ReassignReviewer(context.Background(), "pr-reassign", "reviewer1", "reviewer2", "")
 		require.NoError(t, err)
 
 		// Verify reassignment
@@ -72,7 +72,7 @@ func TestReassignTransactions(t *testing.T) {
 		defer cancel()
 		time.Sleep(10 * time.Millisecond) // Ensure timeout expires
 
-		err := prRepo.ReassignReviewer(ctx, "pr-any", "old", "new")
+		err := prRepo.ReassignReviewer(ctx, "pr-any", "old", "new", "")
 		assert.Error(t, err, "Expected timeout error")
 		assert.Contains(t, err.Error(), "context")
 	})
@@ -93,7 +93,7 @@ func TestReassignTransactions(t *testing.T) {
 				{ID: "reviewer3", Username: "reviewer3", IsActive: true},
 			},
 		}
-		err := teamRepo.CreateWithMembers(context.Background(), team)
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Create PR with reviewer
@@ -104,11 +104,11 @@ func TestReassignTransactions(t *testing.T) {
 			Status:            domain.PRStatusOpen,
 			AssignedReviewers: []string{"reviewer3"},
 		}
-		err = prRepo.Create(context.Background(), pr)
+		err = prRepo.Create(context.Background(), pr, "")
 		require.NoError(t, err)
 
 		// Try to reassign to invalid reviewer (empty ID) - should fail and rollback
-		err = prRepo.ReassignReviewer(context.Background(), "pr-reassign-rollback", "reviewer3", "")
+		err = prRepo.ReassignReviewer(context.Background(), "pr-reassign-rollback", "reviewer3", "", "")
 		assert.Error(t, err, "Expected error due to invalid new reviewer")
 
 		// Verify original reviewer is still assigned (transaction rolled back)
@@ -141,7 +141,7 @@ func TestReassignTransactions(t *testing.T) {
 			Name:    "test-team-concurrent-reassign",
 			Members: members,
 		}
-		err := teamRepo.CreateWithMembers(context.Background(), team)
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Create multiple PRs with different reviewers
@@ -154,7 +154,7 @@ func TestReassignTransactions(t *testing.T) {
 				Status:            domain.PRStatusOpen,
 				AssignedReviewers: []string{fmt.Sprintf("rev%d", i+1)},
 			}
-			err = prRepo.Create(context.Background(), pr)
+			err = prRepo.Create(context.Background(), pr, "")
 			require.NoError(t, err)
 		}
 
@@ -169,7 +169,7 @@ func TestReassignTransactions(t *testing.T) {
 				prID := fmt.Sprintf("pr-concurrent-reassign-%d", idx)
 				oldReviewer := fmt.Sprintf("rev%d", idx+1)
 				newReviewer := fmt.Sprintf("rev%d", idx+6) // Different reviewer
-				errors[idx] = prRepo.ReassignReviewer(context.Background(), prID, oldReviewer, newReviewer)
+				errors[idx] = prRepo.ReassignReviewer(context.Background(), prID, oldReviewer, newReviewer, "")
 			}(i)
 		}
 
@@ -206,7 +206,7 @@ func TestReassignTransactions(t *testing.T) {
 				{ID: "reviewer5", Username: "reviewer5", IsActive: true},
 			},
 		}
-		err := teamRepo.CreateWithMembers(context.Background(), team)
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Create PR
@@ -217,11 +217,11 @@ func TestReassignTransactions(t *testing.T) {
 			Status:            domain.PRStatusOpen,
 			AssignedReviewers: []string{"reviewer4"},
 		}
-		err = prRepo.Create(context.Background(), pr)
+		err = prRepo.Create(context.Background(), pr, "")
 		require.NoError(t, err)
 
 		// Reassign reviewer
-		err = prRepo.ReassignReviewer(context.Background(), "pr-atomic", "reviewer4", "reviewer5")
+		err = prRepo.ReassignReviewer(context.Background(), "pr-atomic", "reviewer4", "reviewer5", "")
 		require.NoError(t, err)
 
 		// Verify atomicity - exactly one reviewer, the new one
@@ -233,4 +233,73 @@ func TestReassignTransactions(t *testing.T) {
 		// Verify old reviewer is completely removed
 		assert.NotContains(t, finalPR.AssignedReviewers, "reviewer4", "Old reviewer should be removed")
 	})
+
+	t.Run("ReassignReviewer_ConcurrentSameReviewer", func(t *testing.T) {
+		pool, cleanup := setupTestDB(t)
+		defer cleanup()
+
+		logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+		teamRepo := repository.NewTeamRepository(pool, logger)
+		prRepo := repository.NewPullRequestRepository(pool, logger)
+
+		team := &domain.Team{
+			Name: "test-team-same-reassign",
+			Members: []domain.User{
+				{ID: "author6", Username: "author", IsActive: true},
+				{ID: "reviewer6", Username: "reviewer6", IsActive: true},
+				{ID: "reviewer7", Username: "reviewer7", IsActive: true},
+				{ID: "reviewer8", Username: "reviewer8", IsActive: true},
+			},
+		}
+		_, err := teamRepo.CreateWithMembers(context.Background(), team)
+		require.NoError(t, err)
+
+		pr := &domain.PullRequest{
+			ID:                "pr-same-reassign",
+			Name:              "Test Same Reassign",
+			AuthorID:          "author6",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"reviewer6"},
+		}
+		err = prRepo.Create(context.Background(), pr, "")
+		require.NoError(t, err)
+
+		// Both replace reviewer6; the second one must see it already gone instead of adding a second reviewer
+		var wg sync.WaitGroup
+		newReviewers := []string{"reviewer7", "reviewer8"}
+		errs := make([]error, len(newReviewers))
+		for i, newReviewer := range newReviewers {
+			wg.Add(1)
+			go func(idx int, newReviewer string) {
+				defer wg.Done()
+				errs[idx] = prRepo.ReassignReviewer(context.Background(), "pr-same-reassign", "reviewer6", newReviewer, "")
+			}(i, newReviewer)
+		}
+		wg.Wait()
+
+		succeeded := 0
+		for _, err := range errs {
+			if err == nil {
+				succeeded++
+				continue
+			}
+			assert.ErrorIs(t, err, domain.ErrReviewerNotFound)
+		}
+		assert.Equal(t, 1, succeeded)
+
+		finalPR, err := prRepo.GetByID(context.Background(), "pr-same-reassign")
+		require.NoError(t, err)
+		assert.Len(t, finalPR.AssignedReviewers, 1)
+		assert.NotContains(t, finalPR.AssignedReviewers, "reviewer6")
+
+		// Swapping in someone already on the PR would shrink it by one reviewer
+		err = prRepo.ReassignReviewer(context.Background(), "pr-same-reassign", finalPR.AssignedReviewers[0], finalPR.AssignedReviewers[0], "")
+		assert.ErrorIs(t, err, domain.ErrAlreadyReviewer)
+
+		// Reviewers of a merged PR can't change
+		_, err = prRepo.Merge(context.Background(), "pr-same-reassign", false)
+		require.NoError(t, err)
+		err = prRepo.ReassignReviewer(context.Background(), "pr-same-reassign", finalPR.AssignedReviewers[0], "reviewer6", "")
+		assert.ErrorIs(t, err, domain.ErrPRMerged)
+	})
 }
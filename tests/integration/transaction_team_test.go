@@ -33,7 +33,7 @@ func TestTeamTransactions(t *testing.T) {
 			},
 		}
 
-		err := repo.CreateWithMembers(context.Background(), team)
+		_, err := repo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Verify team and members were created
@@ -62,7 +62,7 @@ func TestTeamTransactions(t *testing.T) {
 			},
 		}
 
-		err := repo.CreateWithMembers(ctx, team)
+		_, err := repo.CreateWithMembers(ctx, team)
 		assert.Error(t, err, "Expected timeout error")
 		assert.Contains(t, err.Error(), "context")
 	})
@@ -82,11 +82,11 @@ func TestTeamTransactions(t *testing.T) {
 		}
 
 		// First creation should succeed
-		err := repo.CreateWithMembers(context.Background(), team)
+		_, err := repo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Second creation should fail due to unique constraint
-		err = repo.CreateWithMembers(context.Background(), team)
+		_, err = repo.CreateWithMembers(context.Background(), team)
 		assert.Error(t, err, "Expected duplicate key error")
 	})
 
@@ -104,7 +104,7 @@ func TestTeamTransactions(t *testing.T) {
 				{ID: "user1", Username: "alice", IsActive: true},
 			},
 		}
-		err := repo.CreateWithMembers(context.Background(), firstTeam)
+		_, err := repo.CreateWithMembers(context.Background(), firstTeam)
 		require.NoError(t, err)
 
 		// Try to create duplicate team - should fail on team creation
@@ -116,7 +116,7 @@ func TestTeamTransactions(t *testing.T) {
 			},
 		}
 
-		err = repo.CreateWithMembers(context.Background(), duplicateTeam)
+		_, err = repo.CreateWithMembers(context.Background(), duplicateTeam)
 		assert.Error(t, err, "Expected error due to duplicate team")
 
 		// Verify user2 was NOT created (transaction rolled back)
@@ -140,7 +140,7 @@ func TestTeamTransactions(t *testing.T) {
 				{ID: "user1", Username: "alice", IsActive: true},
 			},
 		}
-		err := repo.CreateWithMembers(context.Background(), team)
+		_, err := repo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Update members - UPSERT will add new members
@@ -148,7 +148,7 @@ func TestTeamTransactions(t *testing.T) {
 			{ID: "user2", Username: "bob", TeamName: "test-team-update", IsActive: true},
 			{ID: "user3", Username: "charlie", TeamName: "test-team-update", IsActive: true},
 		}
-		err = repo.UpdateMembers(context.Background(), "test-team-update", newMembers)
+		_, err = repo.UpdateMembers(context.Background(), domain.NewTeam("test-team-update", newMembers))
 		require.NoError(t, err)
 
 		// Verify members were added (UPSERT adds, doesn't replace)
@@ -157,6 +157,37 @@ func TestTeamTransactions(t *testing.T) {
 		assert.GreaterOrEqual(t, len(updatedTeam.Members), 2, "Should have at least 2 members")
 	})
 
+	t.Run("UpdateMembers_PolicyRollsBackMembers", func(t *testing.T) {
+		pool, cleanup := setupTestDB(t)
+		defer cleanup()
+
+		logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+		repo := repository.NewTeamRepository(pool, logger)
+
+		team := &domain.Team{
+			Name: "test-team-policy",
+			Members: []domain.User{
+				{ID: "user1", Username: "alice", IsActive: true},
+			},
+		}
+		_, err := repo.CreateWithMembers(context.Background(), team)
+		require.NoError(t, err)
+
+		// The lead doesn't exist, so the policy violates the foreign key after the members are upserted
+		missing := "missing-lead"
+		update := domain.NewTeam("test-team-policy", []domain.User{
+			{ID: "user2", Username: "bob", IsActive: true},
+		})
+		update.Policy = &domain.TeamPolicy{MinReviewers: 1, MaxReviewers: 2, LeadUserID: &missing}
+		_, err = repo.UpdateMembers(context.Background(), update)
+		require.Error(t, err)
+
+		stored, err := repo.GetByName(context.Background(), "test-team-policy")
+		require.NoError(t, err)
+		assert.Len(t, stored.Members, 1)
+		assert.Nil(t, stored.Policy.LeadUserID)
+	})
+
 	t.Run("UpdateMembers_ConcurrentUpdates", func(t *testing.T) {
 		pool, cleanup := setupTestDB(t)
 		defer cleanup()
@@ -171,7 +202,7 @@ func TestTeamTransactions(t *testing.T) {
 				{ID: "user1", Username: "alice", IsActive: true},
 			},
 		}
-		err := repo.CreateWithMembers(context.Background(), team)
+		_, err := repo.CreateWithMembers(context.Background(), team)
 		require.NoError(t, err)
 
 		// Run concurrent updates
@@ -186,7 +217,7 @@ func TestTeamTransactions(t *testing.T) {
 				members := []domain.User{
 					{ID: string(rune('a' + idx)), Username: string(rune('a' + idx)), IsActive: true},
 				}
-				errors[idx] = repo.UpdateMembers(context.Background(), "test-team-concurrent", members)
+				_, errors[idx] = repo.UpdateMembers(context.Background(), domain.NewTeam("test-team-concurrent", members))
 			}(i)
 		}
 
@@ -49,10 +49,49 @@ func setupTestDB(t *testing.T) (*pgxpool.Pool, func()) {
 	return pool, cleanup
 }
 
+// testRepos are the repositories the test services are wired with
+type testRepos struct {
+	Pool   *pgxpool.Pool
+	Logger *slog.Logger
+	Team   repository.TeamRepository
+	User   repository.UserRepository
+	PR     repository.PullRequestRepository
+	Stats  repository.StatsRepository
+}
+
+type testConfig struct {
+	emitter  func(repos testRepos) service.EventEmitter
+	codeHost func(repos testRepos) service.CodeHostClient
+	repos    *testRepos
+}
+
+// testOption customizes setupTestServices
+type testOption func(*testConfig)
+
+// withEmitter wires the services with the emitter built from the test repositories instead of NopEmitter
+func withEmitter(build func(repos testRepos) service.EventEmitter) testOption {
+	return func(c *testConfig) { c.emitter = build }
+}
+
+// withCodeHost wires the services with the code host client built from the test repositories instead of NopCodeHost
+func withCodeHost(build func(repos testRepos) service.CodeHostClient) testOption {
+	return func(c *testConfig) { c.codeHost = build }
+}
+
+// withRepos exposes the test repositories to the test
+func withRepos(dst *testRepos) testOption {
+	return func(c *testConfig) { c.repos = dst }
+}
+
 // setupTestServices creates all services with test database
-func setupTestServices(t *testing.T) (*service.TeamService, *service.UserService, *service.PullRequestService, *service.StatsService, func()) {
+func setupTestServices(t *testing.T, opts ...testOption) (*service.TeamService, *service.UserService, *service.PullRequestService, *service.StatsService, func()) {
 	t.Helper()
 
+	var cfg testConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	pool, cleanup := setupTestDB(t)
 
 	// Create logger for tests (discard output)
@@ -61,16 +100,35 @@ func setupTestServices(t *testing.T) (*service.TeamService, *service.UserService
 	}))
 
 	// Create repositories
-	teamRepo := repository.NewTeamRepository(pool, testLogger)
-	userRepo := repository.NewUserRepository(pool, testLogger)
-	prRepo := repository.NewPullRequestRepository(pool, testLogger)
-	statsRepo := repository.NewStatsRepository(pool, testLogger)
+	repos := testRepos{
+		Pool:   pool,
+		Logger: testLogger,
+		Team:   repository.NewTeamRepository(pool, testLogger),
+		User:   repository.NewUserRepository(pool, testLogger),
+		PR:     repository.NewPullRequestRepository(pool, testLogger),
+		Stats:  repository.NewStatsRepository(pool, testLogger),
+	}
+	if cfg.repos != nil {
+		*cfg.repos = repos
+	}
+
+	var emitter service.EventEmitter = service.NopEmitter{}
+	if cfg.emitter != nil {
+		emitter = cfg.emitter(repos)
+	}
+	var codeHost service.CodeHostClient = service.NopCodeHost{}
+	if cfg.codeHost != nil {
+		codeHost = cfg.codeHost(repos)
+	}
+
+	selector, err := service.NewReviewerSelector(service.StrategyLeastLoaded, repos.Team)
+	require.NoError(t, err)
 
 	// Create services
-	teamService := service.NewTeamService(teamRepo, userRepo, testLogger)
-	userService := service.NewUserService(userRepo, testLogger)
-	prService := service.NewPullRequestService(prRepo, userRepo, testLogger)
-	statsService := service.NewStatsService(statsRepo, testLogger)
+	teamService := service.NewTeamService(repos.Team, repos.User, emitter, codeHost, testLogger)
+	userService := service.NewUserService(repos.User, emitter, codeHost, testLogger)
+	prService := service.NewPullRequestService(repos.PR, repos.User, repos.Team, selector, emitter, codeHost, testLogger)
+	statsService := service.NewStatsService(repos.Stats, testLogger)
 
 	return teamService, userService, prService, statsService, cleanup
 }
@@ -81,6 +139,10 @@ func cleanupTestData(t *testing.T, pool *pgxpool.Pool) {
 	ctx := context.Background()
 
 	// Delete in correct order due to foreign keys
+	_, _ = pool.Exec(ctx, "DELETE FROM webhook_subscriptions")
+	_, _ = pool.Exec(ctx, "DELETE FROM outbox")
+	_, _ = pool.Exec(ctx, "DELETE FROM event_stream")
+	_, _ = pool.Exec(ctx, "DELETE FROM pr_events")
 	_, _ = pool.Exec(ctx, "DELETE FROM reviewers")
 	_, _ = pool.Exec(ctx, "DELETE FROM pull_requests")
 	_, _ = pool.Exec(ctx, "DELETE FROM users")
@@ -154,3 +154,70 @@ func TestUserService_ConcurrentActivation(t *testing.T) {
 		}
 	})
 }
+
+func TestUserService_ReviewCapacity(t *testing.T) {
+	teamSvc, userSvc, prSvc, _, cleanup := setupTestServices(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+	author, busy, free := userIDs[0], userIDs[1], userIDs[2]
+
+	zero := 0
+	user, err := userSvc.UpdateUser(ctx, busy, domain.UserUpdate{
+		MaxOpenReviews: domain.NewOptional(&zero),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, user.MaxOpenReviews)
+	assert.Equal(t, 0, *user.MaxOpenReviews)
+
+	t.Run("AutoAssignmentSkipsUserAtCapacity", func(t *testing.T) {
+		pr, err := prSvc.CreatePR(ctx, testID("pr_capacity"), "Capacity PR", author)
+		require.NoError(t, err)
+		assert.Equal(t, []string{free}, pr.AssignedReviewers)
+	})
+
+	t.Run("ExplicitAssignmentRejected", func(t *testing.T) {
+		// Create a PR without reviewers: the only free teammate is temporarily inactive
+		emptyID := testID("pr_empty")
+		_, err := userSvc.SetIsActive(ctx, free, false)
+		require.NoError(t, err)
+		_, err = prSvc.CreatePR(ctx, emptyID, "Empty PR", author)
+		require.NoError(t, err)
+		_, err = userSvc.SetIsActive(ctx, free, true)
+		require.NoError(t, err)
+
+		_, err = prSvc.AssignReviewersToPR(ctx, emptyID, []string{busy})
+		assert.ErrorIs(t, err, domain.ErrReviewerAtCapacity)
+	})
+
+	t.Run("RemoveLimit", func(t *testing.T) {
+		user, err := userSvc.UpdateUser(ctx, busy, domain.UserUpdate{
+			MaxOpenReviews: domain.NewOptional[int](nil),
+		})
+		require.NoError(t, err)
+		assert.Nil(t, user.MaxOpenReviews)
+	})
+
+	t.Run("NegativeLimitRejected", func(t *testing.T) {
+		negative := -1
+		_, err := userSvc.UpdateUser(ctx, busy, domain.UserUpdate{
+			MaxOpenReviews: domain.NewOptional(&negative),
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+
+	t.Run("TooLargeLimitRejected", func(t *testing.T) {
+		tooLarge := domain.MaxOpenReviewsLimit + 1
+		_, err := userSvc.UpdateUser(ctx, busy, domain.UserUpdate{
+			MaxOpenReviews: domain.NewOptional(&tooLarge),
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+		members := []domain.User{{ID: busy, Username: "Busy", IsActive: true, MaxOpenReviews: &tooLarge}}
+		user, err := userSvc.GetUser(ctx, busy)
+		require.NoError(t, err)
+		err = teamSvc.AddTeam(ctx, domain.NewTeam(user.TeamName, members))
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+}
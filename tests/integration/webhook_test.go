@@ -0,0 +1,222 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"test_avito/internal/domain"
+	"test_avito/internal/repository"
+	"test_avito/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookReceiver records requests and answers with status
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(r.status)
+}
+
+func (r *webhookReceiver) received() ([]*http.Request, [][]byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests, r.bodies
+}
+
+func setupWebhookTest(t *testing.T) (*service.TeamService, *service.PullRequestService, *service.WebhookService, func()) {
+	t.Helper()
+
+	var webhookService *service.WebhookService
+	teamService, _, prService, _, cleanup := setupTestServices(t, withEmitter(func(repos testRepos) service.EventEmitter {
+		webhookService = service.NewWebhookService(repository.NewWebhookRepository(repos.Pool, repos.Logger), service.WebhookOptions{
+			MaxAttempts: 3,
+			BackoffBase: time.Minute,
+			Timeout:     time.Second,
+		}, repos.Logger)
+		return webhookService
+	}))
+
+	return teamService, prService, webhookService, cleanup
+}
+
+func TestWebhookService_SignedDelivery(t *testing.T) {
+	teamSvc, prSvc, webhookSvc, cleanup := setupWebhookTest(t)
+	defer cleanup()
+
+	receiver := &webhookReceiver{status: http.StatusOK}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	ctx := context.Background()
+	sub, err := webhookSvc.Subscribe(ctx, &domain.WebhookSubscription{
+		URL:    srv.URL,
+		Secret: "s3cret",
+		Events: []domain.EventType{domain.EventPRMerged},
+	})
+	require.NoError(t, err)
+
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 3)
+	prID := testID("pr_webhook")
+	_, err = prSvc.CreatePR(ctx, prID, "Webhook PR", userIDs[0])
+	require.NoError(t, err)
+	_, err = prSvc.MergePR(ctx, prID, true)
+	require.NoError(t, err)
+
+	// Deliveries are queued with the database clock; a second ahead absorbs clock skew
+	delivered, err := webhookSvc.DeliverDue(ctx, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered, "only the subscribed event type is delivered")
+
+	requests, bodies := receiver.received()
+	require.Len(t, requests, 1)
+	assert.Equal(t, string(domain.EventPRMerged), requests[0].Header.Get(service.WebhookEventHeader))
+	assert.Equal(t, service.SignWebhookPayload("s3cret", bodies[0]), requests[0].Header.Get(service.WebhookSignatureHeader))
+
+	var event struct {
+		Type domain.EventType   `json:"type"`
+		Data domain.PREventData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(bodies[0], &event))
+	assert.Equal(t, domain.EventPRMerged, event.Type)
+	assert.Equal(t, prID, event.Data.PR.ID)
+	assert.Equal(t, domain.PRStatusMerged, event.Data.PR.Status)
+
+	t.Run("DeliveredOnce", func(t *testing.T) {
+		delivered, err := webhookSvc.DeliverDue(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+	})
+
+	t.Run("Unsubscribe", func(t *testing.T) {
+		require.NoError(t, webhookSvc.Unsubscribe(ctx, sub.ID))
+		assert.ErrorIs(t, webhookSvc.Unsubscribe(ctx, sub.ID), domain.ErrWebhookNotFound)
+	})
+
+	t.Run("InvalidSubscription", func(t *testing.T) {
+		_, err := webhookSvc.Subscribe(ctx, &domain.WebhookSubscription{URL: "ftp://example.com", Secret: "x"})
+		assert.ErrorIs(t, err, domain.ErrInvalidWebhook)
+
+		_, err = webhookSvc.Subscribe(ctx, &domain.WebhookSubscription{
+			URL:    srv.URL,
+			Secret: "x",
+			Events: []domain.EventType{"pr.unknown"},
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidWebhook)
+	})
+}
+
+func TestWebhookService_RetryAndDeadLetter(t *testing.T) {
+	teamSvc, prSvc, webhookSvc, cleanup := setupWebhookTest(t)
+	defer cleanup()
+
+	receiver := &webhookReceiver{status: http.StatusInternalServerError}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	ctx := context.Background()
+	sub, err := webhookSvc.Subscribe(ctx, &domain.WebhookSubscription{
+		URL:    srv.URL,
+		Secret: "s3cret",
+		Events: []domain.EventType{domain.EventPRCreated},
+	})
+	require.NoError(t, err)
+
+	_, userIDs := setupTestTeam(t, ctx, teamSvc, 2)
+	_, err = prSvc.CreatePR(ctx, testID("pr_webhook_fail"), "Failing webhook PR", userIDs[0])
+	require.NoError(t, err)
+
+	// MaxAttempts=3, BackoffBase=1m: attempts at now, now+1m and now+3m
+	now := time.Now().Add(time.Second)
+	for _, offset := range []time.Duration{0, 30 * time.Second, time.Minute, 3 * time.Minute} {
+		delivered, err := webhookSvc.DeliverDue(ctx, now.Add(offset))
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+	}
+
+	requests, _ := receiver.received()
+	assert.Len(t, requests, 3, "the delivery is not retried before its backoff expires")
+
+	letters, err := webhookSvc.ListDeadLetters(ctx, &sub.ID)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	assert.Equal(t, domain.EventPRCreated, letters[0].EventType)
+	assert.Equal(t, 3, letters[0].Attempts)
+	assert.Contains(t, letters[0].LastError, "500")
+
+	t.Run("Redeliver", func(t *testing.T) {
+		receiver.mu.Lock()
+		receiver.status = http.StatusNoContent
+		receiver.mu.Unlock()
+
+		deadLetterID := letters[0].ID
+		_, err := webhookSvc.Redeliver(ctx, deadLetterID)
+		require.NoError(t, err)
+
+		delivered, err := webhookSvc.DeliverDue(ctx, time.Now().Add(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+
+		remaining, err := webhookSvc.ListDeadLetters(ctx, &sub.ID)
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+
+		_, err = webhookSvc.Redeliver(ctx, deadLetterID)
+		assert.ErrorIs(t, err, domain.ErrDeadLetterNotFound)
+	})
+}
+
+func TestWebhookRepository_DeleteDelivered(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	repo := repository.NewWebhookRepository(pool, logger)
+	ctx := context.Background()
+
+	sub, err := repo.CreateSubscription(ctx, &domain.WebhookSubscription{URL: "http://example.com/hook", Secret: "s3cret"})
+	require.NoError(t, err)
+	require.NoError(t, repo.EnqueueDeliveries(ctx, []int64{sub.ID, sub.ID}, domain.EventPRMerged, []byte(`{}`)))
+
+	now := time.Now().Add(time.Second)
+	deliveries, err := repo.ClaimDueDeliveries(ctx, now, now.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+
+	deliveredAt := now.Add(-time.Hour)
+	require.NoError(t, repo.MarkDelivered(ctx, deliveries[0].ID, deliveredAt))
+
+	deleted, err := repo.DeleteDelivered(ctx, deliveredAt)
+	require.NoError(t, err)
+	assert.Zero(t, deleted, "deliveries are kept for the whole retention")
+
+	deleted, err = repo.DeleteDelivered(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	// A pending delivery is never removed, however old it is
+	pending, err := repo.ClaimDueDeliveries(ctx, now.Add(2*time.Minute), now.Add(3*time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, deliveries[1].ID, pending[0].ID)
+}